@@ -62,6 +62,15 @@ functions as follows:
 	Status        Reports on the number of backup snapshots and the space used
 				overall and by each snapshot.
 
+	History     Reports the transfer statistics recorded for each snapshot
+				and restore showing trends across runs.
+
+	Check       Reports the health of a backup set for a monitoring system
+				with Nagios/Icinga plugin exit codes and performance data.
+
+	Policy      Simulates the retention policy on synthetic snapshots to
+				preview the consequences of changing it.
+
 	Vet         Parses a backup configuration file identifying any errors
 				or problems without making any attempts at any operations.
 
@@ -85,6 +94,32 @@ functions as follows:
 	// Purge the oldest 5 snapshots from a backup set.
 	    szbck prune -n 5 config.szb
 
+	// Write the snapshots trim would purge for review then purge exactly
+	// those once approved.
+	    szbck trim --plan plan.json config.szb
+	    szbck trim --apply plan.json config.szb
+
+	// Report the transfer statistics of the last 10 runs.
+	    szbck history -n 10 config.szb
+
+	// List what changed in the last hour.
+	    szbck diff config.szb -1h latest
+
+	// Find every copy of a spreadsheet deleted since the latest snapshot.
+	    szbck find --deleted config.szb '*.xlsx'
+
+	// Restore a deleted directory without touching anything else.
+	    szbck undelete -r docs/taxes config.szb
+
+	// Compare a file from yesterday's snapshot with the live copy.
+	    szbck cat -s -1d config.szb etc/foo.conf | diff - /etc/foo.conf
+
+	// Alert from a monitoring system if the latest snapshot is stale.
+	    szbck check --warn 2h --crit 6h config.szb
+
+	// Preview keeping 90 days of daily snapshots before changing keepDaily.
+	    szbck policy simulate config.szb --period 400d --keep-daily 90d
+
 	// Vet changes made to a config.szb file.
 	    szbck vet config.szb
 
@@ -65,6 +65,15 @@ functions as follows:
 	Status        Reports on the number of backup snapshots and the space used
 				overall and by each snapshot.
 
+	History     Reports the transfer statistics recorded for each snapshot
+				and restore showing trends across runs.
+
+	Check       Reports the health of a backup set for a monitoring system
+				with Nagios/Icinga plugin exit codes and performance data.
+
+	Policy      Simulates the retention policy on synthetic snapshots to
+				preview the consequences of changing it.
+
 	Vet         Parses a backup configuration file identifying any errors
 				or problems without making any attempts at any operations.
 
@@ -74,6 +83,16 @@ functions as follows:
 	will perform the actual snapshots.  One of the following sub commands must
 	be provided as follows:
 
+	   [--output {text | json | csv}]
+	      Selects the output format for any sub command.  Json writes a single
+	      structured result (one per run in snapshot --daemon mode) to stdout
+	      for snapshot, restore, diff and every sub command accepting csv.  Csv
+	      is available for the tabular results of prune, trim, status, vet,
+	      policy, history, find, undelete and ls-tree.  The help, create, cat
+	      and check sub commands only write text (cat writes the file's
+	      contents unchanged).  Rsync's own output is written to stderr.
+	      Defaults to text.
+
 	{h | help} [subcommand]
 
 	Help information is displayed.
@@ -97,10 +116,28 @@ functions as follows:
 	    source
 	        Specifies the root directory to back up.
 
-	{s | snap | snapshot} [--dry-run] [--daemon [--at minute] [--monitor]] [--trim] [-t target] config.szb
+	{s | snap | snapshot} [--dry-run] [--daemon [--at minute] [--monitor]] [--trim] [--progress] [-t target] config.szb
 
 	Create a new snapshot of the source listed in the configuration file located
-	in the target directory.
+	in the target directory.  Before each snapshot an rsync dry run estimates the
+	bytes and iNodes required.  The snapshot is aborted if the target does not
+	have enough free space.  The estimate is reported with the run summary.
+	While rsync is running the target's free space is checked periodically
+	against the configured minFreeBytes and minFreeINodes floors.  If either is
+	crossed rsync is stopped, the partial snapshot is renamed with a .failed
+	extension and latest continues to point at the previous snapshot.  If rsync
+	exits with a code listed by a warningExitCode entry (IE: 24 some source files
+	vanished) the snapshot is still completed and latest updated.  The outcome of
+	each snapshot, including any files that vanished or could not be read, is
+	appended to the szbck.journal file in the target directory.  The transfer
+	statistics reported by rsync (files examined and transferred, literal and
+	matched data, bytes sent, speedup and elapsed time) are displayed with the
+	run summary and recorded in the journal.  See the history subcommand.
+	Rsync is run with any configured cpuNice and ioPriority scheduling priority
+	and the rate limit of the first bandwidth window containing the start time.
+	If rsync runs longer than the configured maxRunTime or writes no output or
+	progress for stallTimeout it (and every process it started) is killed and
+	the snapshot is marked as failed.
 
 	   [--dry-run]
 	      Identifies all of the actions the utility would take without making any
@@ -121,7 +158,15 @@ functions as follows:
 
 	   [--trim]
 	      Executes the retention policy as specified in the configuration file
-	      after the snapshot has been successfully completed.
+	      after the snapshot has been successfully completed.  If the target does
+	      not have enough free space for the estimated snapshot the retention
+	      policy is also executed before the snapshot in an attempt to free space.
+
+	   [--progress]
+	      Displays the overall progress of the transfer (percent complete, bytes
+	      transferred, rate and estimated time remaining).  When standard output
+	      is a terminal a single line is updated in place otherwise a progress
+	      line is logged every 30 seconds.
 
 	   [-t target]
 	      Specifies the backup set to create the new snapshot in.  It is optional
@@ -131,21 +176,81 @@ functions as follows:
 	   config.sbc
 	      The backup configuration file defining the backup.
 
-	{r | rest | restore} [--dry-run] [--keep] [-s snapshot] [-t target] config.szb
-
-	Restores the specified file or directory tree from the backup.
+	{r | rest | restore} [--dry-run] [--preview [--plan file]] [--keep] [--progress] [-s snapshot | --as-of time | --undo | --version N] [-o dir [--delete]] [-t target] config.szb [path]
+
+	Restores the specified file or directory tree from the backup.  By default
+	the restore overwrites the configured source.  With -o the snapshot subtree
+	is restored into an alternate directory instead (still honouring the
+	configured options, exclusions and restoreOptions) so old versions can be
+	inspected side by side with the live data.  The transfer statistics reported
+	by rsync are displayed and, unless it is a dry run, the restore is recorded
+	in the target's szbck.journal file.  Rsync is run with any configured cpuNice
+	and ioPriority scheduling priority and the rate limit of the first bandwidth
+	window containing the start time.  If rsync runs longer than the configured
+	maxRunTime or writes no output or progress for stallTimeout it (and every
+	process it started) is killed.  Before any restore that overwrites the
+	source (not a dry run and not with -o) a safety snapshot of the current
+	source is taken and tagged 'pre-restore' so the restore can be undone.
 
 	   [--dry-run]
 	      Identifies all of the actions the utility would take without making any
 	      changes to the backup source.
 
+	   [--preview]
+	      Displays the changes the restore would make without making any
+	      changes.  They are grouped into files to be created, overwritten (with
+	      their size and modification time differences), deleted from the
+	      destination and permission only changes followed by their totals.  No
+	      safety snapshot is taken.
+
+	   [--plan file]
+	      Writes the --preview plan to the file.  An argument error occurs if
+	      specified without --preview.
+
 	   [--keep]
 	      Blocks the restore from deleting source files missing from the target
 	      backup.
 
+	   [--progress]
+	      Displays the overall progress of the transfer (percent complete, bytes
+	      transferred, rate and estimated time remaining).  When standard output
+	      is a terminal a single line is updated in place otherwise a progress
+	      line is logged every 30 seconds.
+
 	   [-s snapshot]
 	      Specifies the specif snapshot in the target directory to use.  It will
-	      default to the symbolic link 'latest' is not provided.
+	      default to the symbolic link 'latest' is not provided.  The snapshot
+	      may be followed by a path to restore only part of it (IE:
+	      latest/user/docs).  Instead of a snapshot directory name it may be a
+	      selector: 'latest~N' (N snapshots before latest), 'now', 'yesterday',
+	      a time before now (-30m, -3h, -2d or -1w) or a local time formatted
+	      as 'YYYY-MM-DD HH:MM' or 'YYYY-MM-DD' (the end of that day).  A time
+	      selects the newest snapshot taken at or before it.
+
+	   [--as-of time]
+	      Selects the newest snapshot taken at or before the time using any of
+	      the selectors accepted by -s (IE: --as-of "2026-10-01 14:00").  An
+	      argument error occurs if specified with -s.
+
+	   [--undo]
+	      Restores the 'pre-restore' safety snapshot taken before the last
+	      restore using the same options and exclusions, undoing it.  An
+	      argument error occurs if specified with -s or --as-of.
+
+	   [--version N]
+	      Restores version N of the path as listed by the history subcommand
+	      (IE: szbck restore --version 3 config.szb docs/report.txt).  An
+	      argument error occurs if specified with -s, --as-of or --undo or
+	      without a path.
+
+	   [-o dir]
+	      Restores into the specified directory instead of the configured source.
+	      The directory is created if it does not exist.  Existing files in it
+	      are only deleted if --delete is also specified.
+
+	   [--delete]
+	      Deletes files in the -o directory missing from the snapshot subtree.
+	      An argument error occurs if specified without -o.
 
 	   [-t target]
 	      Specifies the backup set to restore from.  It is optional if the backup
@@ -155,6 +260,10 @@ functions as follows:
 	   config.sbc
 	      the backup configuration file defining the backup.
 
+	   [path]
+	      The file restored by --version.  It is either relative to the
+	      configured source or an absolute path within it.
+
 	{p | prune} [--dry-run] [-n {number | all}] [-t target] config.szb
 
 	Deletes the oldest backups.  Defaults to 1.
@@ -175,9 +284,46 @@ functions as follows:
 	   config.sbc
 	      the backup configuration file defining the backup.
 
-	{stat | status} [-t target] config.szb
-
-	Reports the status on the specified backup set.
+	{stat | status} [--sort {date | exclusive}] [--if-deleted snapshot[,snapshot...]] [--forecast] [--retention] [-t target] config.szb
+
+	Reports the status on the specified backup set.  Each snapshot is listed
+	(newest first) with its total bytes followed by the bytes it does not share
+	with the next older snapshot (in parentheses) and the bytes that would be
+	freed if it were deleted (in brackets).  The freed bytes only count files
+	whose every hard link is inside that snapshot.  The target is measured in a
+	single pass counting each file once no matter how many snapshots hard link
+	it.  The number of directories read concurrently is set by duWorkers in the
+	backup config file.
+
+	   [--sort {date | exclusive}]
+	      Orders the snapshots newest first (date) or by the bytes freed if they
+	      were deleted (exclusive) largest first.  Defaults to date.
+
+	   [--if-deleted snapshot[,snapshot...]]
+	      Also reports the combined bytes freed if all of the listed snapshots
+	      were deleted.  Files hard linked only by the listed snapshots are
+	      freed even if they are shared between them.  Each snapshot may be any
+	      selector accepted by restore's -s option (IE: latest~2 or -1w).
+
+	   [--forecast]
+	      Also reports the rate the target is growing, fitted to the bytes each
+	      snapshot added (its bytes not shared with the next older snapshot)
+	      over the times they were taken, and the days until the target's free
+	      bytes are used up at that rate.  The size the retention policy settles
+	      at is projected as the latest snapshot plus keepDaily of growth.  As
+	      weekly snapshots are kept indefinitely the target continues to grow
+	      by about a week of growth each week.  The projection is an upper
+	      bound as files changed more than once between the retained snapshots
+	      are freed by trim.
+
+	   [--retention]
+	      Also reports the retention decision trim would make for each snapshot
+	      (newest first): whether it is kept or purged and the rule responsible.
+	      A snapshot is kept as the latest, within keepHourly, as the newest of
+	      its day within keepDaily, as the newest of its ISO week or if pinned
+	      by a tag.  Each kept snapshot's projected expiry assumes snapshots
+	      continue to be taken hourly.  Snapshots kept for their ISO week or
+	      pinned never expire.
 
 	   [-t target]
 	      Specifies the backup set to create the new snapshot in.  It is optional
@@ -187,16 +333,214 @@ functions as follows:
 	   config.sbc
 	      The backup configuration file defining the backup.
 
-	{t | trim} [--dry-run] [-t target] config.szb
+	{hist | history} [-n number] [-t target] config.szb [path]
+
+	Reports the transfer statistics recorded in the target's journal for each
+	snapshot and restore showing trends across runs.  The number of files
+	examined and transferred, the literal data sent, the total bytes sent and
+	the elapsed time are listed for each run followed by the snapshot averages.
+	Unlike the free space deltas reported by a snapshot the literal data
+	separates new file data from metadata churn.
+
+	If a path is specified the versions of that file in the snapshots are
+	listed instead.  Consecutive snapshots holding the same copy of the file
+	(the same inode) are grouped into a single version numbered from 1 for the
+	oldest.  The first and last snapshot holding each version are listed along
+	with its size, modification time and mode.  A version is restored with the
+	restore subcommand's --version option.
+
+	   [-n number]
+	      Limits the report to the specified number of most recent runs (or
+	      versions).
+
+	   [-t target]
+	      Specifies the backup set to report on.  It is optional if the backup
+	      config file specifies a target and mandatory if not specified in the
+	      backup config file.
+
+	   config.sbc
+	      The backup configuration file defining the backup.
+
+	   [path]
+	      The file to list the versions of.  It is either relative to the
+	      configured source or an absolute path within it.
+
+	{d | diff} [-p path]... [--summary] [-t target] config.szb [snapA [snapB]]
+
+	Lists the paths added (+), removed (-) and modified (M) between two
+	snapshots followed by a summary of their number and sizes.  By default the
+	snapshot before latest is compared with latest.  Files hard linked between
+	the snapshots (the same inode) are unchanged without further checks as
+	unchanged files are linked to the previous snapshot.  Other files are
+	modified if their type, permissions, size, modification time or link target
+	differ.
+
+	   [-p path]
+	      Limits the comparison to the path (relative to the snapshot IE:
+	      source/docs) and everything below it.  It may be repeated.
+
+	   [--summary]
+	      Only displays the summary.
+
+	   [-t target]
+	      Specifies the backup set to compare.  It is optional if the backup
+	      config file specifies a target and mandatory if not specified in the
+	      backup config file.
+
+	   config.sbc
+	      The backup configuration file defining the backup.
+
+	   [snapA]
+	      The snapshot to compare from.  It defaults to 'latest~1'.  It may be a
+	      snapshot directory name or any selector accepted by the restore
+	      subcommand's -s option (IE: -1h, yesterday or latest~3).
+
+	   [snapB]
+	      The snapshot to compare to.  It defaults to 'latest'.
+
+	{f | find} [--regex] [--deleted] [-t target] config.szb pattern
+
+	Searches every snapshot for files whose path relative to the source matches
+	the pattern.  A copy of a file hard linked between snapshots (the same
+	inode) is reported once with the first and last snapshot holding it and the
+	number of snapshots it is in.  The matches are listed by path oldest copy
+	first.
+
+	   [--regex]
+	      Treats the pattern as a regular expression matched against the whole
+	      path relative to the source (IE: '^docs/.*\.pdf$').
+
+	   [--deleted]
+	      Only reports files missing from the latest snapshot that exist in an
+	      older snapshot.
+
+	   [-t target]
+	      Specifies the backup set to search.  It is optional if the backup
+	      config file specifies a target and mandatory if not specified in the
+	      backup config file.
+
+	   config.sbc
+	      The backup configuration file defining the backup.
+
+	   pattern
+	      The glob pattern (IE: '*.txt' or 'docs/report-*.pdf') to match.  A
+	      pattern without a path separator matches the name of the file
+	      anywhere in the source otherwise the whole path relative to the
+	      source.
+
+	{u | undelete} [--latest] [--all | -r path]... [--dry-run] [-t target] config.szb [subpath]
+
+	Lists the files and directories missing from the source that are held by an
+	older snapshot along with the last snapshot holding them.  Only the top
+	most missing directory is listed.  Specifying --all or -r restores the
+	items from the last snapshot holding them into their original locations.
+	Nothing else in the source is changed (files are never deleted) and each
+	restore is recorded in the target's szbck.journal file.
+
+	   [--latest]
+	      Lists the items missing from the latest snapshot instead of the
+	      source.
+
+	   [--all]
+	      Restores every item listed.
+
+	   [-r path]
+	      Restores the listed item (relative to the source IE: docs/old.txt).
+	      It may be repeated.  An argument error occurs if specified with --all
+	      or if the path is not listed.
+
+	   [--dry-run]
+	      Identifies all of the actions the utility would take without making
+	      any changes to the source.
+
+	   [-t target]
+	      Specifies the backup set to search.  It is optional if the backup
+	      config file specifies a target and mandatory if not specified in the
+	      backup config file.
+
+	   config.sbc
+	      The backup configuration file defining the backup.
+
+	   [subpath]
+	      Limits the search to the path (relative to the source or an absolute
+	      path within it) and everything below it.
+
+	cat [-s snapshot] [-t target] config.szb path
+
+	Writes the file held by a snapshot to standard output without any other
+	output so it may be compared with the current version (IE: diff <(szbck cat
+	config.szb etc/foo.conf) /etc/foo.conf).  Symbolic links are not followed.
+
+	   [-s snapshot]
+	      Specifies the snapshot holding the file.  It defaults to 'latest'.  It
+	      may be a snapshot directory name or any selector accepted by the
+	      restore subcommand's -s option (IE: -1h, yesterday or latest~3).
+
+	   [-t target]
+	      Specifies the backup set holding the file.  It is optional if the
+	      backup config file specifies a target and mandatory if not specified
+	      in the backup config file.
+
+	   config.sbc
+	      The backup configuration file defining the backup.
+
+	   path
+	      The file to write.  It is either relative to the configured source or
+	      an absolute path within it.
+
+	{ls | ls-tree} [-s snapshot] [-t target] config.szb [path]
+
+	Lists the contents of a directory held by a snapshot in the style of ls -l:
+	the type and permissions, the number of hard links, the owner, the group,
+	the size, the modification time and the name (with the target of symbolic
+	links).  Unchanged files are hard linked to the other snapshots holding them
+	so the number of links counts the snapshots sharing the copy.
+
+	   [-s snapshot]
+	      Specifies the snapshot to list.  It defaults to 'latest'.  It may be a
+	      snapshot directory name or any selector accepted by the restore
+	      subcommand's -s option (IE: -1h, yesterday or latest~3).
+
+	   [-t target]
+	      Specifies the backup set to list.  It is optional if the backup config
+	      file specifies a target and mandatory if not specified in the backup
+	      config file.
+
+	   config.sbc
+	      The backup configuration file defining the backup.
+
+	   [path]
+	      The directory (or file) to list.  It is either relative to the
+	      configured source or an absolute path within it.  It defaults to the
+	      source.
+
+	{t | trim} [--dry-run] [--plan plan.json | --apply plan.json] [-t target] config.szb
 
 	Implements the specified retention policy as defined in the backup
 	configuration file deleting backups as appropriate. The most recent snapshot
-	pointed to by the "latest" symbolic link is never deleted.
+	pointed to by the "latest" symbolic link is never deleted.  Neither is a
+	snapshot pinned by a tag (any other symbolic link in the target pointing at
+	it except the pre-restore link maintained by restore).  Each snapshot is
+	listed with the rule that kept or purged it and, if kept, its projected
+	expiry assuming snapshots continue to be taken hourly.
 
 	   [--dry-run]
 	      Identifies all of the actions the utility would take without making any
 	      changes to the backup source.
 
+	   [--plan plan.json]
+	      Writes the snapshots the retention policy would purge now, with the
+	      reason for each, to the plan file without purging anything.  The
+	      snapshots only kept as they are pinned by a tag are also listed.  The
+	      plan may be reviewed before it is applied.
+
+	   [--apply plan.json]
+	      Purges exactly the snapshots listed in the plan file.  The plan must
+	      have been written for the same target and every snapshot it lists must
+	      still exist and be neither latest nor pinned.  Nothing is purged if
+	      any snapshot fails these checks.  It may be combined with --dry-run to
+	      only validate the plan.
+
 	   [-t target]
 	      Specifies the backup set to prune.  It is optional if the backup config
 	      file specifies a target and mandatory if not specified in the backup
@@ -207,11 +551,89 @@ functions as follows:
 
 	{v | vet} config.szb
 
-	Loads and parses the named configuration files reporting any issues.
+	Loads and parses the named configuration files reporting any issues.  With
+	structured output (--output json or csv) every problem is reported with its
+	line number instead of stopping at the first one.
 
 	   config.sbc
 	      the backup configuration file defining the backup.
 
+	check [--warn age] [--crit age] [--warn-full age] [-t target] config.szb
+
+	Checks the health of the backup set for a monitoring system (Nagios, Icinga
+	or any compatible plugin runner) writing a single line stating the overall
+	status, any problems found and performance data following a '|'.  The exit
+	code is 0 (OK), 1 (WARNING), 2 (CRITICAL) or 3 (UNKNOWN if the backup set
+	could not be checked).
+
+	The age of the latest snapshot (taken from its name) is compared with the
+	thresholds and a backup set without one is critical.  A failed last
+	snapshot recorded in the journal is critical and one accepting a warning
+	exit code is a warning.  Free bytes or iNodes on the target below the
+	minFreeBytes or minFreeINodes floors in the backup config file are
+	critical.  Snapshots take no lock file.  Instead a snapshot directory newer
+	than latest shows a snapshot is running and is a warning.  One older than
+	the critical age was abandoned by a killed snapshot and is critical.  The
+	number of these unfinished snapshots and of the snapshots renamed as failed
+	is included in the performance data.
+
+	   [--warn age]
+	      The age of the latest snapshot that is a warning.  It is a number
+	      followed by m (minutes), h (hours), d (days) or w (weeks).  Defaults
+	      to 2h (twice the hourly snapshot interval) allowing one missed
+	      snapshot.
+
+	   [--crit age]
+	      The age of the latest snapshot that is critical.  It must be greater
+	      than the warning age.  Defaults to 4h (four times the hourly snapshot
+	      interval) allowing three missed snapshots.
+
+	   [--warn-full age]
+	      Warns if the target is forecast to be full within the age (IE: 30d).
+	      The forecast fits the growth rate to the bytes transferred by the
+	      successful snapshots in the journal.  The days until full are always
+	      included in the performance data once there are two successful
+	      snapshots and the target is growing.
+
+	   [-t target]
+	      Specifies the backup set to check.  It is optional if the backup
+	      config file specifies a target and mandatory if not specified in the
+	      backup config file.
+
+	   config.sbc
+	      The backup configuration file defining the backup.
+
+	policy simulate [--period duration] [--interval duration] [--keep-hourly duration] [--keep-daily duration] config.szb
+
+	Simulates the retention policy in the backup config file without reading or
+	removing any snapshots.  Synthetic snapshots are taken every interval from
+	the start of today for the period and trimmed at the end of each day
+	exactly as trim would.  The number of snapshots remaining at the end of
+	each day is reported along with how many are still within keepDaily.  The
+	steady state is the number within keepDaily once it stops changing.  As a
+	snapshot is kept for each ISO week indefinitely the total continues to grow
+	by one each week.  Finally every snapshot surviving the period is listed
+	with the rule that kept it.  Each duration is a number followed by m
+	(minutes), h (hours), d (days) or w (weeks).
+
+	   [--period duration]
+	      The length of time to simulate.  Defaults to 400d.
+
+	   [--interval duration]
+	      The time between snapshots.  It must not exceed the period.  Defaults
+	      to 1h matching the snapshot daemon.
+
+	   [--keep-hourly duration]
+	      Replaces keepHourly from the backup config file to preview a change
+	      before making it.  It must be at least 24h.
+
+	   [--keep-daily duration]
+	      Replaces keepDaily from the backup config file to preview a change
+	      before making it.  It must be at least 48h and longer than keepHourly.
+
+	   config.sbc
+	      The backup configuration file defining the retention policy.
+
 # Examples:
 
 	// Display help on the utility and all sub commands.
@@ -230,6 +652,32 @@ functions as follows:
 	// Purge the oldest 5 snapshots from a backup set.
 	    szbck prune -n 5 config.szb
 
+	// Write the snapshots trim would purge for review then purge exactly
+	// those once approved.
+	    szbck trim --plan plan.json config.szb
+	    szbck trim --apply plan.json config.szb
+
+	// Report the transfer statistics of the last 10 runs.
+	    szbck history -n 10 config.szb
+
+	// List what changed in the last hour.
+	    szbck diff config.szb -1h latest
+
+	// Find every copy of a spreadsheet deleted since the latest snapshot.
+	    szbck find --deleted config.szb '*.xlsx'
+
+	// Restore a deleted directory without touching anything else.
+	    szbck undelete -r docs/taxes config.szb
+
+	// Compare a file from yesterday's snapshot with the live copy.
+	    szbck cat -s -1d config.szb etc/foo.conf | diff - /etc/foo.conf
+
+	// Alert from a monitoring system if the latest snapshot is stale.
+	    szbck check --warn 2h --crit 6h config.szb
+
+	// Preview keeping 90 days of daily snapshots before changing keepDaily.
+	    szbck policy simulate config.szb --period 400d --keep-daily 90d
+
 	// Vet changes made to a config.szb file.
 	    szbck vet config.szb
 
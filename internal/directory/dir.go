@@ -21,7 +21,6 @@ package directory
 import (
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -54,8 +53,10 @@ func Is(dir string) error {
 	return fmt.Errorf("%w: '%s'", err, dir)
 }
 
-// IsEmpty confirms that a "new" target directory is empty.
-func IsEmpty(dir string) error {
+// IsEmpty confirms that a "new" target directory is empty.  A lost+found
+// directory and any entries ending with one of the ignore suffixes provided
+// are disregarded.
+func IsEmpty(dir string, ignore ...string) error {
 	var (
 		itemDir *os.File
 		items   []string
@@ -69,20 +70,28 @@ func IsEmpty(dir string) error {
 			_ = itemDir.Close()
 		}()
 
-		items, err = itemDir.Readdirnames(1)
+		items, err = itemDir.Readdirnames(0)
 	}
 
-	if err == nil {
-		if len(items) != 1 || !strings.HasSuffix(items[0], "lost+found") {
+	for i, mi := 0, len(items); i < mi && err == nil; i++ {
+		if !hasSuffix(items[i], append(ignore, "lost+found")) {
 			err = ErrNewNotEmpty
 		}
-	} else if errors.Is(err, io.EOF) {
-		return nil
 	}
 
 	return err
 }
 
+func hasSuffix(name string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // LinkRelative create the provided symbolic link path to the supplied
 // directory path.
 func LinkRelative(fromDir, toLink string) error {
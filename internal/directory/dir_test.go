@@ -101,6 +101,29 @@ func TestDirectory_IsEmpty(t *testing.T) {
 	)
 }
 
+func TestDirectory_IsEmpty_Ignore(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpSubDir("target")
+
+	_ = chk.CreateTmpSubDir(dir, "snapshot.failed")
+
+	chk.Err(
+		directory.IsEmpty(dir),
+		directory.ErrNewNotEmpty.Error(),
+	)
+
+	chk.NoErr(directory.IsEmpty(dir, ".failed"))
+
+	_ = chk.CreateTmpSubDir(dir, "snapshot.szb")
+
+	chk.Err(
+		directory.IsEmpty(dir, ".failed"),
+		directory.ErrNewNotEmpty.Error(),
+	)
+}
+
 func TestDirectory_Link(t *testing.T) {
 	chk := sztestlog.CaptureNothing(t)
 	defer chk.Release()
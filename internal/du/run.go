@@ -19,17 +19,25 @@
 package du
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/dancsecs/szbck/internal/directory"
 )
 
-// Run executes du with the supplied arguments.
-func Run(args []string, cpyErr *os.File) (string, error) {
+// killGrace is how long to wait for output to be closed after du's process
+// group is killed.
+const killGrace = time.Second * 10
+
+// Run executes du with the supplied arguments.  Du is started in its own
+// process group which is killed if the context is done.
+func Run(ctx context.Context, args []string, cpyErr *os.File) (string, error) {
 	var (
 		duPath string
 		cmd    *exec.Cmd
@@ -51,7 +59,12 @@ func Run(args []string, cpyErr *os.File) (string, error) {
 	}
 
 	if err == nil {
-		cmd = exec.Command(duPath, args...) //nolint:gosec // Ok.
+		cmd = exec.CommandContext(ctx, duPath, args...) //nolint:gosec // Ok.
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		cmd.Cancel = func() error {
+			return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		}
+		cmd.WaitDelay = killGrace
 
 		if cpyErr != nil {
 			outErr, err = cmd.StderrPipe()
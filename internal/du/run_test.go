@@ -19,6 +19,8 @@
 package du_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -31,7 +33,7 @@ func TestDuRun_InvalidDirectory(t *testing.T) {
 	chk := sztestlog.CaptureLogAndStderrAndStdout(t)
 	defer chk.Release()
 
-	out, err := du.Run(nil, os.Stderr)
+	out, err := du.Run(context.Background(), nil, os.Stderr)
 
 	chk.Err(
 		err,
@@ -44,7 +46,7 @@ func TestDuRun_InvalidDirectory(t *testing.T) {
 	)
 	chk.Str(out, "")
 
-	out, err = du.Run([]string{""}, os.Stderr)
+	out, err = du.Run(context.Background(), []string{""}, os.Stderr)
 
 	chk.Err(
 		err,
@@ -58,7 +60,9 @@ func TestDuRun_InvalidDirectory(t *testing.T) {
 	)
 	chk.Str(out, "")
 
-	out, err = du.Run([]string{"INVALID_DIRECTORY"}, os.Stderr)
+	out, err = du.Run(
+		context.Background(), []string{"INVALID_DIRECTORY"}, os.Stderr,
+	)
 
 	chk.Err(
 		err,
@@ -83,7 +87,9 @@ func TestDuRun_EmptyDirectory(t *testing.T) {
 
 	dir := chk.CreateTmpDir()
 
-	out, err := du.Run([]string{"-b", "-d", "1", dir}, os.Stderr)
+	out, err := du.Run(
+		context.Background(), []string{"-b", "-d", "1", dir}, os.Stderr,
+	)
 
 	chk.NoErr(err)
 
@@ -94,3 +100,23 @@ func TestDuRun_EmptyDirectory(t *testing.T) {
 	chk.Stdout()
 	chk.Stderr()
 }
+
+func TestDuRun_Canceled(t *testing.T) {
+	chk := sztestlog.CaptureLogAndStderrAndStdout(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out, err := du.Run(ctx, []string{"-b", dir}, os.Stderr)
+
+	chk.True(errors.Is(err, du.ErrDuError))
+	chk.True(errors.Is(err, context.Canceled))
+	chk.Str(out, "")
+
+	chk.Log()
+	chk.Stdout()
+	chk.Stderr()
+}
@@ -0,0 +1,412 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package du
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/dancsecs/szbck/internal/directory"
+)
+
+// DefaultWorkers is the number of directories read concurrently when a
+// worker count is not provided.
+const DefaultWorkers = 4
+
+// wordBits is the number of snapshots tracked by each word of a bit set.
+const wordBits = 64
+
+// fileID uniquely identifies an iNode.
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+// linked tracks an iNode with more than one link.
+type linked struct {
+	size      int64
+	links     uint64   // Links reported by the iNode.
+	seen      uint64   // Links found in the target.
+	outside   bool     // A link was found outside of the snapshots.
+	snapshots []uint64 // Bit set of the snapshots holding a link.
+}
+
+// within reports if every link to the iNode was found in the snapshots.
+func (l *linked) within(snapshots []uint64) bool {
+	if l.outside || l.seen < l.links {
+		return false
+	}
+
+	held := false
+
+	for i, word := range l.snapshots {
+		if word&^snapshots[i] != 0 {
+			return false
+		}
+
+		held = held || word != 0
+	}
+
+	return held
+}
+
+// entry is a single item found while walking a directory.
+type entry struct {
+	id    fileID
+	size  int64
+	links uint64
+}
+
+// SnapshotUsage is the disk usage of a single snapshot.
+type SnapshotUsage struct {
+	// Dir is the snapshot's directory.
+	Dir string
+	// Total bytes used by the snapshot counting each iNode once.
+	Total int64
+	// Changed bytes not hard linked to the next older snapshot.  It is the
+	// snapshot's total for the oldest snapshot.
+	Changed int64
+	// Exclusive bytes freed if the snapshot is deleted.  These are the
+	// iNodes whose every link is inside the snapshot.
+	Exclusive int64
+}
+
+// Usage is the disk usage of a target and its snapshots.
+type Usage struct {
+	// Snapshots in the order they were provided.
+	Snapshots []SnapshotUsage
+	// Total bytes used by everything in the target counting each iNode once.
+	Total int64
+
+	direct []int64
+	linked map[fileID]*linked
+	words  int
+}
+
+// walker accumulates the usage found by concurrently walking a target.
+type walker struct {
+	ctx    context.Context //nolint:containedctx // Ok.
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	words  int
+	direct []int64 // Bytes of iNodes with a single link by snapshot.
+	other  int64   // Bytes of single link iNodes outside of snapshots.
+	linked map[fileID]*linked
+	err    error
+}
+
+// newWalker returns a walker for the number of snapshots.
+func newWalker(ctx context.Context, snapshots, workers int) *walker {
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+
+	return &walker{
+		ctx:    ctx,
+		sem:    make(chan struct{}, workers),
+		words:  (snapshots + wordBits - 1) / wordBits,
+		direct: make([]int64, snapshots),
+		linked: make(map[fileID]*linked),
+	}
+}
+
+// identify returns the entry describing the item.
+func identify(info fs.FileInfo) entry {
+	item := entry{size: info.Size(), links: 1}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		item.id = fileID{dev: stat.Dev, ino: stat.Ino}
+		item.links = uint64(stat.Nlink) //nolint:unconvert // Ok.
+	}
+
+	// Directories cannot be hard linked.
+	if info.IsDir() {
+		item.links = 1
+	}
+
+	return item
+}
+
+// add records the items found in a directory belonging to the snapshot (or
+// to the target when snapshot is negative).
+func (w *walker) add(snapshot int, items []entry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, item := range items {
+		switch {
+		case item.links <= 1 && snapshot < 0:
+			w.other += item.size
+		case item.links <= 1:
+			w.direct[snapshot] += item.size
+		default:
+			found, ok := w.linked[item.id]
+			if !ok {
+				found = &linked{
+					size:      item.size,
+					links:     item.links,
+					snapshots: make([]uint64, w.words),
+				}
+				w.linked[item.id] = found
+			}
+
+			found.seen++
+
+			if snapshot < 0 {
+				found.outside = true
+			} else {
+				set(found.snapshots, snapshot)
+			}
+		}
+	}
+}
+
+// fail records the first error encountered.
+func (w *walker) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// failed reports if the walk should stop.
+func (w *walker) failed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err == nil && w.ctx.Err() != nil {
+		w.err = w.ctx.Err()
+	}
+
+	return w.err != nil
+}
+
+// walk reads the directory recording its items and walking its sub
+// directories.
+func (w *walker) walk(dir string, snapshot int) {
+	var (
+		entries []fs.DirEntry
+		info    fs.FileInfo
+		items   []entry
+		subDirs []string
+		err     error
+	)
+
+	if w.failed() {
+		return
+	}
+
+	entries, err = os.ReadDir(dir)
+
+	for i := 0; i < len(entries) && err == nil; i++ {
+		info, err = entries[i].Info()
+		if err == nil {
+			items = append(items, identify(info))
+
+			if info.IsDir() {
+				subDirs = append(subDirs, filepath.Join(dir, info.Name()))
+			}
+		}
+	}
+
+	if err != nil {
+		w.fail(err)
+
+		return
+	}
+
+	w.add(snapshot, items)
+
+	for _, subDir := range subDirs {
+		w.spawn(subDir, snapshot)
+	}
+}
+
+// spawn walks the directory with a new worker if one is available or in line
+// if not.
+func (w *walker) spawn(dir string, snapshot int) {
+	select {
+	case w.sem <- struct{}{}:
+		w.wg.Add(1)
+
+		go func() {
+			defer w.wg.Done()
+			defer func() { <-w.sem }()
+
+			w.walk(dir, snapshot)
+		}()
+	default:
+		w.walk(dir, snapshot)
+	}
+}
+
+// set adds the snapshot to the bit set.
+func set(snapshots []uint64, snapshot int) {
+	snapshots[snapshot/wordBits] |= 1 << (snapshot % wordBits)
+}
+
+// has reports if the bit set includes the snapshot.
+func has(snapshots []uint64, snapshot int) bool {
+	return snapshots[snapshot/wordBits]&(1<<(snapshot%wordBits)) != 0
+}
+
+// usage totals the walk's results.
+func (w *walker) usage(snapshots []string) Usage {
+	result := Usage{
+		Snapshots: make([]SnapshotUsage, len(snapshots)),
+		Total:     w.other,
+		direct:    w.direct,
+		linked:    w.linked,
+		words:     w.words,
+	}
+
+	for i, dir := range snapshots {
+		result.Snapshots[i] = SnapshotUsage{
+			Dir:       dir,
+			Total:     w.direct[i],
+			Changed:   w.direct[i],
+			Exclusive: w.direct[i],
+		}
+		result.Total += w.direct[i]
+	}
+
+	only := make([]uint64, w.words)
+
+	for _, found := range w.linked {
+		result.Total += found.size
+
+		for i := range snapshots {
+			if !has(found.snapshots, i) {
+				continue
+			}
+
+			result.Snapshots[i].Total += found.size
+
+			if i == 0 || !has(found.snapshots, i-1) {
+				result.Snapshots[i].Changed += found.size
+			}
+
+			clear(only)
+			set(only, i)
+
+			if found.within(only) {
+				result.Snapshots[i].Exclusive += found.size
+			}
+		}
+	}
+
+	return result
+}
+
+// Reclaimable returns the bytes freed if all of the snapshots (identified by
+// their index) were deleted.  These are the iNodes whose every link is
+// inside the deleted snapshots.
+func (u Usage) Reclaimable(snapshots ...int) int64 {
+	var (
+		deleted = make([]uint64, u.words)
+		freed   int64
+	)
+
+	for _, snapshot := range snapshots {
+		if !has(deleted, snapshot) {
+			set(deleted, snapshot)
+
+			freed += u.direct[snapshot]
+		}
+	}
+
+	for _, found := range u.linked {
+		if found.within(deleted) {
+			freed += found.size
+		}
+	}
+
+	return freed
+}
+
+// Measure walks the target once returning the disk usage of the target and
+// each of its snapshot directories (ordered oldest to newest).  Sizes are
+// apparent sizes (as reported by du -b) and each iNode is counted once no
+// matter how many times it is hard linked.  The workers limit the number of
+// directories read concurrently.
+func Measure(
+	ctx context.Context, trg string, snapshots []string, workers int,
+) (Usage, error) {
+	var (
+		info    fs.FileInfo
+		entries []fs.DirEntry
+		index   = make(map[string]int, len(snapshots))
+		w       = newWalker(ctx, len(snapshots), workers)
+		err     error
+	)
+
+	for i, dir := range snapshots {
+		index[filepath.Clean(dir)] = i
+	}
+
+	trg = filepath.Clean(trg)
+	err = directory.Is(trg)
+
+	if err == nil {
+		info, err = os.Lstat(trg)
+	}
+
+	if err == nil {
+		w.add(-1, []entry{identify(info)})
+		entries, err = os.ReadDir(trg)
+	}
+
+	for i := 0; i < len(entries) && err == nil; i++ {
+		info, err = entries[i].Info()
+		if err == nil {
+			path := filepath.Join(trg, info.Name())
+
+			snapshot, ok := index[path]
+			if !ok {
+				snapshot = -1
+			}
+
+			w.add(snapshot, []entry{identify(info)})
+
+			if info.IsDir() {
+				w.spawn(path, snapshot)
+			}
+		}
+	}
+
+	w.wg.Wait()
+
+	if err == nil {
+		err = w.err
+	}
+
+	if err == nil {
+		return w.usage(snapshots), nil
+	}
+
+	return Usage{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+}
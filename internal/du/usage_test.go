@@ -0,0 +1,225 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package du_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/du"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// duSizes returns the bytes reported by du for each directory with later
+// directories accounting for hard links to earlier ones.
+func duSizes(chk *sztest.Chk, dirs ...string) []int64 {
+	chk.T().Helper()
+
+	report, err := du.Run(
+		context.Background(), append([]string{"-s", "-b"}, dirs...), os.Stderr,
+	)
+	chk.NoErr(err)
+
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	sizes := make([]int64, len(lines))
+
+	for i, line := range lines {
+		sizes[i], err = strconv.ParseInt(strings.Split(line, "\t")[0], 10, 64)
+		chk.NoErr(err)
+	}
+
+	return sizes
+}
+
+// setupTarget creates three snapshots sharing some files through hard links
+// along with items outside of the snapshots returning the target and its
+// snapshot directories.
+func setupTarget(chk *sztest.Chk) (string, []string) {
+	chk.T().Helper()
+
+	trg := chk.CreateTmpDir()
+	snapshots := []string{
+		filepath.Join(trg, "A.szb"),
+		filepath.Join(trg, "B.szb"),
+		filepath.Join(trg, "C.szb"),
+	}
+
+	for _, dir := range snapshots {
+		chk.NoErr(os.MkdirAll(filepath.Join(dir, "sub", "deeper"), 0o0700))
+	}
+
+	write := func(path string, size int) {
+		chk.NoErr(
+			os.WriteFile(
+				path, []byte(strings.Repeat("x", size)), 0o0600,
+			),
+		)
+	}
+
+	// Shared by all snapshots.
+	write(filepath.Join(snapshots[0], "shared"), 1000)
+	chk.NoErr(os.Link(
+		filepath.Join(snapshots[0], "shared"),
+		filepath.Join(snapshots[1], "shared"),
+	))
+	chk.NoErr(os.Link(
+		filepath.Join(snapshots[0], "shared"),
+		filepath.Join(snapshots[2], "sub", "deeper", "shared"),
+	))
+
+	// Shared by the first and last snapshot only.
+	write(filepath.Join(snapshots[0], "sub", "skip"), 200)
+	chk.NoErr(os.Link(
+		filepath.Join(snapshots[0], "sub", "skip"),
+		filepath.Join(snapshots[2], "sub", "skip"),
+	))
+
+	// Linked twice inside a single snapshot.
+	write(filepath.Join(snapshots[1], "twice"), 30)
+	chk.NoErr(os.Link(
+		filepath.Join(snapshots[1], "twice"),
+		filepath.Join(snapshots[1], "sub", "twice"),
+	))
+
+	// Changed in each snapshot.
+	for i, dir := range snapshots {
+		write(filepath.Join(dir, "sub", "deeper", "changed"), 10*(i+1))
+	}
+
+	// Outside of the snapshots.
+	write(filepath.Join(trg, "journal"), 4)
+	chk.NoErr(os.Link(
+		filepath.Join(snapshots[2], "sub", "skip"),
+		filepath.Join(trg, "kept"),
+	))
+	chk.NoErr(os.Symlink(snapshots[2], filepath.Join(trg, "latest")))
+
+	return trg, snapshots
+}
+
+func TestDu_Measure_InvalidDirectory(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	usage, err := du.Measure(
+		context.Background(), "DOES_NOT_EXIST", nil, 0,
+	)
+	chk.Err(
+		err,
+		""+
+			du.ErrInvalid.Error()+
+			": "+
+			directory.ErrInvalid.Error()+
+			": 'DOES_NOT_EXIST'"+
+			"",
+	)
+	chk.Int64(usage.Total, 0)
+}
+
+func TestDu_Measure_Canceled(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, snapshots := setupTarget(chk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := du.Measure(ctx, trg, snapshots, 0)
+	chk.Err(
+		err,
+		""+
+			du.ErrInvalid.Error()+
+			": "+
+			context.Canceled.Error()+
+			"",
+	)
+}
+
+func TestDu_Measure_MatchesDu(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, snapshots := setupTarget(chk)
+
+	for _, workers := range []int{0, 1, 2, 16} {
+		usage, err := du.Measure(
+			context.Background(), trg, snapshots, workers,
+		)
+		chk.NoErr(err)
+
+		chk.Int64(usage.Total, duSizes(chk, trg)[0])
+
+		chk.Int(len(usage.Snapshots), len(snapshots))
+
+		for i, dir := range snapshots {
+			chk.Str(usage.Snapshots[i].Dir, dir)
+
+			total := duSizes(chk, dir)[0]
+			chk.Int64(usage.Snapshots[i].Total, total)
+
+			if i == 0 {
+				chk.Int64(usage.Snapshots[i].Changed, total)
+
+				continue
+			}
+
+			chk.Int64(
+				usage.Snapshots[i].Changed,
+				duSizes(chk, snapshots[i-1], dir)[1],
+			)
+		}
+	}
+}
+
+func TestDu_Measure_Exclusive(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	const (
+		shared = 1000 // Linked by every snapshot.
+		skip   = 200  // Linked by the first and last snapshot and the target.
+	)
+
+	trg, snapshots := setupTarget(chk)
+
+	usage, err := du.Measure(context.Background(), trg, snapshots, 0)
+	chk.NoErr(err)
+
+	snapA, snapB, snapC := usage.Snapshots[0], usage.Snapshots[1],
+		usage.Snapshots[2]
+
+	chk.Int64(snapA.Exclusive, snapA.Total-shared-skip)
+	chk.Int64(snapB.Exclusive, snapB.Total-shared)
+	chk.Int64(snapC.Exclusive, snapC.Total-shared-skip)
+
+	chk.Int64(usage.Reclaimable(), 0)
+	chk.Int64(usage.Reclaimable(1, 1), snapB.Exclusive)
+	chk.Int64(usage.Reclaimable(0, 1), snapA.Exclusive+snapB.Exclusive)
+	chk.Int64(
+		usage.Reclaimable(0, 1, 2),
+		snapA.Total+snapB.Total+snapC.Total-shared*2-skip*2,
+	)
+}
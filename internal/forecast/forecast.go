@@ -0,0 +1,163 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/journal"
+)
+
+const (
+	day         = time.Hour * 24
+	daysPerWeek = 7
+)
+
+// Point is the total bytes added to the target by the snapshots taken up to
+// a time.
+type Point struct {
+	Time  time.Time
+	Bytes int64
+}
+
+// Forecast projects the growth of a target.
+type Forecast struct {
+	// BytesPerDay is the rate data is added to the target.
+	BytesPerDay int64 `json:"bytesPerDay"`
+	// FreeBytes is the space remaining on the target's file system.
+	FreeBytes uint64 `json:"freeBytes"`
+	// DaysUntilFull is the number of days until the free bytes are consumed
+	// at the current rate.  It is nil if the target is not growing.
+	DaysUntilFull *int64 `json:"daysUntilFull,omitempty"`
+	// SettleBytes is the size the hourly and daily retention windows settle
+	// at: the latest snapshot plus the data added during keepDaily.
+	SettleBytes int64 `json:"settleBytes"`
+	// WeeklyBytes is the growth each week once settled as the weekly
+	// snapshots older than keepDaily are kept indefinitely.
+	WeeklyBytes int64 `json:"weeklyBytes"`
+}
+
+// Rate fits a line to the points by least squares returning its slope in
+// bytes per day.  At least two points spanning some time are required.
+func Rate(points []Point) (float64, error) {
+	var (
+		count  = float64(len(points))
+		sumX   float64
+		sumY   float64
+		sumXY  float64
+		sumXX  float64
+		x      float64
+		y      float64
+		spread float64
+	)
+
+	if len(points) < 2 { //nolint:mnd // A line needs two points.
+		return 0, fmt.Errorf("%w: %d snapshots", ErrInsufficient, len(points))
+	}
+
+	for _, point := range points {
+		x = float64(point.Time.Sub(points[0].Time)) / float64(day)
+		y = float64(point.Bytes)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	spread = count*sumXX - sumX*sumX
+	if spread <= 0 {
+		return 0, fmt.Errorf("%w: no elapsed time", ErrInsufficient)
+	}
+
+	return (count*sumXY - sumX*sumY) / spread, nil
+}
+
+// FromRecords returns the points of the successful snapshots recorded in the
+// journal using the size of the files each transferred.  The first snapshot
+// copies the whole source so only the data added after it is counted.
+func FromRecords(records []journal.Record) []Point {
+	var (
+		points []Point
+		total  int64
+	)
+
+	for _, record := range records {
+		if record.Operation != journal.OperationSnapshot ||
+			record.Status == journal.StatusFailed ||
+			record.Stats == nil {
+			continue
+		}
+
+		//nolint:gosec // Ok.
+		if len(points) > 0 {
+			total += int64(record.Stats.TransferredFileSize)
+		}
+
+		points = append(points, Point{Time: record.End, Bytes: total})
+	}
+
+	return points
+}
+
+// DaysUntilFull returns the whole days until the free bytes are consumed at
+// the rate or false if the target is not growing.
+func DaysUntilFull(free uint64, bytesPerDay float64) (int64, bool) {
+	if bytesPerDay <= 0 {
+		return 0, false
+	}
+
+	return int64(math.Floor(float64(free) / bytesPerDay)), true
+}
+
+// New forecasts the growth of a target from the points.  The settled size of
+// the retention policy starts from the size of the latest snapshot.
+func New(
+	points []Point, free uint64, latest int64, keepDaily time.Duration,
+) (Forecast, error) {
+	var (
+		forecast Forecast
+		rate     float64
+		days     int64
+		growing  bool
+		err      error
+	)
+
+	rate, err = Rate(points)
+
+	if err == nil {
+		forecast.BytesPerDay = int64(math.Round(rate))
+		forecast.FreeBytes = free
+
+		days, growing = DaysUntilFull(free, rate)
+		if growing {
+			forecast.DaysUntilFull = &days
+		}
+
+		rate = max(rate, 0)
+		forecast.SettleBytes = latest +
+			int64(math.Round(rate*float64(keepDaily)/float64(day)))
+		forecast.WeeklyBytes = int64(math.Round(rate * daysPerWeek))
+
+		return forecast, nil
+	}
+
+	return Forecast{}, err
+}
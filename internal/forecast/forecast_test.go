@@ -0,0 +1,150 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package forecast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/forecast"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+const day = time.Hour * 24
+
+func TestForecast_Rate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+
+	_, err := forecast.Rate(nil)
+	chk.Err(err, forecast.ErrInsufficient.Error()+": 0 snapshots")
+
+	_, err = forecast.Rate([]forecast.Point{
+		{Time: start, Bytes: 0}, {Time: start, Bytes: 10},
+	})
+	chk.Err(err, forecast.ErrInsufficient.Error()+": no elapsed time")
+
+	rate, err := forecast.Rate([]forecast.Point{
+		{Time: start, Bytes: 0},
+		{Time: start.Add(day), Bytes: 1000},
+		{Time: start.Add(day * 2), Bytes: 2000},
+	})
+	chk.NoErr(err)
+	chk.Float64(rate, 1000, 0.001)
+
+	// Uneven additions are smoothed.
+	rate, err = forecast.Rate([]forecast.Point{
+		{Time: start, Bytes: 0},
+		{Time: start.Add(day), Bytes: 500},
+		{Time: start.Add(day * 2), Bytes: 2000},
+	})
+	chk.NoErr(err)
+	chk.Float64(rate, 1000, 0.001)
+}
+
+func TestForecast_FromRecords(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	stats := func(size uint64) *rsync.Stats {
+		return &rsync.Stats{TransferredFileSize: size}
+	}
+
+	points := forecast.FromRecords([]journal.Record{
+		{
+			Operation: journal.OperationSnapshot,
+			Status:    journal.StatusSuccess,
+			End:       start,
+			Stats:     stats(1 << 30),
+		},
+		{
+			Operation: journal.OperationSnapshot,
+			Status:    journal.StatusFailed,
+			End:       start.Add(time.Hour),
+			Stats:     stats(99),
+		},
+		{
+			Operation: journal.OperationRestore,
+			Status:    journal.StatusSuccess,
+			End:       start.Add(time.Hour),
+			Stats:     stats(99),
+		},
+		{
+			Operation: journal.OperationSnapshot,
+			Status:    journal.StatusWarning,
+			End:       start.Add(day),
+			Stats:     stats(100),
+		},
+		{
+			Operation: journal.OperationSnapshot,
+			Status:    journal.StatusSuccess,
+			End:       start.Add(day * 2),
+		},
+		{
+			Operation: journal.OperationSnapshot,
+			Status:    journal.StatusSuccess,
+			End:       start.Add(day * 3),
+			Stats:     stats(50),
+		},
+	})
+
+	chk.Int(len(points), 3)
+	chk.Int64(points[0].Bytes, 0)
+	chk.Int64(points[1].Bytes, 100)
+	chk.Int64(points[2].Bytes, 150)
+	chk.True(points[2].Time.Equal(start.Add(day * 3)))
+}
+
+func TestForecast_New(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	points := []forecast.Point{
+		{Time: start, Bytes: 0},
+		{Time: start.Add(day * 10), Bytes: 10000},
+	}
+
+	result, err := forecast.New(points, 45500, 5000, day*30)
+	chk.NoErr(err)
+	chk.Int64(result.BytesPerDay, 1000)
+	chk.Uint64(result.FreeBytes, 45500)
+	chk.NotNil(result.DaysUntilFull)
+	chk.Int64(*result.DaysUntilFull, 45)
+	chk.Int64(result.SettleBytes, 35000)
+	chk.Int64(result.WeeklyBytes, 7000)
+
+	// A shrinking target never fills.
+	points[1].Bytes = -100
+
+	result, err = forecast.New(points, 45500, 5000, day*30)
+	chk.NoErr(err)
+	chk.Int64(result.BytesPerDay, -10)
+	chk.Nil(result.DaysUntilFull)
+	chk.Int64(result.SettleBytes, 5000)
+	chk.Int64(result.WeeklyBytes, 0)
+
+	_, err = forecast.New(points[:1], 45500, 5000, day*30)
+	chk.Err(err, forecast.ErrInsufficient.Error()+": 1 snapshots")
+}
@@ -59,6 +59,26 @@ func New(path string) (*StatFS, error) {
 	return nil, fmt.Errorf("statfs failed: %w", err)
 }
 
+// FreeBytes returns the number of bytes available on the file system.
+func (a *StatFS) FreeBytes() uint64 {
+	return a.freeBytes
+}
+
+// FreeINodes returns the number of iNodes available on the file system.
+func (a *StatFS) FreeINodes() uint64 {
+	return a.freeINodes
+}
+
+// TotalBytes returns the size of the file system in bytes.
+func (a *StatFS) TotalBytes() uint64 {
+	return a.totalBytes
+}
+
+// TotalINodes returns the number of iNodes on the file system.
+func (a *StatFS) TotalINodes() uint64 {
+	return a.totalINodes
+}
+
 func balancePct(pct string) string {
 	if pct == "" {
 		return ""
@@ -116,26 +136,74 @@ func (a *StatFS) FreeStatus(title string) string {
 	)
 }
 
-// Delta returns a string representing the file system usage changes.
-func (a *StatFS) Delta() string {
+// EstimateStatus returns a string representing an estimated file system
+// usage.
+func (a *StatFS) EstimateStatus(bytes, iNodes uint64) string {
+	return report(
+		"Estimated:",
+		out.Uint(bytes),
+		out.Pct(float64(bytes)/float64(a.totalBytes)),
+		out.Uint(iNodes),
+		out.Pct(float64(iNodes)/float64(a.totalINodes)),
+	)
+}
+
+// Free is the space available on a file system.
+type Free struct {
+	Bytes  uint64 `json:"bytes"`
+	INodes uint64 `json:"iNodes"`
+}
+
+// Change describes a file system's usage before and after an operation.
+type Change struct {
+	TotalBytes  uint64 `json:"totalBytes"`
+	TotalINodes uint64 `json:"totalINodes"`
+	Before      Free   `json:"before"`
+	After       Free   `json:"after"`
+	DeltaBytes  int64  `json:"deltaBytes"`
+	DeltaINodes int64  `json:"deltaINodes"`
+}
+
+// Change returns the file system usage changes.
+func (a *StatFS) Change() Change {
 	deltaStatFS, _ := New(a.path)
 
-	//nolint:gosec // OK.
-	deltaBytes := int64(a.freeBytes - deltaStatFS.freeBytes)
-	//nolint:gosec // OK.
-	deltaINodes := int64(a.freeINodes - deltaStatFS.freeINodes)
+	return Change{
+		TotalBytes:  a.totalBytes,
+		TotalINodes: a.totalINodes,
+		Before:      Free{Bytes: a.freeBytes, INodes: a.freeINodes},
+		After: Free{
+			Bytes:  deltaStatFS.freeBytes,
+			INodes: deltaStatFS.freeINodes,
+		},
+		//nolint:gosec // OK.
+		DeltaBytes: int64(a.freeBytes - deltaStatFS.freeBytes),
+		//nolint:gosec // OK.
+		DeltaINodes: int64(a.freeINodes - deltaStatFS.freeINodes),
+	}
+}
+
+// Delta returns a string representing the file system usage changes.
+func (a *StatFS) Delta() string {
+	change := a.Change()
+	after := &StatFS{
+		totalBytes:  change.TotalBytes,
+		freeBytes:   change.After.Bytes,
+		totalINodes: change.TotalINodes,
+		freeINodes:  change.After.INodes,
+	}
 
 	return fmt.Sprintf(
 		"%s\n%s\n%s\n%s",
 		a.TotalStatus(),
 		a.FreeStatus("Before"),
-		deltaStatFS.FreeStatus("After"),
+		after.FreeStatus("After"),
 		report(
 			"Delta:",
-			out.Int(deltaBytes),
-			out.Pct(float64(deltaBytes)/float64(a.totalBytes)),
-			out.Int(deltaINodes),
-			out.Pct(float64(deltaINodes)/float64(a.totalINodes)),
+			out.Int(change.DeltaBytes),
+			out.Pct(float64(change.DeltaBytes)/float64(a.totalBytes)),
+			out.Int(change.DeltaINodes),
+			out.Pct(float64(change.DeltaINodes)/float64(a.totalINodes)),
 		),
 	)
 }
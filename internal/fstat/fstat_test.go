@@ -131,3 +131,55 @@ func TestStatfs_Tmp(t *testing.T) {
 		exp,
 	)
 }
+
+func TestStatfs_Estimate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	statfs, err := fstat.New(dir)
+
+	chk.NoErr(err)
+	chk.NotNil(statfs)
+
+	chk.True(statfs.FreeBytes() > 0)
+	chk.True(statfs.FreeINodes() > 0)
+	chk.True(statfs.TotalBytes() >= statfs.FreeBytes())
+	chk.True(statfs.TotalINodes() >= statfs.FreeINodes())
+
+	squashNumbers(chk)
+
+	chk.Str(
+		statfs.EstimateStatus(6288, 5),
+		"   Estimated:"+
+			"                6,288 (  0.00%)                    5 (  0.00%)",
+	)
+}
+
+func TestStatfs_Change(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	statfs, err := fstat.New(dir)
+
+	chk.NoErr(err)
+	chk.NotNil(statfs)
+
+	change := statfs.Change()
+
+	chk.True(change.TotalBytes > 0)
+	chk.True(change.TotalINodes > 0)
+	chk.Uint64(change.Before.Bytes, statfs.FreeBytes())
+	chk.Uint64(change.Before.INodes, statfs.FreeINodes())
+	chk.Int64(
+		change.DeltaBytes,
+		int64(change.Before.Bytes)-int64(change.After.Bytes),
+	)
+	chk.Int64(
+		change.DeltaINodes,
+		int64(change.Before.INodes)-int64(change.After.INodes),
+	)
+}
@@ -0,0 +1,213 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+)
+
+const journalPerm = 0o0600
+
+// MaxWarnings is the number of warnings kept in a record.  A noisy run may
+// report thousands which would otherwise produce an enormous journal line.
+const MaxWarnings = 100
+
+// maxLine is the longest journal line loaded.  Longer lines are skipped.
+const maxLine = bufio.MaxScanTokenSize * 16
+
+// Journaled operations.
+const (
+	OperationSnapshot = "snapshot"
+	OperationRestore  = "restore"
+)
+
+// Snapshot outcomes.
+const (
+	StatusSuccess = "success"
+	StatusWarning = "warning"
+	StatusFailed  = "failed"
+)
+
+// Failure classes grouping failed snapshots by their cause.
+const (
+	ClassSpace     = "space"
+	ClassStalled   = "stalled"
+	ClassTimeout   = "timeout"
+	ClassCancelled = "cancelled"
+	ClassRsync     = "rsync"
+	ClassOther     = "other"
+)
+
+// Record captures the outcome of a single snapshot or restore.
+type Record struct {
+	// Operation is either a snapshot or a restore.
+	Operation string `json:"operation"`
+	// Snapshot is the name of the snapshot directory created or restored
+	// from.
+	Snapshot string `json:"snapshot"`
+	// Destination is the alternate directory a restore was written to.
+	Destination string `json:"destination,omitempty"`
+	// Tag identifies a snapshot taken for a specific purpose (IE:
+	// pre-restore).
+	Tag string `json:"tag,omitempty"`
+	// Start is when the snapshot started.
+	Start time.Time `json:"start"`
+	// End is when the snapshot completed.
+	End time.Time `json:"end"`
+	// Status is one of the snapshot outcomes.
+	Status string `json:"status"`
+	// Error is the error reported by a failed snapshot or the warning
+	// accepted by a successful one.
+	Error string `json:"error,omitempty"`
+	// Class is the failure class of a failed snapshot.
+	Class string `json:"class,omitempty"`
+	// ExitCode is the exit code reported by rsync.
+	ExitCode int `json:"exitCode,omitempty"`
+	// WarningCount is the number of items rsync could not transfer.
+	WarningCount int `json:"warningCount,omitempty"`
+	// Warnings lists the first MaxWarnings items rsync could not transfer.
+	Warnings []rsync.Warning `json:"warnings,omitempty"`
+	// Stats are the transfer statistics reported by rsync (if any).
+	Stats *rsync.Stats `json:"stats,omitempty"`
+}
+
+// SetWarnings records the number of warnings keeping only the first
+// MaxWarnings.
+func (r *Record) SetWarnings(warnings []rsync.Warning) {
+	r.WarningCount = len(warnings)
+	r.Warnings = warnings[:min(len(warnings), MaxWarnings)]
+}
+
+// Append adds the record to the end of the journal creating it if necessary.
+func Append(path string, record Record) error {
+	var (
+		line []byte
+		file *os.File
+		err  error
+	)
+
+	line, err = json.Marshal(record)
+
+	if err == nil {
+		file, err = os.OpenFile( //nolint:gosec // Ok.
+			path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, journalPerm,
+		)
+	}
+
+	if err == nil {
+		_, err = file.Write(append(line, '\n'))
+
+		err = errors.Join(err, file.Close())
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrAppend, err)
+}
+
+// readLine returns the next line from the reader.  A line longer than
+// maxLine is consumed but returned empty.
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	var (
+		line     []byte
+		chunk    []byte
+		isPrefix = true
+		tooLong  bool
+		err      error
+	)
+
+	for isPrefix && err == nil {
+		chunk, isPrefix, err = reader.ReadLine()
+		if !tooLong {
+			line = append(line, chunk...)
+			tooLong = len(line) > maxLine
+		}
+	}
+
+	if tooLong {
+		line = nil
+	}
+
+	return line, err //nolint:wrapcheck // Ok.
+}
+
+// Load returns all of the records in the journal oldest first.  A missing
+// journal has no records.  Lines longer than maxLine (IE: written before the
+// warnings were limited) are skipped rather than making the journal
+// unreadable.
+func Load(path string) ([]Record, error) {
+	var (
+		records []Record
+		record  Record
+		file    *os.File
+		reader  *bufio.Reader
+		line    []byte
+		lineNbr int
+		err     error
+	)
+
+	file, err = os.Open(path) //nolint:gosec // Ok.
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err == nil {
+		reader = bufio.NewReader(file)
+
+		for err == nil {
+			line, err = readLine(reader)
+			lineNbr++
+
+			if err != nil || len(line) == 0 {
+				continue
+			}
+
+			record = Record{}
+
+			err = json.Unmarshal(line, &record)
+			if err == nil {
+				records = append(records, record)
+			} else {
+				err = fmt.Errorf("%w(%d): %w", ErrRecord, lineNbr, err)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+
+		err = errors.Join(err, file.Close())
+	}
+
+	if err == nil {
+		return records, nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrLoad, err)
+}
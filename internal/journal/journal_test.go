@@ -0,0 +1,184 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package journal_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestJournal_Load_Missing(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	records, err := journal.Load(
+		filepath.Join(chk.CreateTmpDir(), "missing.journal"),
+	)
+
+	chk.NoErr(err)
+	chk.Int(len(records), 0)
+}
+
+func TestJournal_Load_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	path := filepath.Join(chk.CreateTmpDir(), "bad.journal")
+	chk.NoErr(os.WriteFile(path, []byte("{}\n\nnot json\n"), 0o0600))
+
+	records, err := journal.Load(path)
+
+	chk.Err(
+		err,
+		""+
+			journal.ErrLoad.Error()+
+			": "+
+			journal.ErrRecord.Error()+
+			"(3): invalid character 'o' in literal null (expecting 'u')"+
+			"",
+	)
+	chk.Int(len(records), 0)
+}
+
+func TestJournal_Append_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	err := journal.Append(dir, journal.Record{})
+
+	chk.Err(
+		err,
+		""+
+			journal.ErrAppend.Error()+
+			": open "+dir+": is a directory"+
+			"",
+	)
+}
+
+func TestJournal_AppendAndLoad(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	path := filepath.Join(chk.CreateTmpDir(), "szbck.journal")
+	start := time.Date(2026, time.May, 2, 3, 4, 5, 0, time.UTC)
+
+	chk.NoErr(journal.Append(path, journal.Record{
+		Operation: journal.OperationSnapshot,
+		Snapshot:  "20260502_030405.0000.szb",
+		Start:     start,
+		End:       start.Add(time.Minute),
+		Status:    journal.StatusSuccess,
+		Stats: &rsync.Stats{
+			Files:   3,
+			Elapsed: time.Second,
+		},
+	}))
+
+	chk.NoErr(journal.Append(path, journal.Record{
+		Operation: journal.OperationRestore,
+		Snapshot:  "20260502_040405.0000.szb",
+		Start:     start.Add(time.Hour),
+		End:       start.Add(time.Hour + time.Minute),
+		Status:    journal.StatusWarning,
+		Error:     "some error",
+		ExitCode:  24,
+		Warnings: []rsync.Warning{
+			{Reason: rsync.WarnVanished, Path: "/src/a.txt"},
+		},
+	}))
+
+	records, err := journal.Load(path)
+
+	chk.NoErr(err)
+	chk.Int(len(records), 2)
+	chk.Str(records[0].Snapshot, "20260502_030405.0000.szb")
+	chk.Str(records[0].Status, journal.StatusSuccess)
+	chk.Dur(records[0].End.Sub(records[0].Start), time.Minute)
+	chk.Int(len(records[0].Warnings), 0)
+	chk.Str(records[0].Operation, journal.OperationSnapshot)
+	chk.NotNil(records[0].Stats)
+	chk.Uint64(records[0].Stats.Files, 3)
+	chk.Dur(records[0].Stats.Elapsed, time.Second)
+
+	chk.Str(records[1].Operation, journal.OperationRestore)
+	chk.Nil(records[1].Stats)
+	chk.Str(records[1].Status, journal.StatusWarning)
+	chk.Str(records[1].Error, "some error")
+	chk.Int(records[1].ExitCode, 24)
+	chk.Int(len(records[1].Warnings), 1)
+	chk.Str(records[1].Warnings[0].String(), "vanished: /src/a.txt")
+}
+
+func TestJournal_Load_LongLine(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	path := filepath.Join(chk.CreateTmpDir(), "szbck.journal")
+	long := `{"operation":"snapshot","error":"` +
+		strings.Repeat("x", 2*1024*1024) + `"}`
+
+	chk.NoErr(os.WriteFile(
+		path,
+		[]byte(`{"snapshot":"a"}`+"\n"+long+"\n"+`{"snapshot":"b"}`),
+		0o0600,
+	))
+
+	records, err := journal.Load(path)
+
+	chk.NoErr(err)
+	chk.Int(len(records), 2)
+	chk.Str(records[0].Snapshot, "a")
+	chk.Str(records[1].Snapshot, "b")
+}
+
+func TestJournal_Record_SetWarnings(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var record journal.Record
+
+	record.SetWarnings(nil)
+	chk.Int(record.WarningCount, 0)
+	chk.Int(len(record.Warnings), 0)
+
+	warnings := make([]rsync.Warning, journal.MaxWarnings+5)
+	for i := range warnings {
+		warnings[i] = rsync.Warning{
+			Reason: rsync.WarnVanished, Path: strconv.Itoa(i),
+		}
+	}
+
+	record.SetWarnings(warnings)
+	chk.Int(record.WarningCount, journal.MaxWarnings+5)
+	chk.Int(len(record.Warnings), journal.MaxWarnings)
+	chk.Str(
+		record.Warnings[journal.MaxWarnings-1].Path,
+		strconv.Itoa(journal.MaxWarnings-1),
+	)
+}
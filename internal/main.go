@@ -19,17 +19,30 @@
 package internal
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/subcommand/cat"
+	"github.com/dancsecs/szbck/internal/subcommand/check"
 	"github.com/dancsecs/szbck/internal/subcommand/create"
+	"github.com/dancsecs/szbck/internal/subcommand/diff"
+	"github.com/dancsecs/szbck/internal/subcommand/find"
 	"github.com/dancsecs/szbck/internal/subcommand/help"
+	"github.com/dancsecs/szbck/internal/subcommand/history"
+	"github.com/dancsecs/szbck/internal/subcommand/lstree"
+	"github.com/dancsecs/szbck/internal/subcommand/policy"
 	"github.com/dancsecs/szbck/internal/subcommand/prune"
 	"github.com/dancsecs/szbck/internal/subcommand/restore"
 	"github.com/dancsecs/szbck/internal/subcommand/snapshot"
 	"github.com/dancsecs/szbck/internal/subcommand/status"
 	"github.com/dancsecs/szbck/internal/subcommand/trim"
+	"github.com/dancsecs/szbck/internal/subcommand/undelete"
 	"github.com/dancsecs/szbck/internal/subcommand/vet"
 	"github.com/dancsecs/szlog"
 )
@@ -47,14 +60,34 @@ func Main(rawArgs []string) int {
 		returnValue int
 	)
 
+	// Rsync runs in its own process group so it does not receive terminal
+	// interrupts.  They cancel the context stopping it instead.
+	ctx, stop := signal.NotifyContext(
+		context.Background(), os.Interrupt, syscall.SIGTERM,
+	)
+	defer stop()
+
 	cleanedArgs, err := szlog.AbsorbArgs(easterEgg(rawArgs))
 
+	if err == nil {
+		cleanedArgs, err = out.AbsorbArgs(cleanedArgs)
+	}
+
 	if err == nil {
 		args = szargs.New("", cleanedArgs)
 		subCommand = args.NextString("sub command", "")
+		err = args.Err()
+	} else {
+		// Retain the program name for reporting the error.
+		args = szargs.New("", rawArgs[:min(1, len(rawArgs))])
+	}
+
+	if err == nil && !supportsFormat(subCommand, out.Format()) {
+		err = fmt.Errorf(
+			"%w: '%s': %s", out.ErrUnsupportedFormat, out.Format(), subCommand,
+		)
 	}
 
-	err = args.Err()
 	if err == nil {
 		switch strings.ToLower(subCommand) {
 		case "h", "help":
@@ -65,17 +98,33 @@ func Main(rawArgs []string) int {
 		case "c", "create":
 			outText, err = create.Process(args)
 		case "s", "snap", "snapshot":
-			outText, err = snapshot.Process(args)
+			outText, err = snapshot.Process(ctx, args)
 		case "r", "res", "restore":
-			outText, err = restore.Process(args)
+			outText, err = restore.Process(ctx, args)
 		case "p", "prune":
 			outText, err = prune.Process(args)
 		case "stat", "status":
-			outText, err = status.Process(args)
+			outText, err = status.Process(ctx, args)
+		case "hist", "history":
+			outText, err = history.Process(args)
+		case "d", "diff":
+			outText, err = diff.Process(args)
+		case "f", "find":
+			outText, err = find.Process(args)
+		case "u", "undelete":
+			outText, err = undelete.Process(ctx, args)
+		case "cat":
+			outText, err = cat.Process(args)
+		case "ls", "ls-tree":
+			outText, err = lstree.Process(args)
 		case "t", "trim":
 			outText, err = trim.Process(args)
 		case "v", "vet":
 			outText, err = vet.Process(args)
+		case "check":
+			outText, returnValue = check.Process(args)
+		case "policy":
+			outText, err = policy.Process(args)
 		default:
 			err = fmt.Errorf(
 				"%w: '%s'",
@@ -96,6 +145,29 @@ func Main(rawArgs []string) int {
 	return returnValue
 }
 
+// supportsFormat returns true if the sub command supports the output format.
+// Only tabular results may be written as CSV.
+func supportsFormat(subCommand, format string) bool {
+	switch strings.ToLower(subCommand) {
+	case "s", "snap", "snapshot",
+		"r", "res", "restore",
+		"d", "diff":
+		return format != out.FormatCSV
+	case "p", "prune",
+		"stat", "status",
+		"t", "trim",
+		"v", "vet",
+		"policy",
+		"hist", "history",
+		"f", "find",
+		"u", "undelete",
+		"ls", "ls-tree":
+		return true
+	default:
+		return format == out.FormatText
+	}
+}
+
 func easterEgg(args []string) []string {
 	cleanedArgs := make([]string, 0, len(args))
 
@@ -23,13 +23,22 @@ import (
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/subcommand/cat"
+	"github.com/dancsecs/szbck/internal/subcommand/check"
 	"github.com/dancsecs/szbck/internal/subcommand/create"
+	"github.com/dancsecs/szbck/internal/subcommand/diff"
+	"github.com/dancsecs/szbck/internal/subcommand/find"
 	"github.com/dancsecs/szbck/internal/subcommand/help"
+	"github.com/dancsecs/szbck/internal/subcommand/history"
+	"github.com/dancsecs/szbck/internal/subcommand/lstree"
+	"github.com/dancsecs/szbck/internal/subcommand/policy"
 	"github.com/dancsecs/szbck/internal/subcommand/prune"
 	"github.com/dancsecs/szbck/internal/subcommand/restore"
 	"github.com/dancsecs/szbck/internal/subcommand/snapshot"
 	"github.com/dancsecs/szbck/internal/subcommand/status"
 	"github.com/dancsecs/szbck/internal/subcommand/trim"
+	"github.com/dancsecs/szbck/internal/subcommand/undelete"
 	"github.com/dancsecs/szbck/internal/subcommand/vet"
 	"github.com/dancsecs/sztestlog"
 )
@@ -91,8 +100,16 @@ func TestBackupMain_Help(t *testing.T) {
 		restore.HelpText,
 		prune.HelpText,
 		status.HelpText,
+		history.HelpText,
+		diff.HelpText,
+		find.HelpText,
+		undelete.HelpText,
+		cat.HelpText,
+		lstree.HelpText,
 		trim.HelpText,
 		vet.HelpText,
+		check.HelpText,
+		policy.HelpText,
 	)
 }
 
@@ -205,6 +222,138 @@ func TestBackupMain_Status(t *testing.T) {
 	)
 }
 
+func TestBackupMain_History(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	args := []string{"programName", "hist"}
+
+	chk.Int(
+		internal.Main(args),
+		1,
+	)
+
+	chk.Log(
+		"" +
+			"F:programName - " +
+			history.ErrHistoryError.Error() +
+			": " +
+			szargs.ErrMissing.Error() +
+			": backup config filename" +
+			"",
+	)
+}
+
+func TestBackupMain_Diff(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	args := []string{"programName", "diff"}
+
+	chk.Int(
+		internal.Main(args),
+		1,
+	)
+
+	chk.Log(
+		"" +
+			"F:programName - " +
+			diff.ErrDiffError.Error() +
+			": " +
+			szargs.ErrMissing.Error() +
+			": backup config filename" +
+			"",
+	)
+}
+
+func TestBackupMain_Find(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	args := []string{"programName", "find"}
+
+	chk.Int(
+		internal.Main(args),
+		1,
+	)
+
+	chk.Log(
+		"" +
+			"F:programName - " +
+			find.ErrFindError.Error() +
+			": " +
+			szargs.ErrMissing.Error() +
+			": backup config filename" +
+			"",
+	)
+}
+
+func TestBackupMain_Undelete(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	args := []string{"programName", "undelete"}
+
+	chk.Int(
+		internal.Main(args),
+		1,
+	)
+
+	chk.Log(
+		"" +
+			"F:programName - " +
+			undelete.ErrUndeleteError.Error() +
+			": " +
+			szargs.ErrMissing.Error() +
+			": backup config filename" +
+			"",
+	)
+}
+
+func TestBackupMain_Cat(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	args := []string{"programName", "cat"}
+
+	chk.Int(
+		internal.Main(args),
+		1,
+	)
+
+	chk.Log(
+		"" +
+			"F:programName - " +
+			cat.ErrCatError.Error() +
+			": " +
+			szargs.ErrMissing.Error() +
+			": backup config filename" +
+			"",
+	)
+}
+
+func TestBackupMain_LsTree(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	args := []string{"programName", "ls-tree"}
+
+	chk.Int(
+		internal.Main(args),
+		1,
+	)
+
+	chk.Log(
+		"" +
+			"F:programName - " +
+			lstree.ErrLsTreeError.Error() +
+			": " +
+			szargs.ErrMissing.Error() +
+			": backup config filename" +
+			"",
+	)
+}
+
 func TestBackupMain_Trim(t *testing.T) {
 	chk := sztestlog.CaptureLog(t)
 	defer chk.Release()
@@ -249,6 +398,86 @@ func TestBackupMain_Vet(t *testing.T) {
 	)
 }
 
+func TestBackupMain_Check(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	args := []string{"programName", "check"}
+
+	chk.Int(
+		internal.Main(args),
+		check.ExitUnknown,
+	)
+
+	chk.Stdout(
+		"SZBCK UNKNOWN - " +
+			szargs.ErrMissing.Error() +
+			": backup config filename",
+	)
+}
+
+func TestBackupMain_Policy(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	args := []string{"programName", "policy"}
+
+	chk.Int(
+		internal.Main(args),
+		1,
+	)
+
+	chk.Log(
+		"" +
+			"F:programName - " +
+			policy.ErrPolicyError.Error() +
+			": " +
+			szargs.ErrMissing.Error() +
+			": policy action" +
+			": " +
+			szargs.ErrMissing.Error() +
+			": backup config filename" +
+			"",
+	)
+}
+
+func TestBackupMain_InvalidOutput(t *testing.T) {
+	chk := sztestlog.CaptureLog(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	chk.Int(
+		internal.Main([]string{"programName", "--output", "xml", "vet"}),
+		1,
+	)
+
+	chk.Int(
+		internal.Main([]string{"programName", "--output=json", "cat"}),
+		1,
+	)
+
+	chk.Int(
+		internal.Main([]string{"programName", "--output=csv", "restore"}),
+		1,
+	)
+
+	chk.Log(
+		""+
+			"F:programName - "+
+			out.ErrInvalidFormat.Error()+
+			": 'xml'",
+		""+
+			"F:programName - "+
+			out.ErrUnsupportedFormat.Error()+
+			": 'json': cat",
+		""+
+			"F:programName - "+
+			out.ErrUnsupportedFormat.Error()+
+			": 'csv': restore",
+	)
+}
+
 func TestArgUsage_Dedication(t *testing.T) {
 	chk := sztestlog.CaptureLogAndStdout(t)
 	defer chk.Release()
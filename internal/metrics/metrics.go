@@ -0,0 +1,295 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/settings"
+)
+
+const metricsPerm = 0o0644
+
+// Operations writing the metrics.
+const (
+	OperationSnapshot = "snapshot"
+	OperationTrim     = "trim"
+	OperationPrune    = "prune"
+)
+
+// failureClasses lists every failure class so that each counter is present
+// from the first write.
+//
+//nolint:gochecknoglobals // Ok.
+var failureClasses = []string{
+	journal.ClassSpace,
+	journal.ClassStalled,
+	journal.ClassTimeout,
+	journal.ClassCancelled,
+	journal.ClassRsync,
+	journal.ClassOther,
+}
+
+// textFile builds the exposition format labelling every sample with the
+// target.
+type textFile struct {
+	target string
+	text   strings.Builder
+}
+
+// escape escapes a label value.
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`, "\n", `\n`,
+	).Replace(value)
+}
+
+// metric writes the help and type lines of a metric.
+func (t *textFile) metric(name, kind, help string) {
+	t.text.WriteString("# HELP " + name + " " + help + "\n")
+	t.text.WriteString("# TYPE " + name + " " + kind + "\n")
+}
+
+// sample writes a single sample.  The label (if any) is added to the target
+// label.
+func (t *textFile) sample(name, label, labelValue, value string) {
+	t.text.WriteString(name + `{target="` + escape(t.target) + `"`)
+
+	if label != "" {
+		t.text.WriteString("," + label + `="` + escape(labelValue) + `"`)
+	}
+
+	t.text.WriteString("} " + value + "\n")
+}
+
+// gauge writes a gauge with a single sample.
+func (t *textFile) gauge(name, help, value string) {
+	t.metric(name, "gauge", help)
+	t.sample(name, "", "", value)
+}
+
+// seconds formats a time as seconds since the epoch.
+func seconds(tme time.Time) string {
+	return strconv.FormatInt(tme.Unix(), 10)
+}
+
+// lastSuccess returns the last successful snapshot recorded in the journal
+// (nil if none) along with the number of failed snapshots in each failure
+// class.
+func lastSuccess(records []journal.Record) (*journal.Record, map[string]int) {
+	var (
+		success  *journal.Record
+		failures = make(map[string]int)
+	)
+
+	for i := range records {
+		switch {
+		case records[i].Operation != journal.OperationSnapshot:
+		case records[i].Status != journal.StatusFailed:
+			success = &records[i]
+		case records[i].Class == "":
+			// Snapshots recorded before failures were classified.
+			failures[journal.ClassOther]++
+		default:
+			failures[records[i].Class]++
+		}
+	}
+
+	return success, failures
+}
+
+// build returns the metrics text for the backup set.
+func build(
+	cfg *settings.Config, operation string, purged int, now time.Time,
+) (string, error) {
+	var (
+		records   []journal.Record
+		success   *journal.Record
+		failures  map[string]int
+		snapshots []string
+		fsStat    *fstat.StatFS
+		file      = textFile{target: cfg.Target.GetPath()}
+		err       error
+	)
+
+	records, err = journal.Load(cfg.Target.Journal())
+
+	if err == nil {
+		snapshots, err = cfg.Target.Snapshots()
+	}
+
+	if err == nil {
+		fsStat, err = fstat.New(cfg.Target.GetPath())
+	}
+
+	if err != nil {
+		return "", err //nolint:wrapcheck // Ok.
+	}
+
+	success, failures = lastSuccess(records)
+
+	if success != nil {
+		file.gauge(
+			"szbck_last_success_timestamp_seconds",
+			"Time the last successful snapshot completed.",
+			seconds(success.End),
+		)
+		file.gauge(
+			"szbck_last_success_duration_seconds",
+			"Time taken by the last successful snapshot.",
+			strconv.FormatFloat(
+				success.End.Sub(success.Start).Seconds(), 'f', 3, 64,
+			),
+		)
+	}
+
+	if success != nil && success.Stats != nil {
+		file.gauge(
+			"szbck_last_success_transferred_bytes",
+			"Size of the files transferred by the last successful snapshot.",
+			strconv.FormatUint(success.Stats.TransferredFileSize, 10),
+		)
+	}
+
+	file.gauge(
+		"szbck_snapshots",
+		"Number of snapshots in the target.",
+		strconv.Itoa(len(snapshots)),
+	)
+	file.gauge(
+		"szbck_target_free_bytes",
+		"Bytes available on the target's file system.",
+		strconv.FormatUint(fsStat.FreeBytes(), 10),
+	)
+	file.gauge(
+		"szbck_target_free_inodes",
+		"INodes available on the target's file system.",
+		strconv.FormatUint(fsStat.FreeINodes(), 10),
+	)
+
+	file.metric(
+		"szbck_last_run_timestamp_seconds", "gauge",
+		"Time the operation writing these metrics completed.",
+	)
+	file.sample(
+		"szbck_last_run_timestamp_seconds", "operation", operation,
+		seconds(now),
+	)
+
+	file.metric(
+		"szbck_purged_snapshots", "gauge",
+		"Snapshots purged by the operation writing these metrics.",
+	)
+	file.sample(
+		"szbck_purged_snapshots", "operation", operation,
+		strconv.Itoa(purged),
+	)
+
+	file.metric(
+		"szbck_snapshot_failures_total", "counter",
+		"Failed snapshots recorded in the journal by failure class.",
+	)
+
+	for _, class := range failureClasses {
+		file.sample(
+			"szbck_snapshot_failures_total", "class", class,
+			strconv.Itoa(failures[class]),
+		)
+	}
+
+	return file.text.String(), nil
+}
+
+// replace atomically replaces the file with the text by renaming a
+// temporary file written in the same directory over it.
+func replace(path, text string) error {
+	var (
+		tmp *os.File
+		err error
+	)
+
+	// The textfile collector ignores files not ending in .prom.
+	tmp, err = os.CreateTemp(
+		filepath.Dir(path), "."+filepath.Base(path)+".*.tmp",
+	)
+
+	if err == nil {
+		_, err = tmp.WriteString(text)
+
+		if err == nil {
+			err = tmp.Chmod(metricsPerm)
+		}
+
+		err = errors.Join(err, tmp.Close())
+
+		if err == nil {
+			err = os.Rename(tmp.Name(), path)
+		}
+
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// Write rewrites the configured metrics file after an operation that purged
+// the specified number of snapshots.  Nothing is written if no metrics file
+// is configured.
+func Write(cfg *settings.Config, operation string, purged int) error {
+	var (
+		text string
+		err  error
+	)
+
+	if cfg.MetricsFile == "" {
+		return nil
+	}
+
+	text, err = build(cfg, operation, purged, time.Now())
+
+	if err == nil {
+		err = replace(cfg.MetricsFile, text)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: '%s': %w", ErrWrite, cfg.MetricsFile, err)
+}
+
+// Update writes the metrics file reporting any failure as a warning on
+// standard error.  The metrics file is optional so failing to write it never
+// fails the operation being reported.
+func Update(cfg *settings.Config, operation string, purged int) {
+	err := Write(cfg, operation, purged)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,244 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package metrics_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/metrics"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztestlog"
+)
+
+// reFree matches the free space samples which vary.
+var reFree = regexp.MustCompile(`(szbck_target_free_\w+\{.*\}) \d+`)
+
+func TestMetrics_Write_Disabled(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.NoErr(metrics.Write(&settings.Config{}, metrics.OperationTrim, 0))
+}
+
+func TestMetrics_Write(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trgDir := chk.CreateTmpSubDir("target")
+	metricsDir := chk.CreateTmpSubDir("metrics")
+
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target:      trg,
+		MetricsFile: filepath.Join(metricsDir, "szbck.prom"),
+	}
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	for i := range 2 {
+		chk.NoErr(os.MkdirAll(
+			trg.SnapshotDir(start.Add(time.Minute*time.Duration(i))),
+			0o0700,
+		))
+	}
+
+	records := []journal.Record{
+		{Status: journal.StatusFailed},
+		{Status: journal.StatusSuccess, Stats: &rsync.Stats{}},
+		{Status: journal.StatusFailed, Class: journal.ClassSpace},
+		{
+			Status: journal.StatusWarning,
+			Stats:  &rsync.Stats{TransferredFileSize: 4096},
+		},
+		{Status: journal.StatusFailed, Class: journal.ClassOther},
+	}
+
+	for i, record := range records {
+		record.Operation = journal.OperationSnapshot
+		record.Start = start.Add(time.Minute * time.Duration(i))
+		record.End = record.Start.Add(time.Millisecond * 1500)
+		chk.NoErr(journal.Append(trg.Journal(), record))
+	}
+
+	// Restores are not snapshots.
+	chk.NoErr(journal.Append(trg.Journal(), journal.Record{
+		Operation: journal.OperationRestore,
+		Status:    journal.StatusFailed,
+	}))
+
+	chk.NoErr(metrics.Write(cfg, metrics.OperationTrim, 3))
+
+	data, err := os.ReadFile(cfg.MetricsFile)
+	chk.NoErr(err)
+
+	label := `{target="` + trgDir + `"`
+	success := strconv.FormatInt(
+		start.Add(time.Minute*3+time.Millisecond*1500).Unix(), 10,
+	)
+	lines := strings.Split(reFree.ReplaceAllString(string(data), "$1 #"), "\n")
+
+	// The run time is when the file was written.
+	chk.True(strings.HasPrefix(
+		lines[20], "szbck_last_run_timestamp_seconds"+label+
+			`,operation="trim"} `,
+	))
+	lines[20] = "szbck_last_run_timestamp_seconds"
+
+	chk.StrSlice(
+		lines,
+		[]string{
+			"# HELP szbck_last_success_timestamp_seconds " +
+				"Time the last successful snapshot completed.",
+			"# TYPE szbck_last_success_timestamp_seconds gauge",
+			"szbck_last_success_timestamp_seconds" + label + "} " + success,
+			"# HELP szbck_last_success_duration_seconds " +
+				"Time taken by the last successful snapshot.",
+			"# TYPE szbck_last_success_duration_seconds gauge",
+			"szbck_last_success_duration_seconds" + label + "} 1.500",
+			"# HELP szbck_last_success_transferred_bytes " +
+				"Size of the files transferred by the last successful " +
+				"snapshot.",
+			"# TYPE szbck_last_success_transferred_bytes gauge",
+			"szbck_last_success_transferred_bytes" + label + "} 4096",
+			"# HELP szbck_snapshots Number of snapshots in the target.",
+			"# TYPE szbck_snapshots gauge",
+			"szbck_snapshots" + label + "} 2",
+			"# HELP szbck_target_free_bytes " +
+				"Bytes available on the target's file system.",
+			"# TYPE szbck_target_free_bytes gauge",
+			"szbck_target_free_bytes" + label + "} #",
+			"# HELP szbck_target_free_inodes " +
+				"INodes available on the target's file system.",
+			"# TYPE szbck_target_free_inodes gauge",
+			"szbck_target_free_inodes" + label + "} #",
+			"# HELP szbck_last_run_timestamp_seconds " +
+				"Time the operation writing these metrics completed.",
+			"# TYPE szbck_last_run_timestamp_seconds gauge",
+			"szbck_last_run_timestamp_seconds",
+			"# HELP szbck_purged_snapshots " +
+				"Snapshots purged by the operation writing these metrics.",
+			"# TYPE szbck_purged_snapshots gauge",
+			"szbck_purged_snapshots" + label + `,operation="trim"} 3`,
+			"# HELP szbck_snapshot_failures_total " +
+				"Failed snapshots recorded in the journal by failure class.",
+			"# TYPE szbck_snapshot_failures_total counter",
+			"szbck_snapshot_failures_total" + label + `,class="space"} 1`,
+			"szbck_snapshot_failures_total" + label + `,class="stalled"} 0`,
+			"szbck_snapshot_failures_total" + label + `,class="timeout"} 0`,
+			"szbck_snapshot_failures_total" + label + `,class="cancelled"} 0`,
+			"szbck_snapshot_failures_total" + label + `,class="rsync"} 0`,
+			"szbck_snapshot_failures_total" + label + `,class="other"} 2`,
+			"",
+		},
+	)
+
+	info, err := os.Stat(cfg.MetricsFile)
+	chk.NoErr(err)
+	chk.Str(info.Mode().Perm().String(), "-rw-r--r--")
+
+	// Only the metrics file remains.
+	entries, err := os.ReadDir(metricsDir)
+	chk.NoErr(err)
+	chk.Int(len(entries), 1)
+}
+
+func TestMetrics_Write_Empty(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpSubDir("target"))
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target:      trg,
+		MetricsFile: filepath.Join(chk.CreateTmpSubDir("m"), "szbck.prom"),
+	}
+
+	chk.NoErr(metrics.Write(cfg, metrics.OperationPrune, 0))
+
+	data, err := os.ReadFile(cfg.MetricsFile)
+	chk.NoErr(err)
+	chk.True(strings.HasPrefix(
+		string(data), "# HELP szbck_snapshots Number of snapshots",
+	))
+	chk.True(strings.Contains(
+		string(data), `,operation="prune"} 0`+"\n",
+	))
+}
+
+func TestMetrics_Write_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpSubDir("target"))
+	chk.NoErr(err)
+
+	dir := chk.CreateTmpSubDir("m")
+	path := filepath.Join(dir, "szbck.prom")
+
+	// A directory in place of the metrics file cannot be replaced.
+	chk.NoErr(os.Mkdir(path, 0o0700))
+	chk.NoErr(os.WriteFile(filepath.Join(path, "x"), nil, 0o0600))
+
+	err = metrics.Write(
+		&settings.Config{Target: trg, MetricsFile: path},
+		metrics.OperationSnapshot, 0,
+	)
+	chk.True(err != nil)
+	chk.True(strings.HasPrefix(
+		err.Error(), metrics.ErrWrite.Error()+": '"+path+"': ",
+	))
+
+	entries, err := os.ReadDir(dir)
+	chk.NoErr(err)
+	chk.Int(len(entries), 1)
+}
+
+func TestMetrics_Update_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureStderr(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpSubDir("target"))
+	chk.NoErr(err)
+
+	path := filepath.Join(chk.CreateTmpSubDir("m"), "szbck.prom")
+
+	// A directory in place of the metrics file cannot be replaced.
+	chk.NoErr(os.Mkdir(path, 0o0700))
+	chk.NoErr(os.WriteFile(filepath.Join(path, "x"), nil, 0o0600))
+
+	metrics.Update(
+		&settings.Config{Target: trg, MetricsFile: path},
+		metrics.OperationSnapshot, 0,
+	)
+
+	chk.AddSub(`(?s)'`+regexp.QuoteMeta(path)+`': .*`, "'PATH': ERROR")
+	chk.Stderr("Warning: " + metrics.ErrWrite.Error() + ": 'PATH': ERROR")
+}
@@ -17,6 +17,7 @@
 */
 
 /*
-Package out provides functions to localize number formatting..
+Package out provides functions to localize number formatting and to write
+results in the selected output format (text, json or csv).
 */
 package out
@@ -44,15 +44,17 @@ func Pct(n float64) string {
 }
 
 // Print writes the provided text to os.Stdout of szlog has warnings enabled.
+// Nothing is written if structured output is selected.
 func Print(msg ...any) {
-	if szlog.Verbose() > 0 {
+	if szlog.Verbose() > 0 && !Structured() {
 		_, _ = printer.Print(msg...)
 	}
 }
 
 // Printf writes the provided text to os.Stdout of szlog has warnings enabled.
+// Nothing is written if structured output is selected.
 func Printf(msgFmt string, msgArgs ...any) {
-	if szlog.Verbose() > 0 {
+	if szlog.Verbose() > 0 && !Structured() {
 		_, _ = printer.Printf(msgFmt, msgArgs...)
 	}
 }
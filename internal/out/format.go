@@ -0,0 +1,115 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package out
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Output flag.
+const (
+	FlgOutput = "--output"
+)
+
+// Output errors.
+var (
+	ErrInvalidFormat     = errors.New("invalid output format")
+	ErrMissingFormat     = errors.New("missing output format")
+	ErrUnsupportedFormat = errors.New("output format not supported")
+)
+
+//nolint:goCheckNoGlobals // Ok.
+var format = FormatText
+
+// Format returns the selected output format.
+func Format() string {
+	return format
+}
+
+// SetFormat selects the output format.
+func SetFormat(newFormat string) error {
+	switch newFormat {
+	case FormatText, FormatJSON, FormatCSV:
+		format = newFormat
+
+		return nil
+	default:
+		return fmt.Errorf("%w: '%s'", ErrInvalidFormat, newFormat)
+	}
+}
+
+// Structured returns true if a machine readable output format is selected.
+// Human readable text is suppressed from os.Stdout leaving it to hold only
+// the structured result.
+func Structured() bool {
+	return format != FormatText
+}
+
+// TextWriter returns the file human readable output (IE: rsync's own
+// output) is written to.  It is os.Stderr when structured output is selected.
+func TextWriter() *os.File {
+	if Structured() {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
+
+// AbsorbArgs removes the output flag (--output format or --output=format)
+// from the arguments selecting the format.
+func AbsorbArgs(args []string) ([]string, error) {
+	var (
+		cleanedArgs = make([]string, 0, len(args))
+		value       string
+		found       bool
+		err         error
+	)
+
+	for i := 0; i < len(args) && err == nil; i++ {
+		value, found = strings.CutPrefix(args[i], FlgOutput+"=")
+
+		switch {
+		case found:
+			err = SetFormat(value)
+		case args[i] == FlgOutput && i+1 < len(args):
+			i++
+			err = SetFormat(args[i])
+		case args[i] == FlgOutput:
+			err = ErrMissingFormat
+		default:
+			cleanedArgs = append(cleanedArgs, args[i])
+		}
+	}
+
+	if err == nil {
+		return cleanedArgs, nil
+	}
+
+	return nil, err
+}
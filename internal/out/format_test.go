@@ -0,0 +1,126 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package out_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/sztestlog"
+)
+
+type sample struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+type samples []sample
+
+func (s samples) Header() []string {
+	return []string{"name", "size"}
+}
+
+func (s samples) Rows() [][]string {
+	rows := make([][]string, 0, len(s))
+	for _, item := range s {
+		rows = append(rows, []string{
+			item.Name, strconv.FormatInt(item.Size, 10),
+		})
+	}
+
+	return rows
+}
+
+func TestFormat_AbsorbArgs(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	chk.Str(out.Format(), out.FormatText)
+	chk.False(out.Structured())
+
+	args, err := out.AbsorbArgs([]string{"prg", "--output", "json", "vet"})
+	chk.NoErr(err)
+	chk.StrSlice(args, []string{"prg", "vet"})
+	chk.Str(out.Format(), out.FormatJSON)
+	chk.True(out.Structured())
+
+	args, err = out.AbsorbArgs([]string{"prg", "status", "--output=csv"})
+	chk.NoErr(err)
+	chk.StrSlice(args, []string{"prg", "status"})
+	chk.Str(out.Format(), out.FormatCSV)
+
+	args, err = out.AbsorbArgs([]string{"prg", "--output=xml"})
+	chk.Err(err, out.ErrInvalidFormat.Error()+": 'xml'")
+	chk.StrSlice(args, nil)
+
+	args, err = out.AbsorbArgs([]string{"prg", "status", "--output"})
+	chk.Err(err, out.ErrMissingFormat.Error())
+	chk.StrSlice(args, nil)
+}
+
+func TestFormat_Result(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	result := samples{{Name: "a", Size: 1}, {Name: "b,c", Size: 12345}}
+
+	txt, err := out.Result(result)
+	chk.Err(err, out.ErrUnsupportedFormat.Error()+": 'text'")
+	chk.Str(txt, "")
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	txt, err = out.Result(result)
+	chk.NoErr(err)
+	chk.Str(
+		txt,
+		""+
+			"[\n"+
+			"  {\n"+
+			"    \"name\": \"a\",\n"+
+			"    \"size\": 1\n"+
+			"  },\n"+
+			"  {\n"+
+			"    \"name\": \"b,c\",\n"+
+			"    \"size\": 12345\n"+
+			"  }\n"+
+			"]\n",
+	)
+
+	chk.NoErr(out.SetFormat(out.FormatCSV))
+
+	txt, err = out.Result(result)
+	chk.NoErr(err)
+	chk.Str(
+		txt,
+		""+
+			"name,size\n"+
+			"a,1\n"+
+			"\"b,c\",12345\n",
+	)
+
+	txt, err = out.Result(sample{Name: "a"})
+	chk.Err(err, out.ErrUnsupportedFormat.Error()+": 'csv'")
+	chk.Str(txt, "")
+}
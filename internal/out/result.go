@@ -0,0 +1,76 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package out
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+)
+
+// Tabular is implemented by results that may be written as comma separated
+// values.
+type Tabular interface {
+	// Header returns the names of the columns.
+	Header() []string
+	// Rows returns the values of each row.
+	Rows() [][]string
+}
+
+// Result returns the result formatted in the selected machine readable
+// format.  Only results implementing Tabular may be written as CSV.
+func Result(result any) (string, error) {
+	var (
+		buf     bytes.Buffer
+		data    []byte
+		tabular Tabular
+		ok      bool
+		err     error
+	)
+
+	switch format {
+	case FormatJSON:
+		data, err = json.MarshalIndent(result, "", "  ")
+		buf.Write(data)
+		buf.WriteByte('\n')
+	case FormatCSV:
+		tabular, ok = result.(Tabular)
+		if !ok {
+			err = fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, format)
+		}
+
+		if err == nil {
+			writer := csv.NewWriter(&buf)
+			err = writer.Write(tabular.Header())
+
+			if err == nil {
+				err = writer.WriteAll(tabular.Rows())
+			}
+		}
+	default:
+		err = fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, format)
+	}
+
+	if err == nil {
+		return buf.String(), nil
+	}
+
+	return "", err
+}
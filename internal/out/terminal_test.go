@@ -0,0 +1,43 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package out_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestTerminal_IsTerminal(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	file, err := os.Create(filepath.Join(chk.CreateTmpDir(), "notTerminal"))
+	chk.NoErr(err)
+
+	chk.False(out.IsTerminal(file))
+
+	chk.NoErr(file.Close())
+
+	// A closed file cannot be a terminal.
+	chk.False(out.IsTerminal(file))
+}
@@ -22,5 +22,42 @@ import "errors"
 
 // Help errors.
 var (
-	ErrRsyncError = errors.New("rsync error")
+	ErrRsyncError    = errors.New("rsync error")
+	ErrStatsNotFound = errors.New("rsync stats not found")
+	ErrStatsValue    = errors.New("invalid rsync stats value")
+	ErrEstimate      = errors.New("estimate failed")
+	ErrPreview       = errors.New("preview failed")
+	ErrStopped       = errors.New("rsync stopped")
+	ErrPriority      = errors.New("unable to set rsync priority")
+	ErrTimeout       = errors.New("rsync timed out")
+	ErrMaxRunTime    = errors.New("maximum run time exceeded")
+	ErrStalled       = errors.New("rsync stalled")
+)
+
+// Rsync exit code errors as documented by rsync(1).
+var (
+	ErrExitSyntax     = errors.New("syntax or usage error")
+	ErrExitProtocol   = errors.New("protocol incompatibility")
+	ErrExitFileSelect = errors.New(
+		"errors selecting input/output files, dirs",
+	)
+	ErrExitUnsupported = errors.New("requested action not supported")
+	ErrExitStartClient = errors.New("error starting client-server protocol")
+	ErrExitLogFile     = errors.New("daemon unable to append to log-file")
+	ErrExitSocketIO    = errors.New("error in socket I/O")
+	ErrExitFileIO      = errors.New("error in file I/O")
+	ErrExitStreamIO    = errors.New("error in rsync protocol data stream")
+	ErrExitMessageIO   = errors.New("errors with program diagnostics")
+	ErrExitIPC         = errors.New("error in IPC code")
+	ErrExitSignal      = errors.New("received SIGUSR1 or SIGINT")
+	ErrExitWaitChild   = errors.New("some error returned by waitpid()")
+	ErrExitMalloc      = errors.New("error allocating core memory buffers")
+	ErrExitPartial     = errors.New("partial transfer due to error")
+	ErrExitVanished    = errors.New(
+		"partial transfer due to vanished source files",
+	)
+	ErrExitDelLimit    = errors.New("the --max-delete limit stopped deletions")
+	ErrExitTimeout     = errors.New("timeout in data send/receive")
+	ErrExitConnTimeout = errors.New("timeout waiting for daemon connection")
+	ErrExitUnknown     = errors.New("unknown exit code")
 )
@@ -0,0 +1,98 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"context"
+	"fmt"
+)
+
+// Estimate options added to a dry run to report the statistics using plain
+// numbers.
+const (
+	FlgInfoStats       = "--info=stats2"
+	FlgNoHumanReadable = "--no-human-readable"
+)
+
+// quietOptions returns the options with any flags impacting rsync's output
+// removed.
+func quietOptions(options []string) []string {
+	cleaned := make([]string, 0, len(options))
+
+	for _, option := range options {
+		if !outConfigured([]string{option}) {
+			cleaned = append(cleaned, option)
+		}
+	}
+
+	return cleaned
+}
+
+// Estimate performs an rsync dry run returning the statistics it reports.
+// The statistics identify the bytes and iNodes a real run would require.
+// Errors reported by rsync are ignored if the statistics were still produced
+// (IE: Files vanishing or permissions denied) leaving them to be reported by
+// the real run.  The priority (if any) is applied to the dry run.
+func Estimate(
+	ctx context.Context,
+	linkDest string,
+	basicOptions []string,
+	additionalOptions []string,
+	fromPath string,
+	toPath string,
+	priority *Priority,
+) (Stats, error) {
+	var (
+		scanner  = NewStatsScanner(nil)
+		stats    Stats
+		statsErr error
+		err      error
+	)
+
+	err = Run(
+		ctx,
+		BuildArgs(
+			true, // Delete from target.
+			true, // Dry run.
+			linkDest,
+			quietOptions(basicOptions),
+			append(
+				quietOptions(additionalOptions),
+				FlgInfoStats, FlgNoHumanReadable,
+			),
+			fromPath,
+			toPath,
+		),
+		scanner,
+		nil,
+		nil,
+		priority,
+	)
+
+	stats, statsErr = scanner.Result()
+	if statsErr == nil {
+		return stats, nil
+	}
+
+	if err == nil {
+		err = statsErr
+	}
+
+	return Stats{}, fmt.Errorf("%w: %w", ErrEstimate, err)
+}
@@ -0,0 +1,83 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+//nolint:goCheckNoGlobals,mnd // Ok.
+var exitCodes = map[int]error{
+	1:  ErrExitSyntax,
+	2:  ErrExitProtocol,
+	3:  ErrExitFileSelect,
+	4:  ErrExitUnsupported,
+	5:  ErrExitStartClient,
+	6:  ErrExitLogFile,
+	10: ErrExitSocketIO,
+	11: ErrExitFileIO,
+	12: ErrExitStreamIO,
+	13: ErrExitMessageIO,
+	14: ErrExitIPC,
+	20: ErrExitSignal,
+	21: ErrExitWaitChild,
+	22: ErrExitMalloc,
+	23: ErrExitPartial,
+	24: ErrExitVanished,
+	25: ErrExitDelLimit,
+	30: ErrExitTimeout,
+	35: ErrExitConnTimeout,
+}
+
+// IsExitCode returns true if the code is a documented rsync exit code.
+func IsExitCode(code int) bool {
+	_, found := exitCodes[code]
+
+	return found
+}
+
+// ExitCode returns the exit code rsync reported in the error or zero if the
+// error does not represent an rsync exit.
+func ExitCode(err error) int {
+	var exitErr *exec.ExitError
+
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return 0
+}
+
+// exitError wraps the error returned from running rsync with the typed error
+// matching its exit code.
+func exitError(err error) error {
+	code := ExitCode(err)
+	if code <= 0 {
+		return err
+	}
+
+	codeErr, found := exitCodes[code]
+	if !found {
+		codeErr = ErrExitUnknown
+	}
+
+	return fmt.Errorf("%w (%d): %w", codeErr, code, err)
+}
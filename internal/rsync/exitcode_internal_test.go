@@ -0,0 +1,72 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestRsyncExitCode_Known(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.True(IsExitCode(24))
+	chk.False(IsExitCode(7))
+
+	err := exitError(exec.Command("sh", "-c", "exit 24").Run())
+
+	chk.Err(
+		err,
+		""+
+			ErrExitVanished.Error()+
+			" (24): exit status 24"+
+			"",
+	)
+	chk.True(errors.Is(err, ErrExitVanished))
+	chk.Int(ExitCode(err), 24)
+}
+
+func TestRsyncExitCode_Unknown(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	err := exitError(exec.Command("sh", "-c", "exit 7").Run())
+
+	chk.Err(
+		err,
+		""+
+			ErrExitUnknown.Error()+
+			" (7): exit status 7"+
+			"",
+	)
+	chk.Int(ExitCode(err), 7)
+}
+
+func TestRsyncExitCode_NotExit(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.NoErr(exitError(nil))
+	chk.Int(ExitCode(ErrStopped), 0)
+	chk.Err(exitError(ErrStopped), ErrStopped.Error())
+}
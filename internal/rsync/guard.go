@@ -0,0 +1,211 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Stall detection constants.
+const (
+	// stallCheckInterval is the longest time between checks for a stall.
+	stallCheckInterval = time.Second * 10
+	// killGrace is how long to wait for a killed process group to exit.
+	// A process blocked in the kernel (IE: a hung NFS mount) may never
+	// exit so it is abandoned rather than blocking forever.
+	killGrace = time.Second * 10
+	// termGrace is how long rsync is given to shutdown cleanly after a
+	// SIGTERM before its process group is killed.
+	termGrace = time.Second * 2
+)
+
+// Guard is checked periodically while rsync is running.  If Check returns an
+// error rsync is stopped and the error is returned by Run.  If StallTimeout
+// is set rsync is killed if it writes nothing to its standard output or
+// standard error for that long.
+type Guard struct {
+	// Interval between checks.
+	Interval time.Duration
+	// Check returns an error if rsync should be stopped.  It may be nil.
+	Check func() error
+	// StallTimeout is the longest rsync may go without output.  Zero
+	// disables stall detection.
+	StallTimeout time.Duration
+}
+
+// WithStallTimeout returns the guard (which may be nil) extended to kill
+// rsync if it writes no output or progress for the timeout.  A zero timeout
+// returns the guard unchanged.
+func WithStallTimeout(guard *Guard, timeout time.Duration) *Guard {
+	var extended Guard
+
+	if timeout <= 0 {
+		return guard
+	}
+
+	if guard != nil {
+		extended = *guard
+	}
+
+	if extended.Interval <= 0 {
+		extended.Interval = stallCheckInterval
+	}
+
+	extended.Interval = min(extended.Interval, timeout)
+	extended.StallTimeout = timeout
+
+	return &extended
+}
+
+// activity records when output was last written.
+type activity struct {
+	last atomic.Int64
+}
+
+func newActivity() *activity {
+	a := new(activity)
+	a.touch()
+
+	return a
+}
+
+func (a *activity) touch() {
+	a.last.Store(time.Now().UnixNano())
+}
+
+// idle returns how long it has been since output was last written.
+func (a *activity) idle() time.Duration {
+	return time.Since(time.Unix(0, a.last.Load()))
+}
+
+// writer returns a writer recording activity before forwarding to the
+// supplied writer (if any).
+func (a *activity) writer(forward io.Writer) io.Writer {
+	return activityWriter{activity: a, forward: forward}
+}
+
+type activityWriter struct {
+	activity *activity
+	forward  io.Writer
+}
+
+func (w activityWriter) Write(p []byte) (int, error) {
+	w.activity.touch()
+
+	if w.forward == nil {
+		return len(p), nil
+	}
+
+	return w.forward.Write(p) //nolint:wrapcheck // Ok.
+}
+
+// killGroup kills the command's process group (rsync and every process it
+// forked) waiting a short time for it to exit.
+func killGroup(cmd *exec.Cmd, done <-chan error) {
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+
+	select {
+	case <-done:
+	case <-time.After(killGrace):
+	}
+}
+
+// stopGroup sends the command's process group a SIGTERM allowing rsync to
+// shutdown cleanly.  If it has not exited after a short grace period the
+// process group is killed.
+func stopGroup(cmd *exec.Cmd, done <-chan error) {
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+
+	select {
+	case <-done:
+	case <-time.After(termGrace):
+		killGroup(cmd, done)
+	}
+}
+
+// wait waits for the started command to complete checking the guard (if
+// any) periodically.  If the guard reports an error the command's process
+// group is stopped allowing rsync to shutdown cleanly.  If the context is
+// done or rsync stalls its process group is killed.
+//
+//nolint:cyclop // Ok.
+func wait(
+	ctx context.Context,
+	cmd *exec.Cmd,
+	guard *Guard,
+	active *activity,
+) error {
+	var (
+		done   = make(chan error, 1)
+		ticker *time.Ticker
+		tick   <-chan time.Time
+		err    error
+	)
+
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	if guard != nil {
+		ticker = time.NewTicker(guard.Interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case err = <-done:
+			return err
+		case <-ctx.Done():
+			killGroup(cmd, done)
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("%w: %w", ErrTimeout, ErrMaxRunTime)
+			}
+
+			return ctx.Err() //nolint:wrapcheck // Ok.
+		case <-tick:
+			if guard.StallTimeout > 0 && active.idle() >= guard.StallTimeout {
+				killGroup(cmd, done)
+
+				return fmt.Errorf(
+					"%w: %w: no output for %v",
+					ErrTimeout, ErrStalled, guard.StallTimeout,
+				)
+			}
+
+			if guard.Check != nil {
+				err = guard.Check()
+				if err != nil {
+					stopGroup(cmd, done)
+
+					return fmt.Errorf("%w: %w", ErrStopped, err)
+				}
+			}
+		}
+	}
+}
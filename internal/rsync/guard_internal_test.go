@@ -0,0 +1,266 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+//nolint:goCheckNoGlobals // Ok.
+var errTestGuard = errors.New("test guard")
+
+func TestRsyncGuard_NoGuard(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cmd := exec.Command("true")
+	chk.NoErr(cmd.Start())
+
+	chk.NoErr(wait(context.Background(), cmd, nil, nil))
+}
+
+func TestRsyncGuard_Passes(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	checks := 0
+
+	cmd := exec.Command("sleep", "0.1")
+	chk.NoErr(cmd.Start())
+
+	chk.NoErr(
+		wait(
+			context.Background(),
+			cmd,
+			&Guard{
+				Interval: time.Millisecond * 10,
+				Check: func() error {
+					checks++
+
+					return nil
+				},
+			},
+			nil,
+		),
+	)
+
+	chk.True(checks > 0)
+}
+
+func TestRsyncGuard_Stops(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cmd := exec.Command("sleep", "10")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	chk.NoErr(cmd.Start())
+
+	start := time.Now()
+
+	err := wait(
+		context.Background(),
+		cmd,
+		&Guard{
+			Interval: time.Millisecond * 10,
+			Check: func() error {
+				return errTestGuard
+			},
+		},
+		nil,
+	)
+
+	chk.Err(
+		err,
+		""+
+			ErrStopped.Error()+
+			": "+
+			errTestGuard.Error()+
+			"",
+	)
+	chk.True(errors.Is(err, errTestGuard))
+	chk.True(time.Since(start) < termGrace)
+}
+
+func TestRsyncGuard_StopsIgnoringTerm(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cmd := exec.Command("sh", "-c", "trap '' TERM; sleep 30 & wait")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	chk.NoErr(cmd.Start())
+
+	start := time.Now()
+
+	err := wait(
+		context.Background(),
+		cmd,
+		&Guard{
+			Interval: time.Millisecond * 10,
+			Check: func() error {
+				return errTestGuard
+			},
+		},
+		nil,
+	)
+
+	chk.True(errors.Is(err, ErrStopped))
+	chk.True(time.Since(start) >= termGrace)
+	chk.True(time.Since(start) < termGrace+killGrace)
+}
+
+func TestRsyncGuard_WithStallTimeout(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.Nil(WithStallTimeout(nil, 0))
+
+	guard := WithStallTimeout(nil, time.Minute)
+	chk.Dur(guard.Interval, stallCheckInterval)
+	chk.Dur(guard.StallTimeout, time.Minute)
+	chk.True(guard.Check == nil)
+
+	guard = WithStallTimeout(nil, time.Second)
+	chk.Dur(guard.Interval, time.Second)
+
+	original := &Guard{
+		Interval: time.Second * 30,
+		Check: func() error {
+			return nil
+		},
+	}
+
+	guard = WithStallTimeout(original, time.Minute*5)
+	chk.Dur(guard.Interval, time.Second*30)
+	chk.Dur(guard.StallTimeout, time.Minute*5)
+	chk.True(guard.Check != nil)
+	chk.Dur(original.StallTimeout, 0)
+}
+
+func TestRsyncGuard_Stalled(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cmd := exec.Command("sleep", "10")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	chk.NoErr(cmd.Start())
+
+	start := time.Now()
+
+	err := wait(
+		context.Background(),
+		cmd,
+		WithStallTimeout(nil, time.Millisecond*50),
+		newActivity(),
+	)
+
+	chk.Err(
+		err,
+		""+
+			ErrTimeout.Error()+
+			": "+
+			ErrStalled.Error()+
+			": no output for 50ms",
+	)
+	chk.True(errors.Is(err, ErrTimeout))
+	chk.True(time.Since(start) < time.Second*5)
+}
+
+func TestRsyncGuard_ActiveNotStalled(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	active := newActivity()
+	output := new(strings.Builder)
+
+	cmd := exec.Command(
+		"sh", "-c", "for i in 1 2 3 4 5; do echo $i; sleep 0.05; done",
+	)
+	cmd.Stdout = active.writer(output)
+	chk.NoErr(cmd.Start())
+
+	chk.NoErr(
+		wait(
+			context.Background(),
+			cmd,
+			WithStallTimeout(nil, time.Millisecond*200),
+			active,
+		),
+	)
+
+	chk.Str(output.String(), "1\n2\n3\n4\n5\n")
+}
+
+func TestRsyncGuard_MaxRunTime(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), time.Millisecond*50,
+	)
+	defer cancel()
+
+	// The child sleep shares the process group and must also be killed
+	// for the output pipe to close.
+	output := new(strings.Builder)
+	cmd := exec.Command("sh", "-c", "sleep 10; echo done")
+	cmd.Stdout = output
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	chk.NoErr(cmd.Start())
+
+	start := time.Now()
+
+	err := wait(ctx, cmd, nil, nil)
+
+	chk.Err(
+		err,
+		""+
+			ErrTimeout.Error()+
+			": "+
+			ErrMaxRunTime.Error()+
+			"",
+	)
+	chk.True(time.Since(start) < killGrace)
+	chk.Str(output.String(), "")
+}
+
+func TestRsyncGuard_Canceled(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cmd := exec.Command("sleep", "10")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	chk.NoErr(cmd.Start())
+
+	cancel()
+
+	err := wait(ctx, cmd, nil, nil)
+
+	chk.Err(err, context.Canceled.Error())
+	chk.False(errors.Is(err, ErrTimeout))
+}
@@ -0,0 +1,219 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FlgItemize requests rsync report each change it makes.
+const FlgItemize = "--itemize-changes"
+
+const (
+	itemizeLen     = 11 // YXcstpoguax
+	itemizeDeleted = "*deleting"
+	itemizeNew     = '+'
+	itemizeSame    = '.'
+	itemizePerms   = 5 // Position of the permission flag.
+	linkSeparator  = " -> "
+)
+
+// ChangeKind categorizes an itemized change.
+type ChangeKind int
+
+// Itemized change categories.
+const (
+	// ChangeCreate identifies an item that does not exist at the destination.
+	ChangeCreate ChangeKind = iota
+	// ChangeOverwrite identifies an existing item whose content is replaced.
+	ChangeOverwrite
+	// ChangeDelete identifies an item deleted from the destination.
+	ChangeDelete
+	// ChangePermissions identifies an item only changing its permissions
+	// (possibly along with other attributes).
+	ChangePermissions
+	// ChangeAttributes identifies an item only changing other attributes
+	// (IE: modification times or ownership).
+	ChangeAttributes
+)
+
+// Change is a single item reported by FlgItemize.
+type Change struct {
+	// Kind of the change.
+	Kind ChangeKind
+	// Flags is the itemized change string (IE: >f.st......).
+	Flags string
+	// Name of the item relative to the transfer.  Directories end with a
+	// path separator.
+	Name string
+}
+
+// IsDir returns true if the change is to a directory.
+func (c Change) IsDir() bool {
+	return strings.HasSuffix(c.Name, "/")
+}
+
+// changeKind returns the kind of change described by the itemized flags.
+func changeKind(flags string) (ChangeKind, bool) {
+	attributes := flags[2:]
+
+	switch {
+	case flags == itemizeDeleted+"  ":
+		return ChangeDelete, true
+	case strings.Trim(attributes, string(itemizeNew)) == "":
+		return ChangeCreate, true
+	case flags[0] != itemizeSame:
+		return ChangeOverwrite, true
+	case flags[itemizePerms] == 'p':
+		return ChangePermissions, true
+	case strings.Trim(attributes, ". ") != "":
+		return ChangeAttributes, true
+	default:
+		return ChangeAttributes, false
+	}
+}
+
+// ParseItemized returns the change reported in the line if any.
+func ParseItemized(line string) (Change, bool) {
+	var (
+		change Change
+		found  bool
+	)
+
+	if len(line) <= itemizeLen+1 || line[itemizeLen] != ' ' ||
+		!strings.ContainsRune("<>ch.*", rune(line[0])) {
+		return change, false
+	}
+
+	change.Flags = line[:itemizeLen]
+	change.Name = line[itemizeLen+1:]
+
+	if line[0] != '*' && !strings.ContainsRune("fdLDS", rune(line[1])) {
+		return change, false
+	}
+
+	if line[1] == 'L' {
+		change.Name, _, _ = strings.Cut(change.Name, linkSeparator)
+	}
+
+	change.Kind, found = changeKind(change.Flags)
+
+	return change, found
+}
+
+// ItemizeScanner is an io.Writer that scans rsync's output line by line for
+// the changes requested by FlgItemize.  Lines not reporting a change are
+// forwarded to the provided writer (if any).
+type ItemizeScanner struct {
+	forward io.Writer
+	partial []byte
+	changes []Change
+}
+
+// NewItemizeScanner returns a scanner forwarding all output not reporting a
+// change to the provided writer which may be nil.
+func NewItemizeScanner(forward io.Writer) *ItemizeScanner {
+	return &ItemizeScanner{
+		forward: forward,
+	}
+}
+
+// Write implements io.Writer.
+func (s *ItemizeScanner) Write(p []byte) (int, error) {
+	s.partial = append(s.partial, p...)
+
+	for {
+		idx := bytes.IndexByte(s.partial, '\n')
+		if idx < 0 {
+			break
+		}
+
+		s.scanLine(string(s.partial[:idx]))
+		s.partial = s.partial[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+func (s *ItemizeScanner) scanLine(line string) {
+	line = strings.TrimRight(line, "\r")
+
+	change, found := ParseItemized(line)
+
+	switch {
+	case found:
+		s.changes = append(s.changes, change)
+	case line != "" && s.forward != nil:
+		_, _ = s.forward.Write([]byte(line + "\n"))
+	}
+}
+
+// Changes returns the changes reported.
+func (s *ItemizeScanner) Changes() []Change {
+	if len(s.partial) > 0 {
+		s.scanLine(string(s.partial))
+		s.partial = nil
+	}
+
+	return s.changes
+}
+
+// Preview performs an rsync dry run returning the changes a real run would
+// make to the destination.  Output other than the changes is forwarded to
+// the provided writer (if any).  The priority (if any) is applied to the dry
+// run.
+func Preview(
+	ctx context.Context,
+	deleteFromTarget bool,
+	basicOptions []string,
+	additionalOptions []string,
+	fromPath string,
+	toPath string,
+	cpyOut io.Writer,
+	priority *Priority,
+) ([]Change, error) {
+	scanner := NewItemizeScanner(cpyOut)
+
+	err := Run(
+		ctx,
+		BuildArgs(
+			deleteFromTarget,
+			true, // Dry run.
+			"",   // No linkDest.
+			quietOptions(basicOptions),
+			append(quietOptions(additionalOptions), FlgItemize),
+			fromPath,
+			toPath,
+		),
+		scanner,
+		nil,
+		nil,
+		priority,
+	)
+
+	if err == nil {
+		return scanner.Changes(), nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrPreview, err)
+}
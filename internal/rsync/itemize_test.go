@@ -0,0 +1,97 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestRsyncItemize_Parse(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	change, found := rsync.ParseItemized(">f+++++++++ source/new.txt")
+	chk.True(found)
+	chk.Int(int(change.Kind), int(rsync.ChangeCreate))
+	chk.Str(change.Name, "source/new.txt")
+	chk.Str(change.Flags, ">f+++++++++")
+
+	change, found = rsync.ParseItemized("cd+++++++++ source/dir/")
+	chk.True(found)
+	chk.Int(int(change.Kind), int(rsync.ChangeCreate))
+	chk.True(change.IsDir())
+
+	change, found = rsync.ParseItemized(">f.st...... source/file.txt")
+	chk.True(found)
+	chk.Int(int(change.Kind), int(rsync.ChangeOverwrite))
+	chk.False(change.IsDir())
+
+	change, found = rsync.ParseItemized("*deleting   source/old.txt")
+	chk.True(found)
+	chk.Int(int(change.Kind), int(rsync.ChangeDelete))
+	chk.Str(change.Name, "source/old.txt")
+
+	change, found = rsync.ParseItemized(".f...p..... source/run.sh")
+	chk.True(found)
+	chk.Int(int(change.Kind), int(rsync.ChangePermissions))
+
+	change, found = rsync.ParseItemized(".d..t...... source/")
+	chk.True(found)
+	chk.Int(int(change.Kind), int(rsync.ChangeAttributes))
+
+	change, found = rsync.ParseItemized("cL+++++++++ source/link -> file.txt")
+	chk.True(found)
+	chk.Str(change.Name, "source/link")
+
+	_, found = rsync.ParseItemized(".f          source/same.txt")
+	chk.False(found)
+
+	_, found = rsync.ParseItemized("sending incremental file list")
+	chk.False(found)
+
+	_, found = rsync.ParseItemized("")
+	chk.False(found)
+}
+
+func TestRsyncItemize_Scanner(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var forwarded strings.Builder
+
+	scanner := rsync.NewItemizeScanner(&forwarded)
+
+	_, _ = scanner.Write([]byte("sending incremental file list\n"))
+	_, _ = scanner.Write([]byte(">f+++++++++ source/new.txt\n*dele"))
+	_, _ = scanner.Write([]byte("ting   source/old.txt\n"))
+	_, _ = scanner.Write([]byte(".f...p..... source/run.sh"))
+
+	changes := scanner.Changes()
+
+	chk.Int(len(changes), 3)
+	chk.Str(changes[0].Name, "source/new.txt")
+	chk.Int(int(changes[1].Kind), int(rsync.ChangeDelete))
+	chk.Str(changes[2].Name, "source/run.sh")
+
+	chk.Str(forwarded.String(), "sending incremental file list\n")
+}
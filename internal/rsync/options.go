@@ -29,6 +29,7 @@ const (
 	FlgDryRun    = "--dry-run"
 	FlgDelete    = "--delete"
 	FlgLinkDest  = "--link-dest="
+	FlgBWLimit   = "--bwlimit="
 	extraOptions = 5 // Flags plus source and destination.
 )
 
@@ -98,3 +99,42 @@ func BuildArgs(
 
 	return options
 }
+
+// insertOptions returns the arguments with the options inserted ahead of the
+// source and destination paths.
+func insertOptions(args []string, options ...string) []string {
+	const paths = 2 // Source and destination.
+
+	split := max(len(args)-paths, 0)
+
+	inserted := make([]string, 0, len(args)+len(options))
+	inserted = append(inserted, args[:split]...)
+	inserted = append(inserted, options...)
+
+	return append(inserted, args[split:]...)
+}
+
+// WithStats returns the arguments with the flags requesting statistics using
+// plain numbers inserted ahead of the source and destination paths.  They
+// are added after BuildArgs so that they do not count as configured output
+// options.
+func WithStats(args []string) []string {
+	return insertOptions(args, FlgInfoStats, FlgNoHumanReadable)
+}
+
+// WithProgress returns the arguments with the flag requesting progress
+// updates inserted ahead of the source and destination paths.
+func WithProgress(args []string) []string {
+	return insertOptions(args, FlgInfoProgress)
+}
+
+// WithBandwidthLimit returns the arguments with the bandwidth limit inserted
+// ahead of the source and destination paths.  An empty limit returns the
+// arguments unchanged.
+func WithBandwidthLimit(args []string, limit string) []string {
+	if limit == "" {
+		return args
+	}
+
+	return insertOptions(args, FlgBWLimit+limit)
+}
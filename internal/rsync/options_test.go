@@ -243,3 +243,66 @@ func TestRsync_BuildArgs_WithOnlyAdditional(t *testing.T) {
 		},
 	)
 }
+
+func TestRsync_WithStats(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.StrSlice(
+		rsync.WithStats([]string{"-a", "from", "to"}),
+		[]string{
+			"-a",
+			rsync.FlgInfoStats,
+			rsync.FlgNoHumanReadable,
+			"from",
+			"to",
+		},
+	)
+
+	chk.StrSlice(
+		rsync.WithStats(nil),
+		[]string{
+			rsync.FlgInfoStats,
+			rsync.FlgNoHumanReadable,
+		},
+	)
+}
+
+func TestRsync_WithProgress(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.StrSlice(
+		rsync.WithProgress([]string{"-a", "from", "to"}),
+		[]string{
+			"-a",
+			rsync.FlgInfoProgress,
+			"from",
+			"to",
+		},
+	)
+}
+
+func TestRsync_WithBandwidthLimit(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.StrSlice(
+		rsync.WithBandwidthLimit([]string{"-a", "from", "to"}, ""),
+		[]string{
+			"-a",
+			"from",
+			"to",
+		},
+	)
+
+	chk.StrSlice(
+		rsync.WithBandwidthLimit([]string{"-a", "from", "to"}, "5M"),
+		[]string{
+			"-a",
+			rsync.FlgBWLimit + "5M",
+			"from",
+			"to",
+		},
+	)
+}
@@ -0,0 +1,117 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// I/O scheduling classes as documented by ioprio_set(2).
+const (
+	IOClassNone       = 0
+	IOClassRealtime   = 1
+	IOClassBestEffort = 2
+	IOClassIdle       = 3
+)
+
+// I/O priority encoding constants as documented by ioprio_set(2).
+const (
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+)
+
+// Priority defines the CPU and I/O scheduling priority applied to rsync.
+type Priority struct {
+	// Nice is the CPU niceness (1-19).  Zero leaves it unchanged.
+	Nice int
+	// IOClass is one of the IOClass constants.  IOClassNone leaves the I/O
+	// priority unchanged.
+	IOClass int
+	// IOLevel is the priority level (0-7) within the realtime and
+	// best-effort classes.
+	IOLevel int
+}
+
+// IsSet returns true if the priority changes either the CPU niceness or the
+// I/O priority.
+func (p *Priority) IsSet() bool {
+	return p != nil && (p.Nice != 0 || p.IOClass != IOClassNone)
+}
+
+// apply sets the priority on the calling thread.
+func (p *Priority) apply() error {
+	var (
+		tid = unix.Gettid()
+		err error
+	)
+
+	if p.Nice != 0 {
+		err = unix.Setpriority(unix.PRIO_PROCESS, tid, p.Nice)
+	}
+
+	if err == nil && p.IOClass != IOClassNone {
+		_, _, errno := unix.Syscall(
+			unix.SYS_IOPRIO_SET,
+			ioprioWhoProcess,
+			uintptr(tid),
+			uintptr(p.IOClass<<ioprioClassShift|p.IOLevel),
+		)
+		if errno != 0 {
+			err = errno
+		}
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrPriority, err)
+}
+
+// start starts the command with the priority (if any) applied.  The priority
+// is set on a locked operating system thread before the command is started
+// so that rsync and every process it forks inherit it.  The thread is never
+// unlocked causing it to be discarded, as an unprivileged thread cannot lower
+// its niceness back again.
+func start(cmd *exec.Cmd, priority *Priority) error {
+	var done chan error
+
+	if !priority.IsSet() {
+		return cmd.Start() //nolint:wrapcheck // Ok.
+	}
+
+	done = make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+
+		err := priority.apply()
+		if err == nil {
+			err = cmd.Start()
+		}
+
+		done <- err
+	}()
+
+	return <-done
+}
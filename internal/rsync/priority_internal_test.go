@@ -0,0 +1,90 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestRsyncPriority_NotSet(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var priority *Priority
+
+	chk.False(priority.IsSet())
+	chk.False((&Priority{}).IsSet())
+	chk.True((&Priority{Nice: 10}).IsSet())
+	chk.True((&Priority{IOClass: IOClassIdle}).IsSet())
+
+	cmd := exec.Command("nice")
+	output := new(strings.Builder)
+	cmd.Stdout = output
+
+	chk.NoErr(start(cmd, nil))
+	chk.NoErr(cmd.Wait())
+	chk.Str(output.String(), "0\n")
+}
+
+func TestRsyncPriority_Nice(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cmd := exec.Command("nice")
+	output := new(strings.Builder)
+	cmd.Stdout = output
+
+	chk.NoErr(start(cmd, &Priority{Nice: 15}))
+	chk.NoErr(cmd.Wait())
+	chk.Str(output.String(), "15\n")
+
+	// The priority must not leak into commands started afterwards.
+	cmd = exec.Command("nice")
+	output = new(strings.Builder)
+	cmd.Stdout = output
+
+	chk.NoErr(start(cmd, nil))
+	chk.NoErr(cmd.Wait())
+	chk.Str(output.String(), "0\n")
+}
+
+func TestRsyncPriority_IOClass(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cmd := exec.Command("ionice")
+	output := new(strings.Builder)
+	cmd.Stdout = output
+
+	chk.NoErr(start(cmd, &Priority{IOClass: IOClassIdle}))
+	chk.NoErr(cmd.Wait())
+	chk.Str(output.String(), "idle\n")
+
+	cmd = exec.Command("ionice")
+	output = new(strings.Builder)
+	cmd.Stdout = output
+
+	chk.NoErr(start(cmd, &Priority{IOClass: IOClassBestEffort, IOLevel: 6}))
+	chk.NoErr(cmd.Wait())
+	chk.Str(output.String(), "best-effort: prio 6\n")
+}
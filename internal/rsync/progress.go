@@ -0,0 +1,198 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szlog"
+)
+
+// FlgInfoProgress requests rsync report the progress of the whole transfer.
+const FlgInfoProgress = "--info=progress2"
+
+// ProgressLogInterval is how often progress is written when the output is
+// not a terminal.
+const ProgressLogInterval = time.Second * 30
+
+const progressClearLine = "          "
+
+//nolint:goCheckNoGlobals // Ok.
+var reProgress = regexp.MustCompile(
+	`^\s*([\d,.]+[KMGTP]?)\s+(\d+)%\s+(\S+/s)\s+(\d+:\d\d:\d\d)`,
+)
+
+// Progress is a single progress update reported by rsync.
+type Progress struct {
+	// Bytes transferred so far.
+	Bytes uint64
+	// Percent of the transfer completed.
+	Percent int
+	// Rate of the transfer as reported by rsync (IE: 12.34MB/s).
+	Rate string
+	// Remaining is the estimated time remaining (IE: 0:01:23).
+	Remaining string
+}
+
+// String implements the Stringer interface.
+func (p Progress) String() string {
+	return fmt.Sprintf("Progress: %3d%% %s bytes %s ETA %s",
+		p.Percent, out.Uint(p.Bytes), p.Rate, p.Remaining,
+	)
+}
+
+// parseProgress returns the progress reported in the line if any.
+func parseProgress(line string) (Progress, bool) {
+	var (
+		progress Progress
+		err      error
+	)
+
+	match := reProgress.FindStringSubmatch(line)
+	if match == nil {
+		return progress, false
+	}
+
+	progress.Bytes, err = parseNumber(match[1])
+	if err == nil {
+		progress.Percent, err = strconv.Atoi(match[2])
+	}
+
+	progress.Rate = match[3]
+	progress.Remaining = match[4]
+
+	return progress, err == nil
+}
+
+// ProgressScanner is an io.Writer that scans rsync's output for the progress
+// updates requested by FlgInfoProgress.  Rsync separates the updates with
+// carriage returns.  Each update is passed to the report function (if any)
+// and all other output is forwarded to the provided writer (if any).
+type ProgressScanner struct {
+	forward      io.Writer
+	report       func(Progress)
+	partial      []byte
+	lastProgress bool
+}
+
+// NewProgressScanner returns a scanner reporting progress updates and
+// forwarding all other output.
+func NewProgressScanner(
+	forward io.Writer, report func(Progress),
+) *ProgressScanner {
+	return &ProgressScanner{
+		forward: forward,
+		report:  report,
+	}
+}
+
+// Write implements io.Writer.
+func (s *ProgressScanner) Write(p []byte) (int, error) {
+	s.partial = append(s.partial, p...)
+
+	for {
+		idx := bytes.IndexAny(s.partial, "\r\n")
+		if idx < 0 {
+			break
+		}
+
+		s.scanSegment(string(s.partial[:idx]))
+		s.partial = s.partial[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+func (s *ProgressScanner) scanSegment(segment string) {
+	progress, found := parseProgress(segment)
+
+	switch {
+	case found:
+		if s.report != nil {
+			s.report(progress)
+		}
+	case segment == "" && s.lastProgress:
+		// Line ending terminating the progress updates.
+	case s.forward != nil:
+		_, _ = s.forward.Write([]byte(segment + "\n"))
+	}
+
+	s.lastProgress = found
+}
+
+// Flush forwards any remaining partial line.
+func (s *ProgressScanner) Flush() {
+	if len(s.partial) > 0 {
+		s.scanSegment(string(s.partial))
+		s.partial = nil
+	}
+}
+
+// ProgressDisplay shows progress updates.  On a terminal a single line is
+// updated in place.  Otherwise a line is written at most once per interval.
+type ProgressDisplay struct {
+	terminal bool
+	interval time.Duration
+	last     time.Time
+	shown    bool
+}
+
+// NewProgressDisplay returns a display for progress updates.
+func NewProgressDisplay(
+	terminal bool, interval time.Duration,
+) *ProgressDisplay {
+	return &ProgressDisplay{
+		terminal: terminal,
+		interval: interval,
+	}
+}
+
+// Show displays the progress update.
+func (d *ProgressDisplay) Show(progress Progress) {
+	if d.terminal {
+		szlog.Say0f("%s%s\r", progress, progressClearLine)
+
+		d.shown = true
+
+		return
+	}
+
+	now := time.Now()
+	if !d.shown || now.Sub(d.last) >= d.interval {
+		szlog.Say0f("%s\n", progress)
+
+		d.last = now
+		d.shown = true
+	}
+}
+
+// Done completes the display leaving the final update on a terminal.
+func (d *ProgressDisplay) Done() {
+	if d.terminal && d.shown {
+		szlog.Say0f("\n")
+	}
+
+	d.shown = false
+}
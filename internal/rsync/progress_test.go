@@ -0,0 +1,108 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestRsyncProgress_Scanner(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var (
+		forwarded strings.Builder
+		reported  []string
+	)
+
+	scanner := rsync.NewProgressScanner(
+		&forwarded,
+		func(progress rsync.Progress) {
+			reported = append(reported, progress.String())
+		},
+	)
+
+	_, _ = scanner.Write([]byte("sending incremental file list\n"))
+	_, _ = scanner.Write([]byte(
+		"         32,768  25%   31.25MB/s    0:00:03 (xfr#1, to-chk=3/5)\r",
+	))
+	_, _ = scanner.Write([]byte("        131072 100%   62.50MB/s"))
+	_, _ = scanner.Write([]byte("    0:00:00 (xfr#2, to-chk=0/5)\n"))
+	_, _ = scanner.Write([]byte("Number of files: 5"))
+	scanner.Flush()
+
+	chk.StrSlice(
+		reported,
+		[]string{
+			"Progress:  25% 32,768 bytes 31.25MB/s ETA 0:00:03",
+			"Progress: 100% 131,072 bytes 62.50MB/s ETA 0:00:00",
+		},
+	)
+
+	chk.Str(
+		forwarded.String(),
+		"sending incremental file list\nNumber of files: 5\n",
+	)
+}
+
+func TestRsyncProgress_DisplayTerminal(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	display := rsync.NewProgressDisplay(true, time.Hour)
+
+	display.Show(rsync.Progress{
+		Bytes: 1, Percent: 1, Rate: "1.00kB/s", Remaining: "0:00:02",
+	})
+	display.Show(rsync.Progress{
+		Bytes: 2, Percent: 2, Rate: "2.00kB/s", Remaining: "0:00:01",
+	})
+	display.Done()
+
+	chk.Stdout(
+		"" +
+			"Progress:   1% 1 bytes 1.00kB/s ETA 0:00:02          \r" +
+			"Progress:   2% 2 bytes 2.00kB/s ETA 0:00:01          \r",
+	)
+}
+
+func TestRsyncProgress_DisplayLog(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	display := rsync.NewProgressDisplay(false, time.Hour)
+
+	display.Show(rsync.Progress{
+		Bytes: 1, Percent: 1, Rate: "1.00kB/s", Remaining: "0:00:02",
+	})
+	// Suppressed until the interval has passed.
+	display.Show(rsync.Progress{
+		Bytes: 2, Percent: 2, Rate: "2.00kB/s", Remaining: "0:00:01",
+	})
+	display.Done()
+
+	chk.Stdout(
+		"Progress:   1% 1 bytes 1.00kB/s ETA 0:00:02",
+	)
+}
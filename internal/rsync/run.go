@@ -19,28 +19,39 @@
 package rsync
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"os"
 	"os/exec"
 	"strings"
+	"syscall"
 
 	"github.com/dancsecs/szbck/internal/out"
 )
 
-// Run executes rsync with the supplied arguments.
-func Run(args []string, cpyOut, cpyErr *os.File) error {
+// Run executes rsync with the supplied arguments copying its standard output
+// and standard error to the provided writers if they are not nil.  If a guard
+// is provided it is checked periodically while rsync runs stopping rsync if it
+// reports an error or stalls.  Rsync is started in its own process group which
+// is killed if the context is done.  If a priority is provided rsync is
+// started with it applied.  A non-zero exit is wrapped with the error matching
+// the exit code (see ExitCode).
+func Run(
+	ctx context.Context,
+	args []string,
+	cpyOut, cpyErr io.Writer,
+	guard *Guard,
+	priority *Priority,
+) error {
 	var (
-		rsyncPath    string
-		cmd          *exec.Cmd
-		outCloser    io.ReadCloser
-		outCloserErr io.ReadCloser
-		err          error
+		rsyncPath string
+		cmd       *exec.Cmd
+		active    *activity
+		err       error
 	)
 
 	rsyncPath, err = exec.LookPath("rsync")
 
-	//nolint:nestif // Ok.
 	if err == nil {
 		out.Printf(
 			"Running command: %s %s\n", rsyncPath, strings.Join(args, " "),
@@ -48,27 +59,23 @@ func Run(args []string, cpyOut, cpyErr *os.File) error {
 
 		cmd = exec.Command(rsyncPath, args...) //nolint:gosec // Ok.
 
-		if cpyOut != nil {
-			outCloser, err = cmd.StdoutPipe()
-			if err == nil {
-				go func() {
-					_, _ = io.Copy(cpyOut, outCloser)
-					_ = outCloser.Close()
-				}()
-			}
-		}
+		// Assigned directly so that Wait insures all output has been copied
+		// before returning.
+		cmd.Stdout = cpyOut
+		cmd.Stderr = cpyErr
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-		if cpyErr != nil {
-			outCloserErr, err = cmd.StderrPipe()
-			if err == nil {
-				go func() {
-					_, _ = io.Copy(cpyErr, outCloserErr)
-					_ = outCloserErr.Close()
-				}()
-			}
+		if guard != nil && guard.StallTimeout > 0 {
+			active = newActivity()
+			cmd.Stdout = active.writer(cpyOut)
+			cmd.Stderr = active.writer(cpyErr)
 		}
 
-		err = cmd.Run()
+		err = start(cmd, priority)
+	}
+
+	if err == nil {
+		err = exitError(wait(ctx, cmd, guard, active))
 	}
 
 	if err == nil {
@@ -19,6 +19,7 @@
 package rsync_test
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -30,12 +31,14 @@ func TestRsyncRun_NoArgs(t *testing.T) {
 	chk := sztestlog.CaptureLogAndStderrAndStdout(t)
 	defer chk.Release()
 
-	err := rsync.Run(nil, os.Stdout, nil)
+	err := rsync.Run(context.Background(), nil, os.Stdout, nil, nil, nil)
 
 	chk.Err(
 		err,
 		rsync.ErrRsyncError.Error()+
-			": exit status 1"+
+			": "+
+			rsync.ErrExitSyntax.Error()+
+			" (1): exit status 1"+
 			"",
 	)
 
@@ -60,7 +63,10 @@ func TestRsyncRun_SimpleFiles(t *testing.T) {
 	_ = chk.CreateTmpFileIn(source, []byte("file1"))
 	_ = chk.CreateTmpFileIn(source, []byte("file2"))
 
-	err := rsync.Run([]string{"-av", source, target}, os.Stdout, os.Stderr)
+	err := rsync.Run(
+		context.Background(),
+		[]string{"-av", source, target}, os.Stdout, os.Stderr, nil, nil,
+	)
 
 	chk.NoErr(err)
 
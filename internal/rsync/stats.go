@@ -0,0 +1,361 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/out"
+)
+
+// Stats captures the summary rsync reports when run with --stats.
+type Stats struct {
+	// Files is the total number of items examined.
+	Files uint64 `json:"files"`
+	// CreatedFiles is the number of items created in the destination.
+	CreatedFiles uint64 `json:"createdFiles"`
+	// CreatedRegular is the number of created items that are regular files.
+	CreatedRegular uint64 `json:"createdRegular"`
+	// DeletedFiles is the number of items deleted from the destination.
+	DeletedFiles uint64 `json:"deletedFiles"`
+	// TransferredFiles is the number of regular files actually transferred.
+	TransferredFiles uint64 `json:"transferredFiles"`
+	// TotalFileSize is the size of all of the examined files.
+	TotalFileSize uint64 `json:"totalFileSize"`
+	// TransferredFileSize is the size of all of the transferred files.
+	TransferredFileSize uint64 `json:"transferredFileSize"`
+	// LiteralData is the number of bytes of file data that had to be sent.
+	LiteralData uint64 `json:"literalData"`
+	// MatchedData is the number of bytes rebuilt from data already present
+	// in the destination.
+	MatchedData uint64 `json:"matchedData"`
+	// BytesSent is the total number of bytes rsync sent including metadata.
+	BytesSent uint64 `json:"bytesSent"`
+	// BytesReceived is the total number of bytes rsync received.
+	BytesReceived uint64 `json:"bytesReceived"`
+	// Speedup is the total file size divided by the bytes sent and received.
+	Speedup float64 `json:"speedup"`
+	// Elapsed is the time rsync took to run.  It is not reported by rsync
+	// and must be set by the caller.
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// NeededBytes estimates the bytes a run consumes in the destination.  Files
+// hard linked to a --link-dest directory are not transferred and therefore
+// do not require any new space.
+func (s Stats) NeededBytes() uint64 {
+	return s.TransferredFileSize
+}
+
+// NeededINodes estimates the iNodes a run consumes in the destination.
+// Every created item needs a new iNode except for regular files hard linked
+// to a --link-dest directory rather than being transferred.
+func (s Stats) NeededINodes() uint64 {
+	linked := uint64(0)
+	if s.CreatedRegular > s.TransferredFiles {
+		linked = s.CreatedRegular - s.TransferredFiles
+	}
+
+	if s.CreatedFiles > linked {
+		return s.CreatedFiles - linked
+	}
+
+	return 0
+}
+
+// Report returns the statistics formatted for display.
+func (s Stats) Report() string {
+	const (
+		lineFmt   = "%13s %20s\n"
+		lastFmt   = "%13s %20s"
+		precision = 2
+	)
+
+	return fmt.Sprintf(lineFmt, "Files:", out.Uint(s.Files)) +
+		fmt.Sprintf(lineFmt, "Transferred:", out.Uint(s.TransferredFiles)) +
+		fmt.Sprintf(lineFmt, "Literal:", out.Uint(s.LiteralData)) +
+		fmt.Sprintf(lineFmt, "Matched:", out.Uint(s.MatchedData)) +
+		fmt.Sprintf(lineFmt, "Sent:", out.Uint(s.BytesSent)) +
+		fmt.Sprintf(lineFmt, "Speedup:",
+			strconv.FormatFloat(s.Speedup, 'f', precision, 64),
+		) +
+		fmt.Sprintf(lastFmt, "Elapsed:", FormatElapsed(s.Elapsed))
+}
+
+// FormatElapsed returns the duration in seconds to a tenth of a second.
+func FormatElapsed(elapsed time.Duration) string {
+	return strconv.FormatFloat(elapsed.Seconds(), 'f', 1, 64) + "s"
+}
+
+// parseNumber interprets a number as printed by rsync.  Thousands
+// separators are removed and any unit suffix added by the --human-readable
+// option is applied.
+func parseNumber(raw string) (uint64, error) {
+	const (
+		base = 10
+		bits = 64
+		unit = 1000.0
+	)
+
+	var (
+		multiplier = 1.0
+		value      uint64
+		fValue     float64
+		err        error
+	)
+
+	raw = strings.TrimSpace(strings.TrimSuffix(raw, "bytes"))
+	raw = strings.ReplaceAll(raw, ",", "")
+
+	if raw != "" {
+		switch raw[len(raw)-1] {
+		case 'P':
+			multiplier *= unit
+
+			fallthrough
+		case 'T':
+			multiplier *= unit
+
+			fallthrough
+		case 'G':
+			multiplier *= unit
+
+			fallthrough
+		case 'M':
+			multiplier *= unit
+
+			fallthrough
+		case 'K':
+			multiplier *= unit
+			raw = raw[:len(raw)-1]
+		}
+	}
+
+	if multiplier == 1.0 && !strings.Contains(raw, ".") {
+		value, err = strconv.ParseUint(raw, base, bits)
+	} else {
+		fValue, err = strconv.ParseFloat(raw, bits)
+		value = uint64(fValue * multiplier)
+	}
+
+	if err == nil {
+		return value, nil
+	}
+
+	return 0, fmt.Errorf("%w: '%s'", ErrStatsValue, raw)
+}
+
+// parseBreakdown returns the named count from a breakdown of the form
+// "(reg: 3, dir: 2)".
+func parseBreakdown(breakdown, name string) (uint64, error) {
+	breakdown = strings.Trim(strings.TrimSpace(breakdown), "()")
+
+	for _, item := range strings.Split(breakdown, ", ") {
+		key, value, found := strings.Cut(item, ":")
+		if found && strings.TrimSpace(key) == name {
+			return parseNumber(value)
+		}
+	}
+
+	return 0, nil
+}
+
+// parseLine updates the statistics from a single line of rsync output
+// returning true if the line was part of the --stats summary.
+func (s *Stats) parseLine(line string) (bool, error) {
+	var (
+		key       string
+		value     string
+		breakdown string
+		found     bool
+		err       error
+	)
+
+	if strings.HasPrefix(line, "sent ") &&
+		strings.HasSuffix(line, "bytes/sec") {
+		return true, nil
+	}
+
+	if strings.HasPrefix(line, "total size is ") {
+		return true, s.parseSpeedup(line)
+	}
+
+	key, value, found = strings.Cut(line, ": ")
+	if !found {
+		return false, nil
+	}
+
+	value, breakdown, _ = strings.Cut(strings.TrimSpace(value), " (")
+
+	switch key {
+	case "Number of files":
+		s.Files, err = parseNumber(value)
+	case "Number of created files":
+		s.CreatedFiles, err = parseNumber(value)
+		if err == nil {
+			s.CreatedRegular, err = parseBreakdown(breakdown, "reg")
+		}
+	case "Number of deleted files":
+		s.DeletedFiles, err = parseNumber(value)
+	case "Number of regular files transferred":
+		s.TransferredFiles, err = parseNumber(value)
+	case "Total file size":
+		s.TotalFileSize, err = parseNumber(value)
+	case "Total transferred file size":
+		s.TransferredFileSize, err = parseNumber(value)
+	case "Literal data":
+		s.LiteralData, err = parseNumber(value)
+	case "Matched data":
+		s.MatchedData, err = parseNumber(value)
+	case "Total bytes sent":
+		s.BytesSent, err = parseNumber(value)
+	case "Total bytes received":
+		s.BytesReceived, err = parseNumber(value)
+	case "File list size",
+		"File list generation time",
+		"File list transfer time":
+		// Recognized but not captured.
+	default:
+		return false, nil
+	}
+
+	if err == nil {
+		return true, nil
+	}
+
+	return true, fmt.Errorf("%w: %s", err, line)
+}
+
+// parseSpeedup captures the speedup from the final summary line of the form
+// "total size is 123  speedup is 0.45" possibly followed by " (DRY RUN)".
+func (s *Stats) parseSpeedup(line string) error {
+	const bits = 64
+
+	var err error
+
+	_, speedup, found := strings.Cut(line, "speedup is ")
+	if found {
+		speedup, _, _ = strings.Cut(speedup, " ")
+		s.Speedup, err = strconv.ParseFloat(
+			strings.ReplaceAll(speedup, ",", ""), bits,
+		)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrStatsValue, line)
+}
+
+// StatsScanner is an io.Writer that scans rsync's output line by line for
+// the --stats summary.  Lines not belonging to the summary are forwarded to
+// the provided writer (if any).
+type StatsScanner struct {
+	forward io.Writer
+	partial []byte
+	blanks  int
+	stats   Stats
+	found   bool
+	err     error
+}
+
+// NewStatsScanner returns a scanner forwarding all output not part of the
+// statistics to the provided writer which may be nil.
+func NewStatsScanner(forward io.Writer) *StatsScanner {
+	return &StatsScanner{
+		forward: forward,
+	}
+}
+
+// Write implements io.Writer.
+func (s *StatsScanner) Write(p []byte) (int, error) {
+	s.partial = append(s.partial, p...)
+
+	for {
+		idx := bytes.IndexByte(s.partial, '\n')
+		if idx < 0 {
+			break
+		}
+
+		s.scanLine(string(s.partial[:idx]))
+		s.partial = s.partial[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+// scanLine parses the line forwarding it if it is not part of the summary.
+// Blank lines are held back as rsync precedes the summary with one.
+func (s *StatsScanner) scanLine(line string) {
+	line = strings.TrimRight(line, "\r")
+
+	if line == "" {
+		s.blanks++
+
+		return
+	}
+
+	found, err := s.stats.parseLine(line)
+
+	s.found = s.found || found
+	if s.err == nil {
+		s.err = err
+	}
+
+	if !found && s.forward != nil {
+		_, _ = s.forward.Write(
+			[]byte(strings.Repeat("\n", s.blanks) + line + "\n"),
+		)
+	}
+
+	s.blanks = 0
+}
+
+// Result returns the collected statistics.
+func (s *StatsScanner) Result() (Stats, error) {
+	if len(s.partial) > 0 {
+		s.scanLine(string(s.partial))
+		s.partial = nil
+	}
+
+	if s.err == nil && !s.found {
+		s.err = ErrStatsNotFound
+	}
+
+	if s.err == nil {
+		return s.stats, nil
+	}
+
+	return Stats{}, s.err
+}
+
+// ParseStats extracts the --stats summary from rsync's output.
+func ParseStats(txt string) (Stats, error) {
+	scanner := NewStatsScanner(nil)
+
+	_, _ = scanner.Write([]byte(txt))
+
+	return scanner.Result()
+}
@@ -0,0 +1,198 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestRsyncStats_NotFound(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	stats, err := rsync.ParseStats("sending incremental file list\n")
+
+	chk.Err(err, rsync.ErrStatsNotFound.Error())
+	chk.Uint64(stats.Files, 0)
+}
+
+func TestRsyncStats_InvalidValue(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	stats, err := rsync.ParseStats("Number of files: abc\n")
+
+	chk.Err(
+		err,
+		""+
+			rsync.ErrStatsValue.Error()+
+			": 'abc'"+
+			": Number of files: abc"+
+			"",
+	)
+	chk.Uint64(stats.Files, 0)
+}
+
+func TestRsyncStats_Plain(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	stats, err := rsync.ParseStats(strings.Join([]string{
+		"",
+		"Number of files: 7 (reg: 4, dir: 2, link: 1)",
+		"Number of created files: 7 (reg: 4, dir: 2, link: 1)",
+		"Number of deleted files: 0",
+		"Number of regular files transferred: 1",
+		"Total file size: 123456 bytes",
+		"Total transferred file size: 1024 bytes",
+		"Literal data: 0 bytes",
+		"Matched data: 0 bytes",
+		"File list size: 0",
+		"File list generation time: 0.001 seconds",
+		"File list transfer time: 0.000 seconds",
+		"Total bytes sent: 239",
+		"Total bytes received: 36",
+		"",
+		"sent 239 bytes  received 36 bytes  550.00 bytes/sec",
+		"total size is 123456  speedup is 448.93 (DRY RUN)",
+	}, "\n"))
+
+	chk.NoErr(err)
+	chk.Uint64(stats.Files, 7)
+	chk.Uint64(stats.CreatedFiles, 7)
+	chk.Uint64(stats.CreatedRegular, 4)
+	chk.Uint64(stats.DeletedFiles, 0)
+	chk.Uint64(stats.TransferredFiles, 1)
+	chk.Uint64(stats.TotalFileSize, 123456)
+	chk.Uint64(stats.TransferredFileSize, 1024)
+	chk.Uint64(stats.LiteralData, 0)
+	chk.Uint64(stats.MatchedData, 0)
+	chk.Uint64(stats.BytesSent, 239)
+	chk.Uint64(stats.BytesReceived, 36)
+	chk.Float64(stats.Speedup, 448.93, 0)
+
+	chk.Uint64(stats.NeededBytes(), 1024)
+	chk.Uint64(stats.NeededINodes(), 4)
+}
+
+func TestRsyncStats_HumanReadable(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	stats, err := rsync.ParseStats(strings.Join([]string{
+		"Number of files: 1,234 (reg: 1,000, dir: 234)",
+		"Number of created files: 1,234 (reg: 1,000, dir: 234)",
+		"Number of regular files transferred: 1,000",
+		"Total file size: 1.50G bytes",
+		"Total transferred file size: 12.34M bytes",
+	}, "\n"))
+
+	chk.NoErr(err)
+	chk.Uint64(stats.Files, 1234)
+	chk.Uint64(stats.CreatedFiles, 1234)
+	chk.Uint64(stats.CreatedRegular, 1000)
+	chk.Uint64(stats.TransferredFiles, 1000)
+	chk.Uint64(stats.TotalFileSize, 1500000000)
+	chk.Uint64(stats.TransferredFileSize, 12340000)
+
+	chk.Uint64(stats.NeededINodes(), 1234)
+}
+
+func TestRsyncStats_InvalidSpeedup(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	_, err := rsync.ParseStats("total size is 1  speedup is abc\n")
+
+	chk.Err(
+		err,
+		""+
+			rsync.ErrStatsValue.Error()+
+			": total size is 1  speedup is abc"+
+			"",
+	)
+}
+
+func TestRsyncStats_Forward(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var forwarded strings.Builder
+
+	scanner := rsync.NewStatsScanner(&forwarded)
+
+	_, _ = scanner.Write([]byte(strings.Join([]string{
+		"sending incremental file list",
+		"",
+		"file1",
+		"",
+		"Number of files: 2 (reg: 1, dir: 1)",
+		"Literal data: 5 bytes",
+		"Matched data: 7 bytes",
+		"",
+		"sent 120 bytes  received 35 bytes  310.00 bytes/sec",
+		"total size is 12  speedup is 0.08",
+		"",
+	}, "\n")))
+
+	stats, err := scanner.Result()
+
+	chk.NoErr(err)
+	chk.Uint64(stats.Files, 2)
+	chk.Uint64(stats.LiteralData, 5)
+	chk.Uint64(stats.MatchedData, 7)
+	chk.Float64(stats.Speedup, 0.08, 0)
+
+	chk.Str(
+		forwarded.String(),
+		"sending incremental file list\n\nfile1\n",
+	)
+}
+
+func TestRsyncStats_Report(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	stats := rsync.Stats{
+		Files:            1234,
+		TransferredFiles: 12,
+		LiteralData:      4096,
+		MatchedData:      512,
+		BytesSent:        5000,
+		Speedup:          1.5,
+		Elapsed:          1500 * time.Millisecond,
+	}
+
+	chk.Str(
+		stats.Report(),
+		""+
+			"       Files:                1,234\n"+
+			" Transferred:                   12\n"+
+			"     Literal:                4,096\n"+
+			"     Matched:                  512\n"+
+			"        Sent:                5,000\n"+
+			"     Speedup:                 1.50\n"+
+			"     Elapsed:                 1.5s",
+	)
+}
@@ -0,0 +1,104 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Warning reasons.
+const (
+	WarnVanished         = "vanished"
+	WarnPermissionDenied = "permission denied"
+)
+
+//nolint:goCheckNoGlobals // Ok.
+var (
+	reVanished         = regexp.MustCompile(`file has vanished: "(.*)"`)
+	rePermissionDenied = regexp.MustCompile(
+		`"(.*)".*: Permission denied \(13\)`,
+	)
+)
+
+// Warning identifies a single item rsync could not transfer.
+type Warning struct {
+	// Reason the item was not transferred.
+	Reason string `json:"reason"`
+	// Path of the item as reported by rsync.
+	Path string `json:"path"`
+}
+
+// String implements the Stringer interface.
+func (w Warning) String() string {
+	return w.Reason + ": " + w.Path
+}
+
+// WarningScanner is an io.Writer that scans rsync's standard error line by
+// line for files that vanished or could not be read.
+type WarningScanner struct {
+	partial  []byte
+	warnings []Warning
+}
+
+// Write implements io.Writer.
+func (s *WarningScanner) Write(p []byte) (int, error) {
+	s.partial = append(s.partial, p...)
+
+	for {
+		idx := bytes.IndexByte(s.partial, '\n')
+		if idx < 0 {
+			break
+		}
+
+		s.scanLine(string(s.partial[:idx]))
+		s.partial = s.partial[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+func (s *WarningScanner) scanLine(line string) {
+	var match []string
+
+	line = strings.TrimRight(line, "\r")
+
+	if match = reVanished.FindStringSubmatch(line); match != nil {
+		s.warnings = append(s.warnings, Warning{WarnVanished, match[1]})
+
+		return
+	}
+
+	if match = rePermissionDenied.FindStringSubmatch(line); match != nil {
+		s.warnings = append(
+			s.warnings, Warning{WarnPermissionDenied, match[1]},
+		)
+	}
+}
+
+// Warnings returns the warnings found.
+func (s *WarningScanner) Warnings() []Warning {
+	if len(s.partial) > 0 {
+		s.scanLine(string(s.partial))
+		s.partial = nil
+	}
+
+	return s.warnings
+}
@@ -0,0 +1,61 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsync_test
+
+import (
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestRsyncWarnings_None(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	scanner := new(rsync.WarningScanner)
+
+	_, err := scanner.Write([]byte("rsync: some other message\n"))
+
+	chk.NoErr(err)
+	chk.Int(len(scanner.Warnings()), 0)
+}
+
+func TestRsyncWarnings_Found(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	scanner := new(rsync.WarningScanner)
+
+	// Split across writes to insure partial lines are accumulated.
+	_, _ = scanner.Write([]byte("file has vanished: \"/src/a"))
+	_, _ = scanner.Write([]byte(".txt\"\nrsync: [sender] send_files failed"))
+	_, _ = scanner.Write([]byte(
+		" to open \"/src/b.txt\": Permission denied (13)\n" +
+			"rsync: [sender] opendir \"/src/c\" failed:" +
+			" Permission denied (13)",
+	))
+
+	warnings := scanner.Warnings()
+
+	chk.Int(len(warnings), 3)
+	chk.Str(warnings[0].String(), "vanished: /src/a.txt")
+	chk.Str(warnings[1].String(), "permission denied: /src/b.txt")
+	chk.Str(warnings[2].String(), "permission denied: /src/c")
+}
@@ -22,7 +22,9 @@ import (
 	"os"
 	"time"
 
+	"github.com/dancsecs/szbck/internal/rsync"
 	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/szbck/internal/wait"
 )
 
 // Config defines required parameter to run a szerszam backup.
@@ -42,4 +44,24 @@ type Config struct {
 	// Retention parameters
 	KeepHourly time.Duration
 	KeepDaily  time.Duration
+	// Space guard floors.  A snapshot is stopped if the target's free bytes
+	// or iNodes drop below these values.  Zero disables the check.
+	MinFreeBytes  uint64
+	MinFreeINodes uint64
+	// Rsync exit codes treated as a successful snapshot with warnings.
+	WarningExitCodes []int
+	// CPU and I/O scheduling priority applied to rsync.
+	Priority rsync.Priority
+	// Daily windows limiting rsync's bandwidth.  Unlimited outside of them.
+	Bandwidth []wait.Window
+	// Rsync is killed if it runs longer than MaxRunTime or writes no output
+	// for StallTimeout.  Zero disables the limit.
+	MaxRunTime   time.Duration
+	StallTimeout time.Duration
+	// Number of directories read concurrently when measuring the disk usage
+	// of the target's snapshots.  Zero uses the default.
+	DuWorkers int
+	// Prometheus node_exporter textfile rewritten after each snapshot, trim
+	// and prune.  Empty disables the metrics.
+	MetricsFile string
 }
@@ -58,15 +58,32 @@ func Load(fPath string) (*Config, error) {
 // argument. An error if the replacement is invalid or if both the replacement
 // and the configured target are not defined.
 func LoadFromArgs(args *szargs.Args) (*Config, error) {
+	cfg, _, err := LoadFromArgsWith(args, 0)
+
+	return cfg, err
+}
+
+// LoadFromArgsWith loads the configuration as LoadFromArgs but permits up to
+// maxExtra arguments to follow the configuration filename.  They are
+// returned in order.
+func LoadFromArgsWith(
+	args *szargs.Args, maxExtra int,
+) (*Config, []string, error) {
 	var (
 		trgOverride string
 		cfgFilename string
+		extra       []string
 		cfg         *Config
 		err         error
 	)
 
 	trgOverride, _ = args.ValueString("-t", "")
 	cfgFilename = args.NextString("backup config filename", "")
+
+	for len(extra) < maxExtra && args.HasNext() {
+		extra = append(extra, args.NextString("argument", ""))
+	}
+
 	args.Done()
 	err = args.Err()
 
@@ -84,8 +101,8 @@ func LoadFromArgs(args *szargs.Args) (*Config, error) {
 	}
 
 	if err == nil {
-		return cfg, nil
+		return cfg, extra, nil
 	}
 
-	return nil, err
+	return nil, nil, err
 }
@@ -171,3 +171,33 @@ func TestConfigBackup_LoadFromArgs_DefaultAndOverride(t *testing.T) {
 	chk.NoErr(err)
 	chk.Str(cfg.Target.GetPath(), trg2)
 }
+
+func TestConfigBackup_LoadFromArgsWith(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	src := chk.CreateTmpSubDir("source")
+	trg := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(src, trg)
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "sample.sbc", []byte(cfgData))
+
+	args := szargs.New("", []string{"prg", cfgFile, "latest~1", "latest"})
+	cfg, extra, err := settings.LoadFromArgsWith(args, 2)
+	chk.NoErr(err)
+	chk.Str(cfg.Target.GetPath(), trg)
+	chk.StrSlice(extra, []string{"latest~1", "latest"})
+
+	args = szargs.New("", []string{"prg", cfgFile})
+	_, extra, err = settings.LoadFromArgsWith(args, 2)
+	chk.NoErr(err)
+	chk.StrSlice(extra, nil)
+
+	args = szargs.New("", []string{"prg", cfgFile, "a", "b", "c"})
+	cfg, extra, err = settings.LoadFromArgsWith(args, 2)
+	chk.Err(err, szargs.ErrUnexpected.Error()+": [c]")
+	chk.Nil(cfg)
+	chk.StrSlice(extra, nil)
+}
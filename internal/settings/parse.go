@@ -42,35 +42,44 @@ func isVerbose(flag string) bool {
 	return flag == "-v" || flag == "--verbose"
 }
 
-// Parse takes a the content of a configuration file, and returns a Config
-// structure if there are no errors.
-func Parse(txt string) (*Config, error) {
+//nolint:goCheckNoGlobals // Ok.
+var reStripComments = regexp.MustCompile(`\s*\#.*$`)
+
+// parseLine validates a single line of a configuration file updating the
+// configuration.  Blank and fully commented lines are ignored.
+func (cfg *Config) parseLine(rawLine string) error {
 	var (
-		cfg   Config
 		line  string
 		key   string
 		value string
 		found bool
-		err   error
 	)
 
-	reStripComments := regexp.MustCompile(`\s*\#.*$`)
+	line = reStripComments.ReplaceAllString(rawLine, "")
+	if line == "" {
+		return nil // skip blank and fully commented lines.
+	}
 
-	for lineNbr, rawLine := range strings.Split(txt, "\n") {
-		line = reStripComments.ReplaceAllString(rawLine, "")
-		if line == "" {
-			continue // skip blank and fully commented lines.
-		}
+	key, value, found = strings.Cut(line, ":")
+	if !found {
+		return ErrInvalidSyntax
+	}
 
-		key, value, found = strings.Cut(line, ":")
-		if !found {
-			err = ErrInvalidSyntax
-		} else {
-			key = strings.TrimSpace(key)
-			value = strings.TrimSpace(value)
-			err = cfg.validateKeyValue(key, value)
-		}
+	return cfg.validateKeyValue(
+		strings.TrimSpace(key), strings.TrimSpace(value),
+	)
+}
 
+// Parse takes a the content of a configuration file, and returns a Config
+// structure if there are no errors.
+func Parse(txt string) (*Config, error) {
+	var (
+		cfg Config
+		err error
+	)
+
+	for lineNbr, rawLine := range strings.Split(txt, "\n") {
+		err = cfg.parseLine(rawLine)
 		if err != nil {
 			err = fmt.Errorf(
 				"%w(%d): %w\n\t%s", ErrConfigLine, lineNbr+1, err, rawLine,
@@ -0,0 +1,76 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dancsecs/szbck/internal/wait"
+)
+
+const bandwidth = "bandwidth"
+
+// Bandwidth errors.
+var (
+	ErrInvalidBandwidth = errors.New("invalid bandwidth")
+	ErrInvalidLimit     = errors.New("invalid limit")
+)
+
+// Matches an rsync --bwlimit rate (IE: 500K, 1.5M or 20000).
+var reBandwidthLimit = regexp.MustCompile(`^\d+(\.\d+)?[KMGkmg]?$`)
+
+func (cfg *Config) validateBandwidth(value string) error {
+	const fieldCount = 2
+
+	var (
+		fields []string
+		window wait.Window
+		err    error
+	)
+
+	if value == "" {
+		err = ErrMissing
+	}
+
+	if err == nil {
+		fields = strings.Fields(value)
+		if len(fields) != fieldCount {
+			err = ErrSyntax
+		}
+	}
+
+	if err == nil && !reBandwidthLimit.MatchString(fields[1]) {
+		err = fmt.Errorf("%w: '%s'", ErrInvalidLimit, fields[1])
+	}
+
+	if err == nil {
+		window, err = wait.NewWindow(fields[0], fields[1])
+	}
+
+	if err == nil {
+		cfg.Bandwidth = append(cfg.Bandwidth, window)
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidBandwidth, err)
+}
@@ -0,0 +1,94 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/wait"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestInternalSettings_ValBandwidth_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateBandwidth(""),
+		""+
+			ErrInvalidBandwidth.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateBandwidth("5M"),
+		""+
+			ErrInvalidBandwidth.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateBandwidth("09:00-18:00 fast"),
+		""+
+			ErrInvalidBandwidth.Error()+
+			": "+
+			ErrInvalidLimit.Error()+
+			": 'fast'",
+	)
+
+	chk.Err(
+		cfg.validateBandwidth("09:00-25:00 5M"),
+		""+
+			ErrInvalidBandwidth.Error()+
+			": "+
+			wait.ErrInvalidClock.Error()+
+			": '25:00'",
+	)
+
+	chk.Nil(cfg.Bandwidth)
+}
+
+func TestInternalSettings_ValBandwidth_Valid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.NoErr(cfg.validateKeyValue(bandwidth, "09:00-18:00 5M"))
+	chk.NoErr(cfg.validateKeyValue(bandwidth, "22:00-06:00   1.5m"))
+
+	chk.Int(len(cfg.Bandwidth), 2)
+	chk.Str(cfg.Bandwidth[0].String(), "09:00-18:00 5M")
+	chk.Str(cfg.Bandwidth[1].String(), "22:00-06:00 1.5m")
+
+	chk.Str(
+		wait.BandwidthLimit(
+			cfg.Bandwidth,
+			time.Date(2026, time.May, 15, 10, 0, 0, 0, time.Local),
+		),
+		"5M",
+	)
+}
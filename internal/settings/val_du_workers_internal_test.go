@@ -0,0 +1,87 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"testing"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestInternalSettings_ValDuWorkers_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateDuWorkers(""),
+		""+
+			ErrInvalidDuWorkers.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateDuWorkers("many"),
+		""+
+			ErrInvalidDuWorkers.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateDuWorkers("0"),
+		""+
+			ErrInvalidDuWorkers.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateDuWorkers("65"),
+		""+
+			ErrInvalidDuWorkers.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+}
+
+func TestInternalSettings_ValDuWorkers_Duplicate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.NoErr(cfg.validateKeyValue(duWorkers, "8"))
+	chk.Int(cfg.DuWorkers, 8)
+
+	chk.Err(
+		cfg.validateDuWorkers("2"),
+		""+
+			ErrInvalidDuWorkers.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+duWorkers+"'",
+	)
+}
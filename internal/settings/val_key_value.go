@@ -40,6 +40,26 @@ func (cfg *Config) validateKeyValue(key, value string) error {
 		return cfg.validateKeepHourly(value)
 	case "keepDaily":
 		return cfg.validateKeepDaily(value)
+	case minFreeBytes:
+		return cfg.validateMinFreeBytes(value)
+	case minFreeINodes:
+		return cfg.validateMinFreeINodes(value)
+	case warningExitCode:
+		return cfg.validateWarningExitCode(value)
+	case cpuNice:
+		return cfg.validateCPUNice(value)
+	case ioPriority:
+		return cfg.validateIOPriority(value)
+	case bandwidth:
+		return cfg.validateBandwidth(value)
+	case maxRunTime:
+		return cfg.validateMaxRunTime(value)
+	case stallTimeout:
+		return cfg.validateStallTimeout(value)
+	case duWorkers:
+		return cfg.validateDuWorkers(value)
+	case metricsFile:
+		return cfg.validateMetricsFile(value)
 	default:
 		return fmt.Errorf("%w: '%s'", ErrUnknownKey, key)
 	}
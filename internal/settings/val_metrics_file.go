@@ -0,0 +1,75 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dancsecs/szbck/internal/directory"
+)
+
+const (
+	metricsFile      = "metricsFile"
+	metricsExtension = ".prom"
+)
+
+// Metrics file errors.
+var (
+	ErrInvalidMetricsFile = errors.New("invalid metrics file")
+	ErrMetricsAbs         = errors.New("metrics file must be absolute path")
+	ErrMetricsExt         = errors.New(
+		"metrics file must end in " + metricsExtension,
+	)
+)
+
+func (cfg *Config) validateMetricsFile(value string) error {
+	var err error
+
+	if cfg.MetricsFile != "" {
+		err = fmt.Errorf("%w: '%s'", ErrDuplicate, metricsFile)
+	}
+
+	if err == nil && value == "" {
+		err = ErrMissing
+	}
+
+	if err == nil && !filepath.IsAbs(value) {
+		err = ErrMetricsAbs
+	}
+
+	// The node_exporter textfile collector only reads *.prom files.
+	if err == nil && !strings.HasSuffix(value, metricsExtension) {
+		err = ErrMetricsExt
+	}
+
+	if err == nil {
+		err = directory.Is(filepath.Dir(value))
+	}
+
+	if err == nil {
+		cfg.MetricsFile = filepath.Clean(value)
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidMetricsFile, err)
+}
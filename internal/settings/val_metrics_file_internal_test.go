@@ -0,0 +1,93 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestInternalSettings_ValMetricsFile_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	dir := chk.CreateTmpDir()
+
+	chk.Err(
+		cfg.validateMetricsFile(""),
+		""+
+			ErrInvalidMetricsFile.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateMetricsFile("szbck.prom"),
+		""+
+			ErrInvalidMetricsFile.Error()+
+			": "+
+			ErrMetricsAbs.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateMetricsFile(filepath.Join(dir, "szbck.txt")),
+		""+
+			ErrInvalidMetricsFile.Error()+
+			": "+
+			ErrMetricsExt.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateMetricsFile(filepath.Join(dir, "missing", "szbck.prom")),
+		""+
+			ErrInvalidMetricsFile.Error()+
+			": "+
+			directory.ErrInvalid.Error()+
+			": '"+filepath.Join(dir, "missing")+"'",
+	)
+}
+
+func TestInternalSettings_ValMetricsFile_Duplicate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	path := filepath.Join(chk.CreateTmpDir(), "szbck.prom")
+
+	chk.NoErr(cfg.validateKeyValue(metricsFile, path))
+	chk.Str(cfg.MetricsFile, path)
+
+	chk.Err(
+		cfg.validateMetricsFile(path),
+		""+
+			ErrInvalidMetricsFile.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+metricsFile+"'",
+	)
+}
@@ -0,0 +1,142 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+)
+
+const (
+	cpuNice    = "cpuNice"
+	ioPriority = "ioPriority"
+)
+
+// Valid I/O priority classes message.
+const (
+	ValidIOClasses = "must be one of 'idle', 'best-effort level' or " +
+		"'realtime level' (level 0-7)"
+)
+
+// Priority errors.
+var (
+	ErrInvalidCPUNice    = errors.New("invalid cpu nice")
+	ErrInvalidIOPriority = errors.New("invalid io priority")
+	ErrInvalidIOClass    = errors.New("invalid io class")
+)
+
+func (cfg *Config) validateCPUNice(value string) error {
+	const maxNice = 19
+
+	var (
+		nice int
+		err  error
+	)
+
+	if cfg.Priority.Nice != 0 {
+		err = fmt.Errorf("%w: '%s'", ErrDuplicate, cpuNice)
+	}
+
+	if err == nil && value == "" {
+		err = ErrMissing
+	}
+
+	if err == nil {
+		nice, err = strconv.Atoi(value)
+		if err != nil {
+			err = ErrSyntax
+		}
+	}
+
+	if err == nil && (nice < 1 || nice > maxNice) {
+		err = ErrRange
+	}
+
+	if err == nil {
+		cfg.Priority.Nice = nice
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidCPUNice, err)
+}
+
+func (cfg *Config) validateIOPriority(value string) error {
+	const maxLevel = 7
+
+	var (
+		class    int
+		classStr string
+		levelStr string
+		level    int
+		err      error
+	)
+
+	if cfg.Priority.IOClass != rsync.IOClassNone {
+		err = fmt.Errorf("%w: '%s'", ErrDuplicate, ioPriority)
+	}
+
+	if err == nil && value == "" {
+		err = ErrMissing
+	}
+
+	if err == nil {
+		classStr, levelStr, _ = strings.Cut(value, " ")
+		levelStr = strings.TrimSpace(levelStr)
+
+		switch classStr {
+		case "idle":
+			class = rsync.IOClassIdle
+		case "best-effort":
+			class = rsync.IOClassBestEffort
+		case "realtime":
+			class = rsync.IOClassRealtime
+		default:
+			err = fmt.Errorf("%w: %s", ErrInvalidIOClass, ValidIOClasses)
+		}
+	}
+
+	if err == nil && class == rsync.IOClassIdle && levelStr != "" {
+		err = ErrSyntax
+	}
+
+	if err == nil && class != rsync.IOClassIdle {
+		level, err = strconv.Atoi(levelStr)
+		if err != nil {
+			err = ErrSyntax
+		}
+	}
+
+	if err == nil && (level < 0 || level > maxLevel) {
+		err = ErrRange
+	}
+
+	if err == nil {
+		cfg.Priority.IOClass = class
+		cfg.Priority.IOLevel = level
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidIOPriority, err)
+}
@@ -0,0 +1,171 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestInternalSettings_ValPriority_InvalidNice(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateCPUNice(""),
+		""+
+			ErrInvalidCPUNice.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateCPUNice("low"),
+		""+
+			ErrInvalidCPUNice.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateCPUNice("0"),
+		""+
+			ErrInvalidCPUNice.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateCPUNice("20"),
+		""+
+			ErrInvalidCPUNice.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+
+	chk.NoErr(cfg.validateKeyValue(cpuNice, "10"))
+	chk.Int(cfg.Priority.Nice, 10)
+
+	chk.Err(
+		cfg.validateCPUNice("10"),
+		""+
+			ErrInvalidCPUNice.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+cpuNice+"'",
+	)
+}
+
+func TestInternalSettings_ValPriority_InvalidIO(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateIOPriority(""),
+		""+
+			ErrInvalidIOPriority.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateIOPriority("lazy"),
+		""+
+			ErrInvalidIOPriority.Error()+
+			": "+
+			ErrInvalidIOClass.Error()+
+			": "+
+			ValidIOClasses+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateIOPriority("idle 3"),
+		""+
+			ErrInvalidIOPriority.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateIOPriority("best-effort"),
+		""+
+			ErrInvalidIOPriority.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateIOPriority("realtime 8"),
+		""+
+			ErrInvalidIOPriority.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+
+	chk.NoErr(cfg.validateKeyValue(ioPriority, "idle"))
+
+	chk.Err(
+		cfg.validateIOPriority("idle"),
+		""+
+			ErrInvalidIOPriority.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+ioPriority+"'",
+	)
+}
+
+func TestInternalSettings_ValPriority_Valid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.False(cfg.Priority.IsSet())
+
+	chk.NoErr(cfg.validateKeyValue(ioPriority, "best-effort 7"))
+	chk.Int(cfg.Priority.IOClass, rsync.IOClassBestEffort)
+	chk.Int(cfg.Priority.IOLevel, 7)
+	chk.True(cfg.Priority.IsSet())
+
+	cfg = Config{}
+
+	chk.NoErr(cfg.validateKeyValue(ioPriority, "realtime 0"))
+	chk.Int(cfg.Priority.IOClass, rsync.IOClassRealtime)
+	chk.Int(cfg.Priority.IOLevel, 0)
+
+	cfg = Config{}
+
+	chk.NoErr(cfg.validateKeyValue(ioPriority, "idle"))
+	chk.Int(cfg.Priority.IOClass, rsync.IOClassIdle)
+}
@@ -33,12 +33,18 @@ const (
 
 // Valid time units message.
 const (
-	UnitHours  = "hours"
-	UnitDays   = "days"
-	ValidUnits = "must be '" +
+	UnitMinutes = "minutes"
+	UnitHours   = "hours"
+	UnitDays    = "days"
+	ValidUnits  = "must be '" +
 		UnitHours + "'" +
 		"' or '" + UnitDays + "'" +
 		""
+	ValidTimeoutUnits = "must be '" +
+		UnitMinutes + "', '" +
+		UnitHours + "'" +
+		" or '" + UnitDays + "'" +
+		""
 )
 
 // Retention minimum durations.
@@ -63,6 +69,7 @@ func validateTimeUnit(
 	name string,
 	currentValue *time.Duration,
 	value string,
+	allowMinutes bool,
 ) error {
 	const (
 		base10      = 10
@@ -104,6 +111,14 @@ func validateTimeUnit(
 
 	if err == nil {
 		switch units {
+		case UnitMinutes:
+			if !allowMinutes {
+				break
+			}
+
+			*currentValue = time.Duration(amount) * time.Minute
+
+			return nil
 		case UnitHours:
 			*currentValue = time.Duration(amount) * time.Hour
 
@@ -112,24 +127,42 @@ func validateTimeUnit(
 			*currentValue = time.Duration(amount) * time.Hour * hoursPerDay
 
 			return nil
-		default:
-			err = fmt.Errorf(
-				"%w: %s",
-				ErrInvalidUnit, ValidUnits,
-			)
+		}
+
+		err = fmt.Errorf("%w: %s", ErrInvalidUnit, ValidUnits)
+		if allowMinutes {
+			err = fmt.Errorf("%w: %s", ErrInvalidUnit, ValidTimeoutUnits)
 		}
 	}
 
 	return err
 }
 
+// checkKeepHourly applies the minimum hourly retention.
+func checkKeepHourly(keep time.Duration) error {
+	if keep < minHourly {
+		return ErrRetentionHourlyMin
+	}
+
+	return nil
+}
+
+// checkKeepDaily applies the minimum daily retention.
+func checkKeepDaily(keep time.Duration) error {
+	if keep < minDaily {
+		return ErrRetentionDailyMin
+	}
+
+	return nil
+}
+
 func (cfg *Config) validateKeepHourly(value string) error {
 	err := validateTimeUnit(
-		keepHourly, &cfg.KeepHourly, value,
+		keepHourly, &cfg.KeepHourly, value, false,
 	)
 
-	if err == nil && cfg.KeepHourly < minHourly {
-		err = ErrRetentionHourlyMin
+	if err == nil {
+		err = checkKeepHourly(cfg.KeepHourly)
 	}
 
 	if err == nil {
@@ -141,11 +174,11 @@ func (cfg *Config) validateKeepHourly(value string) error {
 
 func (cfg *Config) validateKeepDaily(value string) error {
 	err := validateTimeUnit(
-		keepDaily, &cfg.KeepDaily, value,
+		keepDaily, &cfg.KeepDaily, value, false,
 	)
 
-	if err == nil && cfg.KeepDaily < minDaily {
-		err = ErrRetentionDailyMin
+	if err == nil {
+		err = checkKeepDaily(cfg.KeepDaily)
 	}
 
 	if err == nil {
@@ -154,3 +187,33 @@ func (cfg *Config) validateKeepDaily(value string) error {
 
 	return fmt.Errorf("%w: %w", ErrInvalidKeepDaily, err)
 }
+
+// SetRetention overrides the configured retention applying the same rules as
+// the backup config file.  A zero duration keeps the configured value.
+func (cfg *Config) SetRetention(hourly, daily time.Duration) error {
+	var err error
+
+	if hourly != 0 {
+		err = checkKeepHourly(hourly)
+		if err == nil {
+			cfg.KeepHourly = hourly
+		} else {
+			err = fmt.Errorf("%w: %w", ErrInvalidKeepHourly, err)
+		}
+	}
+
+	if err == nil && daily != 0 {
+		err = checkKeepDaily(daily)
+		if err == nil {
+			cfg.KeepDaily = daily
+		} else {
+			err = fmt.Errorf("%w: %w", ErrInvalidKeepDaily, err)
+		}
+	}
+
+	if err == nil && cfg.KeepDaily <= cfg.KeepHourly {
+		err = fmt.Errorf("%w: %w", ErrInvalidKeepDaily, ErrRetentionDailyMin)
+	}
+
+	return err
+}
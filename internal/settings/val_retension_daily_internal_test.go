@@ -20,6 +20,7 @@ package settings
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dancsecs/sztestlog"
 )
@@ -87,6 +88,17 @@ func TestInternalSettings_ValRetentionDaily_InvalidUnits(t *testing.T) {
 			": "+ValidUnits+
 			"",
 	)
+
+	// Minutes are only valid for timeouts.
+	chk.Err(
+		cfg.validateKeepDaily("2880 minutes"),
+		""+
+			ErrInvalidKeepDaily.Error()+
+			": "+
+			ErrInvalidUnit.Error()+
+			": "+ValidUnits+
+			"",
+	)
 }
 
 func TestInternalSettings_ValRetentionDaily_Low(t *testing.T) {
@@ -140,3 +152,33 @@ func TestInternalSettings_ValRetentionDaily_Duplicate(t *testing.T) {
 			"",
 	)
 }
+
+func TestInternalSettings_SetRetention(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfg := Config{KeepHourly: time.Hour * 48, KeepDaily: time.Hour * 240}
+
+	chk.NoErr(cfg.SetRetention(0, 0))
+	chk.Dur(cfg.KeepHourly, time.Hour*48)
+	chk.Dur(cfg.KeepDaily, time.Hour*240)
+
+	chk.NoErr(cfg.SetRetention(time.Hour*24, time.Hour*48))
+	chk.Dur(cfg.KeepHourly, time.Hour*24)
+	chk.Dur(cfg.KeepDaily, time.Hour*48)
+
+	chk.Err(
+		cfg.SetRetention(time.Hour, 0),
+		ErrInvalidKeepHourly.Error()+": "+ErrRetentionHourlyMin.Error(),
+	)
+
+	chk.Err(
+		cfg.SetRetention(0, time.Hour*24),
+		ErrInvalidKeepDaily.Error()+": "+ErrRetentionDailyMin.Error(),
+	)
+
+	chk.Err(
+		cfg.SetRetention(time.Hour*72, 0),
+		ErrInvalidKeepDaily.Error()+": "+ErrRetentionDailyMin.Error(),
+	)
+}
@@ -87,6 +87,17 @@ func TestInternalSettings_ValRetentionHourly_InvalidUnits(t *testing.T) {
 			": "+ValidUnits+
 			"",
 	)
+
+	// Minutes are only valid for timeouts.
+	chk.Err(
+		cfg.validateKeepHourly("2880 minutes"),
+		""+
+			ErrInvalidKeepHourly.Error()+
+			": "+
+			ErrInvalidUnit.Error()+
+			": "+ValidUnits+
+			"",
+	)
 }
 
 func TestInternalSettings_ValRetentionHourly_Low(t *testing.T) {
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/dancsecs/szbck/internal/directory"
 )
@@ -30,6 +31,7 @@ import (
 var (
 	ErrSource    = errors.New("invalid source")
 	ErrSourceAbs = errors.New("source must be absolute path")
+	ErrNotSource = errors.New("path not in source")
 )
 
 func (cfg *Config) validateSource(source string) error {
@@ -58,3 +60,30 @@ func (cfg *Config) validateSource(source string) error {
 
 	return fmt.Errorf("%w: %w", ErrSource, err)
 }
+
+// SnapshotPath returns the path of the item within a snapshot.  The path is
+// either absolute within the source or relative to it.  Snapshots hold the
+// source directory so the result starts with the source's base name.
+func (cfg *Config) SnapshotPath(path string) (string, error) {
+	var (
+		rel string
+		err error
+	)
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.Source, path)
+	}
+
+	rel, err = filepath.Rel(cfg.Source, filepath.Clean(path))
+
+	if err == nil && (rel == ".." ||
+		strings.HasPrefix(rel, ".."+directory.PathSeparator)) {
+		err = fmt.Errorf("'%s'", path)
+	}
+
+	if err == nil {
+		return filepath.Join(filepath.Base(cfg.Source), rel), nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrNotSource, err)
+}
@@ -90,3 +90,28 @@ func TestInternalSettings_ValidateSource_Valid(t *testing.T) {
 	fDir := chk.CreateTmpDir()
 	chk.NoErr(cfg.validateSource(fDir))
 }
+
+func TestInternalSettings_SnapshotPath(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfg := Config{Source: "/home/user"}
+
+	path, err := cfg.SnapshotPath("docs/report.txt")
+	chk.NoErr(err)
+	chk.Str(path, "user/docs/report.txt")
+
+	path, err = cfg.SnapshotPath("/home/user/docs/report.txt")
+	chk.NoErr(err)
+	chk.Str(path, "user/docs/report.txt")
+
+	path, err = cfg.SnapshotPath("/home/user")
+	chk.NoErr(err)
+	chk.Str(path, "user")
+
+	_, err = cfg.SnapshotPath("/home/other/report.txt")
+	chk.Err(err, ErrNotSource.Error()+": '/home/other/report.txt'")
+
+	_, err = cfg.SnapshotPath("../other")
+	chk.Err(err, ErrNotSource.Error()+": '/home/other'")
+}
@@ -0,0 +1,156 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	minFreeBytes  = "minFreeBytes"
+	minFreeINodes = "minFreeINodes"
+)
+
+// Valid byte units message.
+const (
+	ValidByteUnits = "must be one of 'bytes', " +
+		"'KB', 'MB', 'GB', 'TB', 'KiB', 'MiB', 'GiB' or 'TiB'"
+)
+
+// Space guard errors.
+var (
+	ErrInvalidMinFreeBytes  = errors.New("invalid minimum free bytes")
+	ErrInvalidMinFreeINodes = errors.New("invalid minimum free iNodes")
+	ErrInvalidByteUnit      = errors.New("invalid byte unit")
+)
+
+func byteUnit(units string) (uint64, bool) {
+	const (
+		kilo = 1000
+		kibi = 1024
+	)
+
+	switch units {
+	case "", "bytes":
+		return 1, true
+	case "KB":
+		return kilo, true
+	case "MB":
+		return kilo * kilo, true
+	case "GB":
+		return kilo * kilo * kilo, true
+	case "TB":
+		return kilo * kilo * kilo * kilo, true
+	case "KiB":
+		return kibi, true
+	case "MiB":
+		return kibi * kibi, true
+	case "GiB":
+		return kibi * kibi * kibi, true
+	case "TiB":
+		return kibi * kibi * kibi * kibi, true
+	default:
+		return 0, false
+	}
+}
+
+func validateCount(
+	name string,
+	currentValue *uint64,
+	value string,
+	unitAllowed bool,
+) error {
+	const (
+		base10 = 10
+		bits64 = 64
+	)
+
+	var (
+		amountStr  string
+		amount     uint64
+		units      string
+		multiplier uint64
+		found      bool
+		err        error
+	)
+
+	if *currentValue != 0 {
+		err = fmt.Errorf("%w: '%s'", ErrDuplicate, name)
+	}
+
+	if err == nil && value == "" {
+		err = ErrMissing
+	}
+
+	if err == nil {
+		amountStr, units, _ = strings.Cut(value, " ")
+		units = strings.TrimSpace(units)
+
+		amount, err = strconv.ParseUint(amountStr, base10, bits64)
+		if err != nil {
+			err = ErrSyntax
+		}
+	}
+
+	if err == nil && !unitAllowed && units != "" {
+		err = ErrSyntax
+	}
+
+	if err == nil {
+		multiplier, found = byteUnit(units)
+		if !found {
+			err = fmt.Errorf("%w: %s", ErrInvalidByteUnit, ValidByteUnits)
+		}
+	}
+
+	if err == nil && amount > 0 && amount*multiplier/multiplier != amount {
+		err = ErrRange
+	}
+
+	if err == nil {
+		*currentValue = amount * multiplier
+
+		return nil
+	}
+
+	return err
+}
+
+func (cfg *Config) validateMinFreeBytes(value string) error {
+	err := validateCount(minFreeBytes, &cfg.MinFreeBytes, value, true)
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidMinFreeBytes, err)
+}
+
+func (cfg *Config) validateMinFreeINodes(value string) error {
+	err := validateCount(minFreeINodes, &cfg.MinFreeINodes, value, false)
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidMinFreeINodes, err)
+}
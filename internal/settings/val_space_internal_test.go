@@ -0,0 +1,170 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"testing"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestInternalSettings_ValSpace_InvalidBlank(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateMinFreeBytes(""),
+		""+
+			ErrInvalidMinFreeBytes.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateMinFreeINodes(""),
+		""+
+			ErrInvalidMinFreeINodes.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+}
+
+func TestInternalSettings_ValSpace_InvalidSyntax(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateMinFreeBytes("5GB"),
+		""+
+			ErrInvalidMinFreeBytes.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateMinFreeINodes("5 GB"),
+		""+
+			ErrInvalidMinFreeINodes.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+}
+
+func TestInternalSettings_ValSpace_InvalidUnit(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateMinFreeBytes("5 gigs"),
+		""+
+			ErrInvalidMinFreeBytes.Error()+
+			": "+
+			ErrInvalidByteUnit.Error()+
+			": "+ValidByteUnits+
+			"",
+	)
+}
+
+func TestInternalSettings_ValSpace_InvalidRange(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateMinFreeBytes("18446744073709551615 TiB"),
+		""+
+			ErrInvalidMinFreeBytes.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+}
+
+func TestInternalSettings_ValSpace_Duplicate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.NoErr(cfg.validateMinFreeBytes("1 GB"))
+	chk.Err(
+		cfg.validateMinFreeBytes("1 GB"),
+		""+
+			ErrInvalidMinFreeBytes.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+minFreeBytes+"'"+
+			"",
+	)
+
+	chk.NoErr(cfg.validateMinFreeINodes("1000"))
+	chk.Err(
+		cfg.validateMinFreeINodes("1000"),
+		""+
+			ErrInvalidMinFreeINodes.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+minFreeINodes+"'"+
+			"",
+	)
+}
+
+func TestInternalSettings_ValSpace_Valid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	tests := []struct {
+		value string
+		want  uint64
+	}{
+		{"123", 123},
+		{"123 bytes", 123},
+		{"2 KB", 2_000},
+		{"2 MB", 2_000_000},
+		{"2 GB", 2_000_000_000},
+		{"2 TB", 2_000_000_000_000},
+		{"2 KiB", 2 << 10},
+		{"2 MiB", 2 << 20},
+		{"2 GiB", 2 << 30},
+		{"2 TiB", 2 << 40},
+	}
+
+	for _, test := range tests {
+		var cfg Config
+
+		chk.NoErr(cfg.validateMinFreeBytes(test.value))
+		chk.Uint64(cfg.MinFreeBytes, test.want, test.value)
+	}
+
+	var cfg Config
+
+	chk.NoErr(cfg.validateMinFreeINodes("5000"))
+	chk.Uint64(cfg.MinFreeINodes, 5000)
+}
@@ -0,0 +1,78 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	maxRunTime   = "maxRunTime"
+	stallTimeout = "stallTimeout"
+)
+
+// Timeout errors.
+var (
+	ErrInvalidMaxRunTime   = errors.New("invalid maximum run time")
+	ErrInvalidStallTimeout = errors.New("invalid stall timeout")
+)
+
+func (cfg *Config) validateMaxRunTime(value string) error {
+	err := validateTimeUnit(maxRunTime, &cfg.MaxRunTime, value, true)
+
+	if err == nil && cfg.MaxRunTime <= 0 {
+		err = ErrRange
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidMaxRunTime, err)
+}
+
+func (cfg *Config) validateStallTimeout(value string) error {
+	err := validateTimeUnit(
+		stallTimeout, &cfg.StallTimeout, value, true,
+	)
+
+	if err == nil && cfg.StallTimeout <= 0 {
+		err = ErrRange
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidStallTimeout, err)
+}
+
+// RunContext returns a context for an rsync run ending when the parent is
+// done or the configured maximum run time (if any) elapses.
+func (cfg *Config) RunContext(
+	parent context.Context,
+) (context.Context, context.CancelFunc) {
+	if cfg.MaxRunTime > 0 {
+		return context.WithTimeout(parent, cfg.MaxRunTime)
+	}
+
+	return context.WithCancel(parent)
+}
@@ -0,0 +1,127 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestInternalSettings_ValTimeout_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateMaxRunTime(""),
+		""+
+			ErrInvalidMaxRunTime.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateStallTimeout("10"),
+		""+
+			ErrInvalidStallTimeout.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateStallTimeout("10 seconds"),
+		""+
+			ErrInvalidStallTimeout.Error()+
+			": "+
+			ErrInvalidUnit.Error()+
+			": "+
+			ValidTimeoutUnits+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateMaxRunTime("0 hours"),
+		""+
+			ErrInvalidMaxRunTime.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+}
+
+func TestInternalSettings_ValTimeout_Duplicate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.NoErr(cfg.validateKeyValue(maxRunTime, "2 hours"))
+	chk.NoErr(cfg.validateKeyValue(stallTimeout, "15 minutes"))
+
+	chk.Dur(cfg.MaxRunTime, time.Hour*2)
+	chk.Dur(cfg.StallTimeout, time.Minute*15)
+
+	chk.Err(
+		cfg.validateMaxRunTime("3 hours"),
+		""+
+			ErrInvalidMaxRunTime.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+maxRunTime+"'",
+	)
+
+	chk.Err(
+		cfg.validateStallTimeout("5 minutes"),
+		""+
+			ErrInvalidStallTimeout.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			": '"+stallTimeout+"'",
+	)
+}
+
+func TestInternalSettings_ValTimeout_RunContext(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	ctx, cancel := cfg.RunContext(context.Background())
+
+	_, hasDeadline := ctx.Deadline()
+	chk.False(hasDeadline)
+
+	cancel()
+	chk.Err(ctx.Err(), context.Canceled.Error())
+
+	cfg.MaxRunTime = time.Hour
+
+	ctx, cancel = cfg.RunContext(context.Background())
+	defer cancel()
+
+	deadline, hasDeadline := ctx.Deadline()
+	chk.True(hasDeadline)
+	chk.True(time.Until(deadline) > time.Minute*59)
+}
@@ -0,0 +1,82 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dancsecs/szbck/internal/rsync"
+)
+
+const warningExitCode = "warningExitCode"
+
+// WarningExitCode errors.
+var (
+	ErrInvalidWarningExitCode = errors.New("invalid warning exit code")
+)
+
+func (cfg *Config) validateWarningExitCode(value string) error {
+	var (
+		code int
+		err  error
+	)
+
+	if value == "" {
+		err = ErrMissing
+	}
+
+	if err == nil {
+		code, err = strconv.Atoi(value)
+		if err != nil {
+			err = ErrSyntax
+		}
+	}
+
+	if err == nil && !rsync.IsExitCode(code) {
+		err = ErrRange
+	}
+
+	for i, mi := 0, len(cfg.WarningExitCodes); i < mi && err == nil; i++ {
+		if cfg.WarningExitCodes[i] == code {
+			err = ErrDuplicate
+		}
+	}
+
+	if err == nil {
+		cfg.WarningExitCodes = append(cfg.WarningExitCodes, code)
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidWarningExitCode, err)
+}
+
+// IsWarningExitCode returns true if rsync exiting with the code should be
+// treated as a successful snapshot with warnings.
+func (cfg *Config) IsWarningExitCode(code int) bool {
+	for _, warningCode := range cfg.WarningExitCodes {
+		if warningCode == code {
+			return true
+		}
+	}
+
+	return false
+}
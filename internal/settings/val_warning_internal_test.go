@@ -0,0 +1,92 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"testing"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestInternalSettings_ValWarning_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.Err(
+		cfg.validateWarningExitCode(""),
+		""+
+			ErrInvalidWarningExitCode.Error()+
+			": "+
+			ErrMissing.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateWarningExitCode("twenty"),
+		""+
+			ErrInvalidWarningExitCode.Error()+
+			": "+
+			ErrSyntax.Error()+
+			"",
+	)
+
+	chk.Err(
+		cfg.validateWarningExitCode("7"),
+		""+
+			ErrInvalidWarningExitCode.Error()+
+			": "+
+			ErrRange.Error()+
+			"",
+	)
+}
+
+func TestInternalSettings_ValWarning_Duplicate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.NoErr(cfg.validateWarningExitCode("24"))
+
+	chk.Err(
+		cfg.validateWarningExitCode("24"),
+		""+
+			ErrInvalidWarningExitCode.Error()+
+			": "+
+			ErrDuplicate.Error()+
+			"",
+	)
+}
+
+func TestInternalSettings_ValWarning_Valid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	var cfg Config
+
+	chk.NoErr(cfg.validateKeyValue(warningExitCode, "24"))
+	chk.NoErr(cfg.validateKeyValue(warningExitCode, "23"))
+
+	chk.IntSlice(cfg.WarningExitCodes, []int{24, 23})
+	chk.True(cfg.IsWarningExitCode(23))
+	chk.True(cfg.IsWarningExitCode(24))
+	chk.False(cfg.IsWarningExitCode(12))
+}
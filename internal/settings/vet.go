@@ -0,0 +1,62 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings
+
+import (
+	"strings"
+)
+
+// Problem is a single error found in a configuration file.
+type Problem struct {
+	// Line number (starting at one) of the invalid line.  It is zero for
+	// problems with the file as a whole (IE: a missing mandatory key).
+	Line int `json:"line"`
+	// Text of the invalid line.
+	Text string `json:"text,omitempty"`
+	// Error describing the problem.
+	Error string `json:"error"`
+}
+
+// Vet validates the content of a configuration file as Parse does but
+// continues past invalid lines returning every problem found.
+func Vet(txt string) []Problem {
+	var (
+		cfg      Config
+		problems []Problem
+		err      error
+	)
+
+	for lineNbr, rawLine := range strings.Split(txt, "\n") {
+		err = cfg.parseLine(rawLine)
+		if err != nil {
+			problems = append(problems, Problem{
+				Line:  lineNbr + 1,
+				Text:  rawLine,
+				Error: err.Error(),
+			})
+		}
+	}
+
+	err = cfg.validateMandatory()
+	if err != nil {
+		problems = append(problems, Problem{Error: err.Error()})
+	}
+
+	return problems
+}
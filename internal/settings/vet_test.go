@@ -0,0 +1,82 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package settings_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestSettings_Vet_Valid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgData, err := settings.Create(chk.CreateTmpDir(), "")
+	chk.NoErr(err)
+
+	chk.Int(len(settings.Vet(cfgData)), 0)
+}
+
+func TestSettings_Vet_Problems(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgData := "" +
+		"# A sample configuration.\n" +
+		"source: " + chk.CreateTmpDir() + "\n" +
+		"permission: 0o0500\n" +
+		"option: --archive\n" +
+		"unknown: value\n" +
+		"keepHourly: 24 hours\n" +
+		"cpuNice: 99\n" +
+		"no separator\n"
+
+	problems := settings.Vet(cfgData)
+	chk.Int(len(problems), 4)
+
+	chk.Int(problems[0].Line, 5)
+	chk.Str(problems[0].Text, "unknown: value")
+	chk.Str(
+		problems[0].Error,
+		settings.ErrUnknownKey.Error()+": 'unknown'",
+	)
+
+	chk.Int(problems[1].Line, 7)
+	chk.Str(problems[1].Text, "cpuNice: 99")
+	chk.Str(
+		problems[1].Error,
+		settings.ErrInvalidCPUNice.Error()+": "+settings.ErrRange.Error(),
+	)
+
+	chk.Int(problems[2].Line, 8)
+	chk.Str(problems[2].Error, settings.ErrInvalidSyntax.Error())
+
+	chk.Int(problems[3].Line, 0)
+	chk.Str(problems[3].Text, "")
+	chk.True(
+		strings.HasPrefix(
+			problems[3].Error,
+			settings.ErrUndefined.Error()+": "+
+				settings.ErrKeepDailyMissing.Error(),
+		),
+	)
+}
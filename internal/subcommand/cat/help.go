@@ -0,0 +1,47 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cat
+
+// HelpText describes the overall operation of the utility.
+const HelpText = `cat ` +
+	"[-s snapshot] " +
+	"[-t target] " +
+	"config.szb path" + `
+
+Writes the file held by a snapshot to standard output without any other
+output so it may be compared with the current version (IE: diff <(szbck cat
+config.szb etc/foo.conf) /etc/foo.conf).  Symbolic links are not followed.
+
+   [-s snapshot]
+      Specifies the snapshot holding the file.  It defaults to 'latest'.  It
+      may be a snapshot directory name or any selector accepted by the
+      restore subcommand's -s option (IE: -1h, yesterday or latest~3).
+
+   [-t target]
+      Specifies the backup set holding the file.  It is optional if the
+      backup config file specifies a target and mandatory if not specified
+      in the backup config file.
+
+   config.sbc
+      The backup configuration file defining the backup.
+
+   path
+      The file to write.  It is either relative to the configured source or
+      an absolute path within it.
+`
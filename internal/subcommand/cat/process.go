@@ -0,0 +1,122 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cat
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+)
+
+func parseArgs(args *szargs.Args) (*settings.Config, string, string, error) {
+	var (
+		snapshot string
+		path     string
+		cfg      *settings.Config
+		extra    []string
+		err      error
+	)
+
+	snapshot, _ = args.ValueString("-s", "")
+
+	err = args.Err()
+
+	if err == nil {
+		cfg, extra, err = settings.LoadFromArgsWith(args, 1)
+	}
+
+	if err == nil && len(extra) == 0 {
+		err = fmt.Errorf("%w: path", szargs.ErrMissing)
+	}
+
+	if err == nil {
+		path, err = cfg.SnapshotPath(extra[0])
+	}
+
+	if snapshot == "" {
+		snapshot = target.LatestDirectoryLink
+	}
+
+	return cfg, snapshot, path, err //nolint:wrapcheck // Ok.
+}
+
+// copyFile writes the regular file to the writer.  Symbolic links are not
+// followed as they may point outside of the snapshot.
+func copyFile(w io.Writer, path string) error {
+	var (
+		info fs.FileInfo
+		file *os.File
+		err  error
+	)
+
+	info, err = os.Lstat(path)
+
+	if err == nil && !info.Mode().IsRegular() {
+		err = fmt.Errorf("%w: '%s'", ErrNotFile, path)
+	}
+
+	if err == nil {
+		file, err = os.Open(path) //nolint:gosec // Ok.
+	}
+
+	if err == nil {
+		_, err = io.Copy(w, file)
+
+		err = errors.Join(err, file.Close())
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// Process parses the remaining arguments writing the file held by the
+// snapshot to standard output.
+func Process(args *szargs.Args) (string, error) {
+	var (
+		cfg      *settings.Config
+		snapshot string
+		path     string
+		err      error
+	)
+
+	cfg, snapshot, path, err = parseArgs(args)
+
+	if err == nil {
+		snapshot, err = cfg.Target.Select(snapshot, time.Now())
+	}
+
+	if err == nil {
+		err = copyFile(
+			os.Stdout, filepath.Join(cfg.Target.GetPath(), snapshot, path),
+		)
+	}
+
+	if err == nil {
+		return "", nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrCatError, err)
+}
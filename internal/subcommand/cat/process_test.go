@@ -0,0 +1,122 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cat_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/cat"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// setup creates two snapshots holding different versions of a file
+// returning the config file.
+func setup(chk *sztest.Chk) string {
+	chk.T().Helper()
+
+	source := chk.CreateTmpSubDir("source")
+	trgDir := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(source, trgDir)
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	taken := time.Now().Add(-time.Hour * 2)
+
+	for i, data := range []string{"version 1\n", "version 2\n"} {
+		dir := filepath.Join(
+			trg.SnapshotDir(taken.Add(time.Hour*time.Duration(i))),
+			"source",
+			"etc",
+		)
+		chk.NoErr(os.MkdirAll(dir, 0o0700))
+		chk.NoErr(
+			os.WriteFile(
+				filepath.Join(dir, "foo.conf"), []byte(data), 0o0600,
+			),
+		)
+		chk.NoErr(os.Symlink("/etc/passwd", filepath.Join(dir, "link")))
+
+		chk.NoErr(trg.SetLatest(filepath.Dir(filepath.Dir(dir))))
+	}
+
+	return cfgFile
+}
+
+func TestCatProcess_Latest(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	cfgFile := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile, "etc/foo.conf"})
+	outText, err := cat.Process(args)
+	chk.NoErr(err)
+	chk.Str(outText, "")
+
+	args = szargs.New("", []string{
+		"prg", "-s", "latest~1", cfgFile, "etc/foo.conf",
+	})
+	outText, err = cat.Process(args)
+	chk.NoErr(err)
+	chk.Str(outText, "")
+
+	chk.Stdout(
+		"version 2",
+		"version 1",
+	)
+}
+
+func TestCatProcess_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile})
+	_, err := cat.Process(args)
+	chk.Err(
+		err,
+		cat.ErrCatError.Error()+": "+szargs.ErrMissing.Error()+": path",
+	)
+
+	latest, err := filepath.EvalSymlinks(
+		filepath.Join(filepath.Dir(cfgFile), "target", "latest"),
+	)
+	chk.NoErr(err)
+
+	args = szargs.New("", []string{"prg", cfgFile, "etc/link"})
+	_, err = cat.Process(args)
+	chk.Err(
+		err,
+		cat.ErrCatError.Error()+": "+cat.ErrNotFile.Error()+": '"+
+			filepath.Join(latest, "source", "etc", "link")+"'",
+	)
+}
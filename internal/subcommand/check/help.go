@@ -0,0 +1,70 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package check
+
+// HelpText describes the overall operation of the utility.
+const HelpText = `check ` +
+	`[--warn age] ` +
+	`[--crit age] ` +
+	`[--warn-full age] ` +
+	`[-t target] config.szb
+
+Checks the health of the backup set for a monitoring system (Nagios, Icinga
+or any compatible plugin runner) writing a single line stating the overall
+status, any problems found and performance data following a '|'.  The exit
+code is 0 (OK), 1 (WARNING), 2 (CRITICAL) or 3 (UNKNOWN if the backup set
+could not be checked).
+
+The age of the latest snapshot (taken from its name) is compared with the
+thresholds and a backup set without one is critical.  A failed last
+snapshot recorded in the journal is critical and one accepting a warning
+exit code is a warning.  Free bytes or iNodes on the target below the
+minFreeBytes or minFreeINodes floors in the backup config file are
+critical.  Snapshots take no lock file.  Instead a snapshot directory newer
+than latest shows a snapshot is running and is a warning.  One older than
+the critical age was abandoned by a killed snapshot and is critical.  The
+number of these unfinished snapshots and of the snapshots renamed as failed
+is included in the performance data.
+
+   [--warn age]
+      The age of the latest snapshot that is a warning.  It is a number
+      followed by m (minutes), h (hours), d (days) or w (weeks).  Defaults
+      to 2h (twice the hourly snapshot interval) allowing one missed
+      snapshot.
+
+   [--crit age]
+      The age of the latest snapshot that is critical.  It must be greater
+      than the warning age.  Defaults to 4h (four times the hourly snapshot
+      interval) allowing three missed snapshots.
+
+   [--warn-full age]
+      Warns if the target is forecast to be full within the age (IE: 30d).
+      The forecast fits the growth rate to the bytes transferred by the
+      successful snapshots in the journal.  The days until full are always
+      included in the performance data once there are two successful
+      snapshots and the target is growing.
+
+   [-t target]
+      Specifies the backup set to check.  It is optional if the backup
+      config file specifies a target and mandatory if not specified in the
+      backup config file.
+
+   config.sbc
+      The backup configuration file defining the backup.
+`
@@ -0,0 +1,436 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package check
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/forecast"
+	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+)
+
+// Monitoring plugin exit codes.
+const (
+	ExitOK       = 0
+	ExitWarning  = 1
+	ExitCritical = 2
+	ExitUnknown  = 3
+)
+
+const day = time.Hour * 24
+
+// snapshotInterval is the time between snapshots taken by the daemon's
+// hourly schedule.
+const snapshotInterval = time.Hour
+
+// Default age thresholds allowing one (warning) and three (critical) missed
+// snapshots.
+const (
+	defaultWarn = snapshotInterval * 2
+	defaultCrit = snapshotInterval * 4
+)
+
+// statusNames maps each exit code to the status reported.
+//
+//nolint:gochecknoglobals // Ok.
+var statusNames = [...]string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+// options holds the command line options controlling the check.
+type options struct {
+	warn     time.Duration
+	crit     time.Duration
+	warnFull time.Duration
+}
+
+// result accumulates the outcome of each check.
+type result struct {
+	code     int
+	problems []string
+	perfData []string
+}
+
+// raise records a problem escalating the exit code if necessary.
+func (r *result) raise(code int, problem string) {
+	r.code = max(r.code, code)
+	r.problems = append(r.problems, problem)
+}
+
+// perf appends a performance data item.  Empty thresholds and limits are
+// left empty.
+func (r *result) perf(label, value, warn, crit, minimum, maximum string) {
+	r.perfData = append(r.perfData, strings.TrimRight(
+		label+"="+value+";"+warn+";"+crit+";"+minimum+";"+maximum, ";",
+	))
+}
+
+// String implements the Stringer interface returning the plugin output.
+func (r *result) String() string {
+	summary := "no problems"
+	if len(r.problems) > 0 {
+		summary = strings.Join(r.problems, ", ")
+	}
+
+	return "SZBCK " + statusNames[r.code] + " - " + summary +
+		" | " + strings.Join(r.perfData, " ") + "\n"
+}
+
+// parseAge parses an age threshold such as 30m, 25h, 2d or 1w.
+func parseAge(spec string) (time.Duration, error) {
+	age, err := target.ParseDuration(spec)
+	if err == nil {
+		return age, nil
+	}
+
+	return 0, fmt.Errorf("%w: '%s'", ErrInvalidThreshold, spec)
+}
+
+func parseArguments(args *szargs.Args) (*settings.Config, options, error) {
+	var (
+		cfg     *settings.Config
+		opts    options
+		warnStr string
+		critStr string
+		fullStr string
+		err     error
+	)
+
+	opts.warn = defaultWarn
+	opts.crit = defaultCrit
+
+	warnStr, _ = args.ValueString("--warn", "")
+	critStr, _ = args.ValueString("--crit", "")
+	fullStr, _ = args.ValueString("--warn-full", "")
+
+	err = args.Err()
+
+	if err == nil && warnStr != "" {
+		opts.warn, err = parseAge(warnStr)
+	} else {
+		warnStr = opts.warn.String()
+	}
+
+	if err == nil && critStr != "" {
+		opts.crit, err = parseAge(critStr)
+	} else {
+		critStr = opts.crit.String()
+	}
+
+	if err == nil && fullStr != "" {
+		opts.warnFull, err = parseAge(fullStr)
+	}
+
+	if err == nil && opts.warn >= opts.crit {
+		err = fmt.Errorf(
+			"%w: '%s' >= '%s'", ErrThresholdOrder, warnStr, critStr,
+		)
+	}
+
+	if err == nil {
+		cfg, err = settings.LoadFromArgs(args)
+	}
+
+	return cfg, opts, err //nolint:wrapcheck // Ok.
+}
+
+// seconds formats a duration as whole seconds.
+func seconds(duration time.Duration) string {
+	return strconv.FormatInt(int64(duration/time.Second), 10)
+}
+
+// checkLatest compares the age of the latest snapshot with the thresholds
+// returning its name.
+func checkLatest(
+	res *result, cfg *settings.Config, opts options, now time.Time,
+) (string, error) {
+	var (
+		hasLatest bool
+		latestDir string
+		taken     time.Time
+		age       time.Duration
+		err       error
+	)
+
+	hasLatest, err = cfg.Target.HasLatest()
+
+	if err == nil && !hasLatest {
+		res.raise(ExitCritical, "no latest snapshot")
+
+		return "", nil
+	}
+
+	if err == nil {
+		latestDir, err = filepath.EvalSymlinks(cfg.Target.Latest())
+	}
+
+	if err == nil {
+		taken, err = target.SnapshotTime(latestDir)
+	}
+
+	if err != nil {
+		return "", err //nolint:wrapcheck // Ok.
+	}
+
+	age = now.Sub(taken).Round(time.Second)
+
+	switch {
+	case age >= opts.crit:
+		res.raise(ExitCritical, "latest snapshot "+age.String()+" old")
+	case age >= opts.warn:
+		res.raise(ExitWarning, "latest snapshot "+age.String()+" old")
+	}
+
+	res.perf(
+		"age", seconds(age)+"s", seconds(opts.warn), seconds(opts.crit),
+		"0", "",
+	)
+
+	return filepath.Base(latestDir), nil
+}
+
+// checkLastRun reports the outcome of the last snapshot recorded in the
+// journal.
+func checkLastRun(res *result, cfg *settings.Config) error {
+	var (
+		records []journal.Record
+		last    *journal.Record
+		err     error
+	)
+
+	records, err = journal.Load(cfg.Target.Journal())
+
+	for i := len(records) - 1; i >= 0 && last == nil; i-- {
+		if records[i].Operation == journal.OperationSnapshot {
+			last = &records[i]
+		}
+	}
+
+	switch {
+	case last == nil:
+	case last.Status == journal.StatusFailed:
+		res.raise(ExitCritical, "last snapshot failed: "+
+			last.Start.Local().Format(time.DateTime))
+	case last.Status == journal.StatusWarning:
+		res.raise(ExitWarning, "last snapshot had warnings: "+
+			last.Start.Local().Format(time.DateTime))
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// checkSpace compares the target's free bytes and iNodes with the
+// configured floors.
+func checkSpace(res *result, cfg *settings.Config) error {
+	var (
+		fsStat     *fstat.StatFS
+		bytesFloor string
+		nodesFloor string
+		err        error
+	)
+
+	fsStat, err = fstat.New(cfg.Target.GetPath())
+
+	if err == nil {
+		if cfg.MinFreeBytes > 0 {
+			bytesFloor = strconv.FormatUint(cfg.MinFreeBytes, 10)
+
+			if fsStat.FreeBytes() < cfg.MinFreeBytes {
+				res.raise(ExitCritical, "free bytes below floor")
+			}
+		}
+
+		if cfg.MinFreeINodes > 0 {
+			nodesFloor = strconv.FormatUint(cfg.MinFreeINodes, 10)
+
+			if fsStat.FreeINodes() < cfg.MinFreeINodes {
+				res.raise(ExitCritical, "free iNodes below floor")
+			}
+		}
+
+		res.perf(
+			"free_bytes", strconv.FormatUint(fsStat.FreeBytes(), 10)+"B",
+			"", bytesFloor,
+			"0", strconv.FormatUint(fsStat.TotalBytes(), 10),
+		)
+		res.perf(
+			"free_inodes", strconv.FormatUint(fsStat.FreeINodes(), 10),
+			"", nodesFloor,
+			"0", strconv.FormatUint(fsStat.TotalINodes(), 10),
+		)
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// checkGrowth forecasts when the target will be full from the data added by
+// the snapshots recorded in the journal.  Nothing is reported without enough
+// history or if the target is not growing.
+func checkGrowth(res *result, cfg *settings.Config, opts options) error {
+	var (
+		records  []journal.Record
+		fsStat   *fstat.StatFS
+		rate     float64
+		days     int64
+		growing  bool
+		warnDays string
+		err      error
+	)
+
+	records, err = journal.Load(cfg.Target.Journal())
+
+	if err == nil {
+		fsStat, err = fstat.New(cfg.Target.GetPath())
+	}
+
+	if err != nil {
+		return err //nolint:wrapcheck // Ok.
+	}
+
+	rate, err = forecast.Rate(forecast.FromRecords(records))
+	if err == nil {
+		days, growing = forecast.DaysUntilFull(fsStat.FreeBytes(), rate)
+	}
+
+	if opts.warnFull > 0 {
+		warnDays = strconv.FormatInt(int64(opts.warnFull/day), 10)
+	}
+
+	if growing && time.Duration(days)*day < opts.warnFull {
+		res.raise(ExitWarning,
+			"target full in ~"+strconv.FormatInt(days, 10)+" days",
+		)
+	}
+
+	if growing {
+		res.perf(
+			"full_days", strconv.FormatInt(days, 10), warnDays, "", "0", "",
+		)
+	}
+
+	return nil
+}
+
+// checkUnfinished reports snapshots newer than latest and counts the
+// snapshots marked as failed.  Snapshots take no lock file so an unfinished
+// snapshot directory is the lock held by a running snapshot.  One older than
+// the critical age is taken to be abandoned by a killed snapshot.
+func checkUnfinished(
+	res *result,
+	cfg *settings.Config,
+	opts options,
+	latest string,
+	now time.Time,
+) error {
+	var (
+		snapshots []string
+		failed    []string
+		taken     time.Time
+		partial   int
+		err       error
+	)
+
+	snapshots, err = cfg.Target.Snapshots()
+
+	if err == nil {
+		failed, err = filepath.Glob(filepath.Join(
+			cfg.Target.GetPath(),
+			"*"+target.BackupDirectoryExtension+
+				target.FailedDirectoryExtension,
+		))
+	}
+
+	if err == nil {
+		for i := 0; i < len(snapshots) && err == nil; i++ {
+			if snapshots[i] <= latest {
+				continue
+			}
+
+			partial++
+
+			taken, err = target.SnapshotTime(snapshots[i])
+
+			switch {
+			case err != nil:
+			case now.Sub(taken) >= opts.crit:
+				res.raise(
+					ExitCritical, "abandoned snapshot: "+snapshots[i],
+				)
+			default:
+				res.raise(
+					ExitWarning, "unfinished snapshot: "+snapshots[i],
+				)
+			}
+		}
+
+		res.perf("partial", strconv.Itoa(partial), "", "", "0", "")
+		res.perf("failed", strconv.Itoa(len(failed)), "", "", "0", "")
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// Process parses the remaining arguments checking the backup set and
+// returning the plugin output and exit code.  Any error checking the backup
+// set is reported as UNKNOWN.
+func Process(args *szargs.Args) (string, int) {
+	var (
+		cfg    *settings.Config
+		opts   options
+		res    result
+		latest string
+		now    = time.Now()
+		err    error
+	)
+
+	cfg, opts, err = parseArguments(args)
+
+	if err == nil {
+		latest, err = checkLatest(&res, cfg, opts, now)
+	}
+
+	if err == nil {
+		err = checkLastRun(&res, cfg)
+	}
+
+	if err == nil {
+		err = checkSpace(&res, cfg)
+	}
+
+	if err == nil {
+		err = checkUnfinished(&res, cfg, opts, latest, now)
+	}
+
+	if err == nil {
+		err = checkGrowth(&res, cfg, opts)
+	}
+
+	if err == nil {
+		return res.String(), res.code
+	}
+
+	return "SZBCK " + statusNames[ExitUnknown] + " - " + err.Error() + "\n",
+		ExitUnknown
+}
@@ -0,0 +1,296 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package check_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/check"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// setup creates a backup set with snapshots taken the specified ages ago
+// (oldest first) with latest pointing at the last one returning the config
+// file and the target.
+func setup(chk *sztest.Chk, ages ...time.Duration) (string, *target.Path) {
+	chk.T().Helper()
+
+	source := chk.CreateTmpSubDir("source")
+	trgDir := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(source, trgDir)
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	for _, age := range ages {
+		dir := trg.SnapshotDir(time.Now().Add(-age))
+		chk.NoErr(os.MkdirAll(dir, 0o0700))
+		chk.NoErr(trg.SetLatest(dir))
+	}
+
+	return cfgFile, trg
+}
+
+// split separates the plugin output from the performance data dropping the
+// file system values which vary.
+func split(outText string) (string, string) {
+	output, perfData, _ := strings.Cut(outText, " | ")
+	fields := strings.Fields(perfData)
+	kept := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "free_") {
+			kept = append(kept, field)
+		}
+	}
+
+	return output, strings.Join(kept, " ")
+}
+
+func TestCheckProcess_OK(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, _ := setup(chk, time.Hour*3, time.Hour)
+
+	outText, code := check.Process(
+		szargs.New("", []string{"prg", cfgFile}),
+	)
+	chk.Int(code, check.ExitOK)
+	chk.True(strings.HasSuffix(outText, "\n"))
+	chk.True(strings.Contains(outText, " free_bytes="))
+	chk.True(strings.Contains(outText, " free_inodes="))
+
+	output, perfData := split(outText)
+	chk.Str(output, "SZBCK OK - no problems")
+	chk.Str(perfData, "age=3600s;7200;14400;0 partial=0;;;0 failed=0;;;0")
+}
+
+func TestCheckProcess_Warning(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, trg := setup(chk, time.Hour*3)
+
+	running := trg.SnapshotDir(time.Now().Add(-time.Minute))
+	chk.NoErr(os.MkdirAll(running, 0o0700))
+	chk.NoErr(os.MkdirAll(
+		trg.SnapshotDir(time.Now().Add(-time.Hour*2))+
+			target.FailedDirectoryExtension,
+		0o0700,
+	))
+
+	outText, code := check.Process(
+		szargs.New("", []string{
+			"prg", "--warn", "2h", "--crit", "6h", cfgFile,
+		}),
+	)
+	chk.Int(code, check.ExitWarning)
+
+	output, perfData := split(outText)
+	chk.Str(
+		output,
+		"SZBCK WARNING - latest snapshot 3h0m0s old, "+
+			"unfinished snapshot: "+running[len(trg.GetPath())+1:],
+	)
+	chk.Str(perfData, "age=10800s;7200;21600;0 partial=1;;;0 failed=1;;;0")
+}
+
+func TestCheckProcess_Critical(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, trg := setup(chk, time.Hour*7)
+
+	start := time.Now().Add(-time.Hour)
+
+	chk.NoErr(journal.Append(trg.Journal(), journal.Record{
+		Operation: journal.OperationSnapshot,
+		Start:     start,
+		Status:    journal.StatusFailed,
+	}))
+	chk.NoErr(journal.Append(trg.Journal(), journal.Record{
+		Operation: journal.OperationRestore,
+		Status:    journal.StatusSuccess,
+	}))
+
+	outText, code := check.Process(
+		szargs.New("", []string{
+			"prg", "--warn", "2h", "--crit", "6h", cfgFile,
+		}),
+	)
+	chk.Int(code, check.ExitCritical)
+
+	output, perfData := split(outText)
+	chk.Str(
+		output,
+		"SZBCK CRITICAL - latest snapshot 7h0m0s old, "+
+			"last snapshot failed: "+start.Format(time.DateTime),
+	)
+	chk.Str(perfData, "age=25200s;7200;21600;0 partial=0;;;0 failed=0;;;0")
+}
+
+func TestCheckProcess_Abandoned(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, trg := setup(chk, time.Hour*12)
+
+	abandoned := trg.SnapshotDir(time.Now().Add(-time.Hour * 11))
+	chk.NoErr(os.MkdirAll(abandoned, 0o0700))
+
+	outText, code := check.Process(
+		szargs.New("", []string{
+			"prg", "--warn", "2h", "--crit", "6h", cfgFile,
+		}),
+	)
+	chk.Int(code, check.ExitCritical)
+
+	output, perfData := split(outText)
+	chk.Str(
+		output,
+		"SZBCK CRITICAL - latest snapshot 12h0m0s old, "+
+			"abandoned snapshot: "+abandoned[len(trg.GetPath())+1:],
+	)
+	chk.Str(perfData, "age=43200s;7200;21600;0 partial=1;;;0 failed=0;;;0")
+}
+
+func TestCheckProcess_NoLatest(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, _ := setup(chk)
+
+	outText, code := check.Process(
+		szargs.New("", []string{"prg", cfgFile}),
+	)
+	chk.Int(code, check.ExitCritical)
+
+	output, perfData := split(outText)
+	chk.Str(output, "SZBCK CRITICAL - no latest snapshot")
+	chk.Str(perfData, "partial=0;;;0 failed=0;;;0")
+}
+
+func TestCheckProcess_Unknown(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, _ := setup(chk, time.Hour)
+
+	outText, code := check.Process(
+		szargs.New("", []string{"prg", "--warn", "2x", cfgFile}),
+	)
+	chk.Int(code, check.ExitUnknown)
+	chk.Str(
+		outText,
+		"SZBCK UNKNOWN - "+check.ErrInvalidThreshold.Error()+": '2x'\n",
+	)
+
+	outText, code = check.Process(
+		szargs.New("", []string{
+			"prg", "--warn", "1d", "--crit", "24h", cfgFile,
+		}),
+	)
+	chk.Int(code, check.ExitUnknown)
+	chk.Str(
+		outText,
+		"SZBCK UNKNOWN - "+check.ErrThresholdOrder.Error()+
+			": '1d' >= '24h'\n",
+	)
+
+	outText, code = check.Process(
+		szargs.New("", []string{"prg", "--warn", "5h", cfgFile}),
+	)
+	chk.Int(code, check.ExitUnknown)
+	chk.Str(
+		outText,
+		"SZBCK UNKNOWN - "+check.ErrThresholdOrder.Error()+
+			": '5h' >= '4h0m0s'\n",
+	)
+
+	outText, code = check.Process(szargs.New("", []string{"prg"}))
+	chk.Int(code, check.ExitUnknown)
+	chk.Str(
+		outText,
+		"SZBCK UNKNOWN - "+szargs.ErrMissing.Error()+
+			": backup config filename\n",
+	)
+}
+
+func TestCheckProcess_WarnFull(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, trg := setup(chk, time.Hour)
+
+	start := time.Now().Add(-time.Hour * 48)
+
+	// Growing by a petabyte a day fills any test file system today.
+	for i := range 3 {
+		chk.NoErr(journal.Append(trg.Journal(), journal.Record{
+			Operation: journal.OperationSnapshot,
+			Start:     start.Add(time.Hour * 24 * time.Duration(i)),
+			End:       start.Add(time.Hour * 24 * time.Duration(i)),
+			Status:    journal.StatusSuccess,
+			Stats:     &rsync.Stats{TransferredFileSize: 1 << 50},
+		}))
+	}
+
+	outText, code := check.Process(
+		szargs.New("", []string{"prg", "--warn-full", "30d", cfgFile}),
+	)
+	chk.Int(code, check.ExitWarning)
+
+	output, perfData := split(outText)
+	chk.Str(output, "SZBCK WARNING - target full in ~0 days")
+	chk.Str(
+		perfData,
+		"age=3600s;7200;14400;0 partial=0;;;0 failed=0;;;0 "+
+			"full_days=0;30;;0",
+	)
+
+	// Without a threshold the forecast is only reported.
+	outText, code = check.Process(szargs.New("", []string{"prg", cfgFile}))
+	chk.Int(code, check.ExitOK)
+
+	_, perfData = split(outText)
+	chk.True(strings.HasSuffix(perfData, " full_days=0;;;0"))
+
+	outText, code = check.Process(
+		szargs.New("", []string{"prg", "--warn-full", "soon", cfgFile}),
+	)
+	chk.Int(code, check.ExitUnknown)
+	chk.Str(
+		outText,
+		"SZBCK UNKNOWN - "+check.ErrInvalidThreshold.Error()+": 'soon'\n",
+	)
+}
@@ -0,0 +1,264 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/directory"
+)
+
+// Kinds of change.
+const (
+	Added    = "added"
+	Removed  = "removed"
+	Modified = "modified"
+)
+
+// Change is a single path differing between the snapshots.
+type Change struct {
+	// Kind is one of added, removed or modified.
+	Kind string `json:"kind"`
+	// Path relative to the snapshot.  Directories end with a separator.
+	Path string `json:"path"`
+	// OldSize is the size of the file in the first snapshot.
+	OldSize int64 `json:"oldSize"`
+	// NewSize is the size of the file in the second snapshot.
+	NewSize int64 `json:"newSize"`
+}
+
+// entry describes a single item in a snapshot.
+type entry struct {
+	mode    fs.FileMode
+	size    int64
+	modTime time.Time
+	dev     uint64
+	ino     uint64
+	link    string
+}
+
+// newEntry returns the description of the item.
+func newEntry(path string, info fs.FileInfo) (entry, error) {
+	var err error
+
+	item := entry{
+		mode:    info.Mode(),
+		modTime: info.ModTime(),
+	}
+
+	if info.Mode().IsRegular() {
+		item.size = info.Size()
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		item.dev, item.ino = stat.Dev, stat.Ino
+	}
+
+	if info.Mode()&fs.ModeSymlink != 0 {
+		item.link, err = os.Readlink(path)
+	}
+
+	return item, err //nolint:wrapcheck // Ok.
+}
+
+// sameAs returns true if the entries describe the same content.  Entries
+// hard linked together (the same inode) are the same without further
+// checks as rsync only links unchanged files.
+func (e entry) sameAs(other entry) bool {
+	if e.ino != 0 && e.dev == other.dev && e.ino == other.ino {
+		return true
+	}
+
+	if e.mode.IsDir() && other.mode.IsDir() {
+		return e.mode == other.mode
+	}
+
+	return e.mode == other.mode &&
+		e.size == other.size &&
+		e.modTime.Equal(other.modTime) &&
+		e.link == other.link
+}
+
+// inFilter returns true if the path is one of or below one of the filters.
+// An empty filter matches everything.
+func inFilter(path string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	for _, filter := range filters {
+		if path == filter ||
+			strings.HasPrefix(path, filter+directory.PathSeparator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cleanFilters returns the filters relative to the snapshot root.
+func cleanFilters(filters []string) []string {
+	cleaned := make([]string, 0, len(filters))
+
+	for _, filter := range filters {
+		filter = strings.Trim(filepath.Clean(filter), directory.PathSeparator)
+		if filter == "." || filter == "" {
+			return nil
+		}
+
+		cleaned = append(cleaned, filter)
+	}
+
+	return cleaned
+}
+
+// walk calls visit for each item in the snapshot matching the filters.
+func walk(
+	root string,
+	filters []string,
+	visit func(path string, item entry),
+) error {
+	return filepath.WalkDir( //nolint:wrapcheck // Ok.
+		root,
+		func(path string, dirEntry fs.DirEntry, err error) error {
+			var (
+				rel  string
+				info fs.FileInfo
+				item entry
+			)
+
+			if err == nil {
+				rel, err = filepath.Rel(root, path)
+			}
+
+			if err == nil && rel == "." {
+				return nil
+			}
+
+			if err == nil && !inFilter(rel, filters) {
+				// Keep descending into the parents of filtered paths.
+				if dirEntry.IsDir() && underFilter(rel, filters) {
+					return nil
+				}
+
+				if dirEntry.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if err == nil {
+				info, err = dirEntry.Info()
+			}
+
+			if err == nil {
+				item, err = newEntry(path, info)
+			}
+
+			if err == nil {
+				if dirEntry.IsDir() {
+					rel += directory.PathSeparator
+				}
+
+				visit(rel, item)
+			}
+
+			return err
+		},
+	)
+}
+
+// underFilter returns true if the directory is a parent of a filter.
+func underFilter(dir string, filters []string) bool {
+	for _, filter := range filters {
+		if strings.HasPrefix(filter, dir+directory.PathSeparator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compare returns the changes between the from and to snapshot directories
+// sorted by path along with the number of unchanged items.  Only paths
+// matching the filters (if any) are compared.
+func compare(
+	fromDir, toDir string, filters []string,
+) ([]Change, int, error) {
+	var (
+		fromItems = make(map[string]entry)
+		changes   []Change
+		unchanged int
+		err       error
+	)
+
+	filters = cleanFilters(filters)
+
+	err = walk(fromDir, filters, func(path string, item entry) {
+		fromItems[path] = item
+	})
+
+	if err == nil {
+		err = walk(toDir, filters, func(path string, item entry) {
+			from, found := fromItems[path]
+
+			switch {
+			case !found:
+				changes = append(changes, Change{
+					Kind: Added, Path: path, NewSize: item.size,
+				})
+			case from.sameAs(item):
+				unchanged++
+			default:
+				changes = append(changes, Change{
+					Kind:    Modified,
+					Path:    path,
+					OldSize: from.size,
+					NewSize: item.size,
+				})
+			}
+
+			delete(fromItems, path)
+		})
+	}
+
+	if err == nil {
+		for path, item := range fromItems {
+			changes = append(changes, Change{
+				Kind: Removed, Path: path, OldSize: item.size,
+			})
+		}
+
+		slices.SortFunc(changes, func(a, b Change) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+
+		return changes, unchanged, nil
+	}
+
+	return nil, 0, fmt.Errorf("%w: %w", ErrCompare, err)
+}
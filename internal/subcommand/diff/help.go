@@ -0,0 +1,58 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package diff
+
+// HelpText describes the overall operation of the utility.
+const HelpText = `{d | diff} ` +
+	"[-p path]... " +
+	"[--summary] " +
+	"[-t target] " +
+	"config.szb [snapA [snapB]]" + `
+
+Lists the paths added (+), removed (-) and modified (M) between two
+snapshots followed by a summary of their number and sizes.  By default the
+snapshot before latest is compared with latest.  Files hard linked between
+the snapshots (the same inode) are unchanged without further checks as
+unchanged files are linked to the previous snapshot.  Other files are
+modified if their type, permissions, size, modification time or link target
+differ.
+
+   [-p path]
+      Limits the comparison to the path (relative to the snapshot IE:
+      source/docs) and everything below it.  It may be repeated.
+
+   [--summary]
+      Only displays the summary.
+
+   [-t target]
+      Specifies the backup set to compare.  It is optional if the backup
+      config file specifies a target and mandatory if not specified in the
+      backup config file.
+
+   config.sbc
+      The backup configuration file defining the backup.
+
+   [snapA]
+      The snapshot to compare from.  It defaults to 'latest~1'.  It may be a
+      snapshot directory name or any selector accepted by the restore
+      subcommand's -s option (IE: -1h, yesterday or latest~3).
+
+   [snapB]
+      The snapshot to compare to.  It defaults to 'latest'.
+`
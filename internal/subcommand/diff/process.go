@@ -0,0 +1,222 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+)
+
+// Default snapshots compared.
+const (
+	defaultFrom = target.LatestDirectoryLink + "~1"
+	defaultTo   = target.LatestDirectoryLink
+	maxSnaps    = 2
+)
+
+// options holds the command line options controlling a diff.
+type options struct {
+	from        string
+	to          string
+	filters     []string
+	summaryOnly bool
+}
+
+// Summary totals the changes between the snapshots.
+type Summary struct {
+	Added        int   `json:"added"`
+	AddedBytes   int64 `json:"addedBytes"`
+	Removed      int   `json:"removed"`
+	RemovedBytes int64 `json:"removedBytes"`
+	Modified     int   `json:"modified"`
+	OldBytes     int64 `json:"oldBytes"`
+	NewBytes     int64 `json:"newBytes"`
+	Unchanged    int   `json:"unchanged"`
+}
+
+// Report describes the differences between two snapshots.
+type Report struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Changes []Change `json:"changes,omitempty"`
+	Summary Summary  `json:"summary"`
+}
+
+func parseArgs(args *szargs.Args) (*settings.Config, options, error) {
+	var (
+		opts  options
+		cfg   *settings.Config
+		snaps []string
+		err   error
+	)
+
+	opts.filters = args.ValuesString("-p", "")
+	opts.summaryOnly = args.Is("--summary", "")
+
+	err = args.Err()
+
+	if err == nil {
+		cfg, snaps, err = settings.LoadFromArgsWith(args, maxSnaps)
+	}
+
+	opts.from, opts.to = defaultFrom, defaultTo
+
+	if len(snaps) > 0 {
+		opts.from = snaps[0]
+	}
+
+	if len(snaps) > 1 {
+		opts.to = snaps[1]
+	}
+
+	return cfg, opts, err //nolint:wrapcheck // Ok.
+}
+
+// summarize totals the changes.
+func summarize(changes []Change, unchanged int) Summary {
+	summary := Summary{Unchanged: unchanged}
+
+	for _, change := range changes {
+		switch change.Kind {
+		case Added:
+			summary.Added++
+			summary.AddedBytes += change.NewSize
+		case Removed:
+			summary.Removed++
+			summary.RemovedBytes += change.OldSize
+		case Modified:
+			summary.Modified++
+			summary.OldBytes += change.OldSize
+			summary.NewBytes += change.NewSize
+		}
+	}
+
+	return summary
+}
+
+// String implements the Stringer interface.
+func (c Change) String() string {
+	isDir := strings.HasSuffix(c.Path, directory.PathSeparator)
+
+	switch {
+	case c.Kind == Added && !isDir:
+		return "+ " + c.Path + " (" + out.Int(c.NewSize) + " bytes)"
+	case c.Kind == Added:
+		return "+ " + c.Path
+	case c.Kind == Removed && !isDir:
+		return "- " + c.Path + " (" + out.Int(c.OldSize) + " bytes)"
+	case c.Kind == Removed:
+		return "- " + c.Path
+	case !isDir:
+		return "M " + c.Path + " (" +
+			out.Int(c.OldSize) + " -> " + out.Int(c.NewSize) + " bytes)"
+	default:
+		return "M " + c.Path
+	}
+}
+
+// String implements the Stringer interface.
+func (r Report) String() string {
+	var report strings.Builder
+
+	report.WriteString("From: " + r.From + "\n  To: " + r.To + "\n\n")
+
+	for _, change := range r.Changes {
+		report.WriteString(change.String() + "\n")
+	}
+
+	if len(r.Changes) > 0 {
+		report.WriteString("\n")
+	}
+
+	report.WriteString(fmt.Sprintf(""+
+		"    Added: %s (%s bytes)\n"+
+		"  Removed: %s (%s bytes)\n"+
+		" Modified: %s (%s -> %s bytes)\n"+
+		"Unchanged: %s\n",
+		out.Int(int64(r.Summary.Added)), out.Int(r.Summary.AddedBytes),
+		out.Int(int64(r.Summary.Removed)), out.Int(r.Summary.RemovedBytes),
+		out.Int(int64(r.Summary.Modified)),
+		out.Int(r.Summary.OldBytes), out.Int(r.Summary.NewBytes),
+		out.Int(int64(r.Summary.Unchanged)),
+	))
+
+	return report.String()
+}
+
+// Process parses the remaining arguments reporting the differences between
+// two snapshots.
+func Process(args *szargs.Args) (string, error) {
+	var (
+		cfg       *settings.Config
+		opts      options
+		report    Report
+		changes   []Change
+		unchanged int
+		outText   string
+		now       = time.Now()
+		err       error
+	)
+
+	cfg, opts, err = parseArgs(args)
+
+	if err == nil {
+		report.From, err = cfg.Target.Select(opts.from, now)
+	}
+
+	if err == nil {
+		report.To, err = cfg.Target.Select(opts.to, now)
+	}
+
+	if err == nil {
+		changes, unchanged, err = compare(
+			filepath.Join(cfg.Target.GetPath(), report.From),
+			filepath.Join(cfg.Target.GetPath(), report.To),
+			opts.filters,
+		)
+	}
+
+	if err == nil {
+		report.Summary = summarize(changes, unchanged)
+
+		if !opts.summaryOnly {
+			report.Changes = changes
+		}
+
+		if !out.Structured() {
+			return "diff successful\n\n" + report.String(), nil
+		}
+
+		outText, err = out.Result(report)
+	}
+
+	if err == nil {
+		return outText, nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrDiffError, err)
+}
@@ -0,0 +1,248 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package diff_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/diff"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// setup creates three snapshots returning the config file and the names of
+// the snapshots oldest first.
+func setup(chk *sztest.Chk) (string, []string) {
+	chk.T().Helper()
+
+	source := chk.CreateTmpSubDir("source")
+	trgDir := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(source, trgDir)
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	taken := time.Now().Add(-time.Hour * 3)
+	names := make([]string, 0, 3)
+
+	for i := range 3 {
+		dir := trg.SnapshotDir(taken.Add(time.Hour * time.Duration(i)))
+		names = append(names, filepath.Base(dir))
+		chk.NoErr(os.MkdirAll(filepath.Join(dir, "source", "docs"), 0o0700))
+	}
+
+	write := func(name, path, data string) {
+		chk.NoErr(
+			os.WriteFile(
+				filepath.Join(trgDir, name, path), []byte(data), 0o0600,
+			),
+		)
+	}
+
+	link := func(from, to, path string) {
+		chk.NoErr(
+			os.Link(
+				filepath.Join(trgDir, from, path),
+				filepath.Join(trgDir, to, path),
+			),
+		)
+	}
+
+	write(names[0], "source/same.txt", "same")
+	write(names[0], "source/docs/old.txt", "old")
+	write(names[0], "source/docs/edit.txt", "edit")
+	write(names[0], "source/gone.txt", "gone!")
+
+	link(names[0], names[1], "source/same.txt")
+	link(names[0], names[1], "source/docs/old.txt")
+	write(names[1], "source/docs/edit.txt", "edited")
+	write(names[1], "source/new.txt", "new")
+
+	link(names[1], names[2], "source/same.txt")
+	link(names[1], names[2], "source/docs/old.txt")
+	link(names[1], names[2], "source/docs/edit.txt")
+	link(names[1], names[2], "source/new.txt")
+
+	chk.NoErr(trg.SetLatest(filepath.Join(trgDir, names[2])))
+
+	return cfgFile, names
+}
+
+func TestDiffProcess_Default(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile})
+	outText, err := diff.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"diff successful",
+			"",
+			"From: " + names[1],
+			"  To: " + names[2],
+			"",
+			"    Added: 0 (0 bytes)",
+			"  Removed: 0 (0 bytes)",
+			" Modified: 0 (0 -> 0 bytes)",
+			"Unchanged: 6",
+			"",
+		},
+	)
+}
+
+func TestDiffProcess_Changes(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile, names[0], "latest"})
+	outText, err := diff.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"diff successful",
+			"",
+			"From: " + names[0],
+			"  To: " + names[2],
+			"",
+			"M source/docs/edit.txt (4 -> 6 bytes)",
+			"- source/gone.txt (5 bytes)",
+			"+ source/new.txt (3 bytes)",
+			"",
+			"    Added: 1 (3 bytes)",
+			"  Removed: 1 (5 bytes)",
+			" Modified: 1 (4 -> 6 bytes)",
+			"Unchanged: 4",
+			"",
+		},
+	)
+}
+
+func TestDiffProcess_FilterSummary(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{
+		"prg", "-p", "source/docs", "--summary", cfgFile, "latest~2",
+	})
+	outText, err := diff.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"diff successful",
+			"",
+			"From: " + names[0],
+			"  To: " + names[2],
+			"",
+			"    Added: 0 (0 bytes)",
+			"  Removed: 0 (0 bytes)",
+			" Modified: 1 (4 -> 6 bytes)",
+			"Unchanged: 2",
+			"",
+		},
+	)
+}
+
+func TestDiffProcess_JSON(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile, names := setup(chk)
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	args := szargs.New("", []string{
+		"prg", "-p", "source/new.txt", cfgFile, "latest~2",
+	})
+	outText, err := diff.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			`{`,
+			`  "from": "` + names[0] + `",`,
+			`  "to": "` + names[2] + `",`,
+			`  "changes": [`,
+			`    {`,
+			`      "kind": "added",`,
+			`      "path": "source/new.txt",`,
+			`      "oldSize": 0,`,
+			`      "newSize": 3`,
+			`    }`,
+			`  ],`,
+			`  "summary": {`,
+			`    "added": 1,`,
+			`    "addedBytes": 3,`,
+			`    "removed": 0,`,
+			`    "removedBytes": 0,`,
+			`    "modified": 0,`,
+			`    "oldBytes": 0,`,
+			`    "newBytes": 0,`,
+			`    "unchanged": 0`,
+			`  }`,
+			`}`,
+			``,
+		},
+	)
+}
+
+func TestDiffProcess_InvalidSnapshot(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, _ := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile, "latest~5"})
+	outText, err := diff.Process(args)
+
+	chk.Str(outText, "")
+	chk.Err(
+		err,
+		diff.ErrDiffError.Error()+": "+
+			target.ErrSelect.Error()+": "+
+			target.ErrNoSnapshot.Error()+": 'latest~5': only 3 snapshots",
+	)
+}
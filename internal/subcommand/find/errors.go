@@ -0,0 +1,29 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package find
+
+import "errors"
+
+// Find errors.
+var (
+	ErrFindError      = errors.New("find error")
+	ErrInvalidPattern = errors.New("invalid pattern")
+	ErrSearch         = errors.New("search failed")
+	ErrNoMatches      = errors.New("no matches")
+)
@@ -0,0 +1,55 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package find
+
+// HelpText describes the overall operation of the utility.
+const HelpText = `{f | find} ` +
+	"[--regex] " +
+	"[--deleted] " +
+	"[-t target] " +
+	"config.szb pattern" + `
+
+Searches every snapshot for files whose path relative to the source matches
+the pattern.  A copy of a file hard linked between snapshots (the same
+inode) is reported once with the first and last snapshot holding it and the
+number of snapshots it is in.  The matches are listed by path oldest copy
+first.
+
+   [--regex]
+      Treats the pattern as a regular expression matched against the whole
+      path relative to the source (IE: '^docs/.*\.pdf$').
+
+   [--deleted]
+      Only reports files missing from the latest snapshot that exist in an
+      older snapshot.
+
+   [-t target]
+      Specifies the backup set to search.  It is optional if the backup
+      config file specifies a target and mandatory if not specified in the
+      backup config file.
+
+   config.sbc
+      The backup configuration file defining the backup.
+
+   pattern
+      The glob pattern (IE: '*.txt' or 'docs/report-*.pdf') to match.  A
+      pattern without a path separator matches the name of the file
+      anywhere in the source otherwise the whole path relative to the
+      source.
+`
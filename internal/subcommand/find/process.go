@@ -0,0 +1,350 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package find
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+)
+
+const rowFormat = "%-24s  %-24s  %9s  %13s  %s"
+
+// matcher reports if a path relative to the source matches the pattern.
+type matcher func(rel string) bool
+
+// Match is a single copy of a file (an inode) found in one or more
+// snapshots.
+type Match struct {
+	// Path relative to the source.
+	Path string `json:"path"`
+	// First snapshot holding the copy.
+	First string `json:"first"`
+	// Last snapshot holding the copy.
+	Last string `json:"last"`
+	// Snapshots is the number of snapshots holding the copy.
+	Snapshots int `json:"snapshots"`
+	// Size of the copy.
+	Size int64 `json:"size"`
+}
+
+// Result is the structured outcome of a search.
+type Result struct {
+	Pattern string  `json:"pattern"`
+	Deleted bool    `json:"deleted"`
+	Matches []Match `json:"matches"`
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{"first", "last", "snapshots", "size", "path"}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Matches))
+
+	for _, match := range r.Matches {
+		rows = append(rows, []string{
+			match.First,
+			match.Last,
+			strconv.Itoa(match.Snapshots),
+			strconv.FormatInt(match.Size, 10),
+			match.Path,
+		})
+	}
+
+	return rows
+}
+
+// key identifies a single copy of a file.
+type key struct {
+	path string
+	dev  uint64
+	ino  uint64
+}
+
+// options holds the command line options controlling a search.
+type options struct {
+	regex   bool
+	deleted bool
+	pattern string
+}
+
+func parseArgs(args *szargs.Args) (*settings.Config, options, error) {
+	var (
+		opts  options
+		cfg   *settings.Config
+		extra []string
+		err   error
+	)
+
+	opts.regex = args.Is("--regex", "")
+	opts.deleted = args.Is("--deleted", "")
+
+	err = args.Err()
+
+	if err == nil {
+		cfg, extra, err = settings.LoadFromArgsWith(args, 1)
+	}
+
+	if err == nil && len(extra) == 0 {
+		err = fmt.Errorf("%w: pattern", szargs.ErrMissing)
+	}
+
+	if err == nil {
+		opts.pattern = extra[0]
+	}
+
+	return cfg, opts, err //nolint:wrapcheck // Ok.
+}
+
+// newMatcher returns the matcher for the pattern.  A glob without a path
+// separator matches the name of the file otherwise the whole path relative
+// to the source.
+func newMatcher(pattern string, isRegex bool) (matcher, error) {
+	var (
+		re  *regexp.Regexp
+		err error
+	)
+
+	if isRegex {
+		re, err = regexp.Compile(pattern)
+		if err == nil {
+			return re.MatchString, nil
+		}
+	} else {
+		_, err = path.Match(pattern, "")
+		if err == nil && !strings.Contains(pattern, directory.PathSeparator) {
+			return func(rel string) bool {
+				matched, _ := path.Match(pattern, path.Base(rel))
+
+				return matched
+			}, nil
+		}
+
+		if err == nil {
+			return func(rel string) bool {
+				matched, _ := path.Match(pattern, rel)
+
+				return matched
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s': %w", ErrInvalidPattern, pattern, err)
+}
+
+// collector gathers the matches.  Copies of a file hard linked between
+// snapshots (the same inode) are collected into a single match.
+type collector struct {
+	matches []Match
+	found   map[key]int
+	present map[string]bool
+}
+
+// add records the file found in the snapshot.
+func (c *collector) add(snapshot, rel string, info fs.FileInfo) {
+	id := key{path: rel}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		id.dev, id.ino = stat.Dev, stat.Ino
+	}
+
+	c.present[rel] = true
+
+	if idx, ok := c.found[id]; ok && id.ino != 0 {
+		c.matches[idx].Last = snapshot
+		c.matches[idx].Snapshots++
+
+		return
+	}
+
+	c.found[id] = len(c.matches)
+	c.matches = append(c.matches, Match{
+		Path:      rel,
+		First:     snapshot,
+		Last:      snapshot,
+		Snapshots: 1,
+		Size:      info.Size(),
+	})
+}
+
+// walk collects the files in the snapshot's source matching.  A snapshot
+// without the source is skipped.
+func walk(
+	cfg *settings.Config, snapshot string, match matcher, found *collector,
+) error {
+	root := filepath.Join(
+		cfg.Target.GetPath(), snapshot, filepath.Base(cfg.Source),
+	)
+
+	return filepath.WalkDir( //nolint:wrapcheck // Ok.
+		root,
+		func(path string, entry fs.DirEntry, err error) error {
+			var (
+				rel  string
+				info fs.FileInfo
+			)
+
+			// The source may not exist in a snapshot.
+			if path == root && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+
+			if err == nil && !entry.IsDir() {
+				rel, err = filepath.Rel(root, path)
+			}
+
+			if err == nil && !entry.IsDir() && match(rel) {
+				info, err = entry.Info()
+				if err == nil {
+					found.add(snapshot, rel, info)
+				}
+			}
+
+			return err
+		},
+	)
+}
+
+// search walks the source in every snapshot oldest first collecting the
+// files matching.  The paths present in the snapshot latest points at are
+// also returned (nil if there is no latest snapshot).
+func search(
+	cfg *settings.Config, match matcher,
+) ([]Match, map[string]bool, error) {
+	var (
+		snapshots []string
+		latest    string
+		present   map[string]bool
+		found     = collector{found: make(map[key]int)}
+		err       error
+	)
+
+	snapshots, err = cfg.Target.Snapshots()
+
+	if err == nil {
+		latest, err = cfg.Target.LatestSnapshot()
+	}
+
+	for i := 0; i < len(snapshots) && err == nil; i++ {
+		found.present = make(map[string]bool)
+
+		err = walk(cfg, snapshots[i], match, &found)
+
+		if snapshots[i] == latest {
+			present = found.present
+		}
+	}
+
+	if err == nil {
+		return found.matches, present, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: %w", ErrSearch, err)
+}
+
+func buildReport(matches []Match) string {
+	var report strings.Builder
+
+	report.WriteString(fmt.Sprintf(rowFormat,
+		"First", "Last", "Snapshots", "Size", "Path",
+	) + "\n")
+
+	for _, match := range matches {
+		report.WriteString(fmt.Sprintf(rowFormat,
+			match.First,
+			match.Last,
+			strconv.Itoa(match.Snapshots),
+			out.Int(match.Size),
+			match.Path,
+		) + "\n")
+	}
+
+	return report.String()
+}
+
+// Process parses the remaining arguments searching the snapshots for files
+// matching the pattern.
+func Process(args *szargs.Args) (string, error) {
+	var (
+		cfg      *settings.Config
+		opts     options
+		match    matcher
+		matches  []Match
+		inLatest map[string]bool
+		outText  string
+		err      error
+	)
+
+	cfg, opts, err = parseArgs(args)
+
+	if err == nil {
+		match, err = newMatcher(opts.pattern, opts.regex)
+	}
+
+	if err == nil {
+		matches, inLatest, err = search(cfg, match)
+	}
+
+	if err == nil && opts.deleted {
+		matches = slices.DeleteFunc(matches, func(match Match) bool {
+			return inLatest[match.Path]
+		})
+	}
+
+	if err == nil && len(matches) == 0 {
+		err = fmt.Errorf("%w: '%s'", ErrNoMatches, opts.pattern)
+	}
+
+	if err == nil {
+		slices.SortStableFunc(matches, func(a, b Match) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+	}
+
+	if err == nil && out.Structured() {
+		outText, err = out.Result(Result{
+			Pattern: opts.pattern,
+			Deleted: opts.deleted,
+			Matches: matches,
+		})
+		if err == nil {
+			return outText, nil
+		}
+	}
+
+	if err == nil {
+		return "find successful\n\n" + buildReport(matches), nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrFindError, err)
+}
@@ -0,0 +1,285 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package find_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/find"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// setup creates three snapshots returning the config file and the names of
+// the snapshots oldest first.
+func setup(chk *sztest.Chk) (string, []string) {
+	chk.T().Helper()
+
+	source := chk.CreateTmpSubDir("source")
+	trgDir := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(source, trgDir)
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	taken := time.Now().Add(-time.Hour * 3)
+	names := make([]string, 0, 3)
+
+	for i := range 3 {
+		dir := trg.SnapshotDir(taken.Add(time.Hour * time.Duration(i)))
+		names = append(names, filepath.Base(dir))
+		chk.NoErr(os.MkdirAll(filepath.Join(dir, "source", "docs"), 0o0700))
+	}
+
+	file := func(name, path string) string {
+		return filepath.Join(trgDir, name, "source", path)
+	}
+
+	write := func(name, path, data string) {
+		chk.NoErr(os.WriteFile(file(name, path), []byte(data), 0o0600))
+	}
+
+	link := func(from, to, path string) {
+		chk.NoErr(os.Link(file(from, path), file(to, path)))
+	}
+
+	write(names[0], "docs/report.txt", "report")
+	write(names[0], "docs/old.txt", "old")
+	write(names[0], "notes.md", "notes")
+
+	link(names[0], names[1], "docs/report.txt")
+	link(names[0], names[1], "notes.md")
+	write(names[1], "docs/draft.txt", "draft")
+
+	write(names[2], "docs/report.txt", "report v2")
+	link(names[1], names[2], "notes.md")
+
+	chk.NoErr(trg.SetLatest(filepath.Join(trgDir, names[2])))
+
+	return cfgFile, names
+}
+
+func row(first, last, count, size, path string) string {
+	return first + "  " + last + "  " +
+		strings.Repeat(" ", 9-len(count)) + count + "  " +
+		strings.Repeat(" ", 13-len(size)) + size + "  " + path
+}
+
+func TestFindProcess_Glob(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile, "*.txt"})
+	outText, err := find.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"find successful",
+			"",
+			"First                     Last                      Snapshots" +
+				"           Size  Path",
+			row(names[1], names[1], "1", "5", "docs/draft.txt"),
+			row(names[0], names[0], "1", "3", "docs/old.txt"),
+			row(names[0], names[1], "2", "6", "docs/report.txt"),
+			row(names[2], names[2], "1", "9", "docs/report.txt"),
+			"",
+		},
+	)
+}
+
+func TestFindProcess_RegexDeleted(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{
+		"prg", "--regex", "--deleted", cfgFile, `^docs/`,
+	})
+	outText, err := find.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"find successful",
+			"",
+			"First                     Last                      Snapshots" +
+				"           Size  Path",
+			row(names[1], names[1], "1", "5", "docs/draft.txt"),
+			row(names[0], names[0], "1", "3", "docs/old.txt"),
+			"",
+		},
+	)
+}
+
+func TestFindProcess_DeletedUnfinished(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	// A newer snapshot still being written without the source.
+	trgDir := filepath.Join(filepath.Dir(cfgFile), "target")
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+	chk.NoErr(os.Mkdir(trg.SnapshotDir(time.Now()), 0o0700))
+
+	args := szargs.New("", []string{"prg", "--deleted", cfgFile, "*.txt"})
+	outText, err := find.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"find successful",
+			"",
+			"First                     Last                      Snapshots" +
+				"           Size  Path",
+			row(names[1], names[1], "1", "5", "docs/draft.txt"),
+			row(names[0], names[0], "1", "3", "docs/old.txt"),
+			"",
+		},
+	)
+}
+
+func TestFindProcess_Structured(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile, names := setup(chk)
+
+	chk.NoErr(out.SetFormat(out.FormatCSV))
+
+	args := szargs.New("", []string{"prg", "--deleted", cfgFile, "*.txt"})
+	outText, err := find.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"first,last,snapshots,size,path\n"+
+			names[1]+","+names[1]+",1,5,docs/draft.txt\n"+
+			names[0]+","+names[0]+",1,3,docs/old.txt\n",
+	)
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	args = szargs.New("", []string{"prg", "--deleted", cfgFile, "old.*"})
+	outText, err = find.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"{\n"+
+			"  \"pattern\": \"old.*\",\n"+
+			"  \"deleted\": true,\n"+
+			"  \"matches\": [\n"+
+			"    {\n"+
+			"      \"path\": \"docs/old.txt\",\n"+
+			"      \"first\": \""+names[0]+"\",\n"+
+			"      \"last\": \""+names[0]+"\",\n"+
+			"      \"snapshots\": 1,\n"+
+			"      \"size\": 3\n"+
+			"    }\n"+
+			"  ]\n"+
+			"}\n",
+	)
+}
+
+func TestFindProcess_PathGlob(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile, "*/notes.md"})
+	_, err := find.Process(args)
+	chk.Err(
+		err,
+		find.ErrFindError.Error()+": "+
+			find.ErrNoMatches.Error()+": '*/notes.md'",
+	)
+
+	args = szargs.New("", []string{"prg", cfgFile, "notes.*"})
+	outText, err := find.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"find successful",
+			"",
+			"First                     Last                      Snapshots" +
+				"           Size  Path",
+			row(names[0], names[2], "3", "5", "notes.md"),
+			"",
+		},
+	)
+}
+
+func TestFindProcess_InvalidArgs(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, _ := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile})
+	_, err := find.Process(args)
+	chk.Err(
+		err,
+		find.ErrFindError.Error()+": "+
+			szargs.ErrMissing.Error()+": pattern",
+	)
+
+	args = szargs.New("", []string{"prg", "--regex", cfgFile, "("})
+	_, err = find.Process(args)
+	chk.Err(
+		err,
+		find.ErrFindError.Error()+": "+
+			find.ErrInvalidPattern.Error()+": '(': "+
+			"error parsing regexp: missing closing ): `(`",
+	)
+
+	args = szargs.New("", []string{"prg", cfgFile, "["})
+	_, err = find.Process(args)
+	chk.Err(
+		err,
+		find.ErrFindError.Error()+": "+
+			find.ErrInvalidPattern.Error()+": '[': "+
+			"syntax error in pattern",
+	)
+}
@@ -24,6 +24,16 @@ const Usage = "" +
 requires the underlying system to have the utility rsync installed which
 will perform the actual snapshots.  One of the following sub commands must
 be provided as follows:
+
+   [--output {text | json | csv}]
+      Selects the output format for any sub command.  Json writes a single
+      structured result (one per run in snapshot --daemon mode) to stdout
+      for snapshot, restore, diff and every sub command accepting csv.  Csv
+      is available for the tabular results of prune, trim, status, vet,
+      policy, history, find, undelete and ls-tree.  The help, create, cat
+      and check sub commands only write text (cat writes the file's
+      contents unchanged).  Rsync's own output is written to stderr.
+      Defaults to text.
 `
 
 // HelpText describes the overall operation of the help subcommand.
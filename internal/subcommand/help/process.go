@@ -23,12 +23,20 @@ import (
 	"strings"
 
 	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/subcommand/cat"
+	"github.com/dancsecs/szbck/internal/subcommand/check"
 	"github.com/dancsecs/szbck/internal/subcommand/create"
+	"github.com/dancsecs/szbck/internal/subcommand/diff"
+	"github.com/dancsecs/szbck/internal/subcommand/find"
+	"github.com/dancsecs/szbck/internal/subcommand/history"
+	"github.com/dancsecs/szbck/internal/subcommand/lstree"
+	"github.com/dancsecs/szbck/internal/subcommand/policy"
 	"github.com/dancsecs/szbck/internal/subcommand/prune"
 	"github.com/dancsecs/szbck/internal/subcommand/restore"
 	"github.com/dancsecs/szbck/internal/subcommand/snapshot"
 	"github.com/dancsecs/szbck/internal/subcommand/status"
 	"github.com/dancsecs/szbck/internal/subcommand/trim"
+	"github.com/dancsecs/szbck/internal/subcommand/undelete"
 	"github.com/dancsecs/szbck/internal/subcommand/vet"
 )
 
@@ -61,8 +69,16 @@ func Process(args *szargs.Args) (string, error) {
 				restore.HelpText + "\n" +
 				prune.HelpText + "\n" +
 				status.HelpText + "\n" +
+				history.HelpText + "\n" +
+				diff.HelpText + "\n" +
+				find.HelpText + "\n" +
+				undelete.HelpText + "\n" +
+				cat.HelpText + "\n" +
+				lstree.HelpText + "\n" +
 				trim.HelpText + "\n" +
-				vet.HelpText +
+				vet.HelpText + "\n" +
+				check.HelpText + "\n" +
+				policy.HelpText +
 				"", nil
 		case "h", "help":
 			return HelpText, nil
@@ -76,10 +92,26 @@ func Process(args *szargs.Args) (string, error) {
 			return prune.HelpText, nil
 		case "stat", "status":
 			return status.HelpText, nil
+		case "hist", "history":
+			return history.HelpText, nil
+		case "d", "diff":
+			return diff.HelpText, nil
+		case "f", "find":
+			return find.HelpText, nil
+		case "u", "undelete":
+			return undelete.HelpText, nil
+		case "cat":
+			return cat.HelpText, nil
+		case "ls", "ls-tree":
+			return lstree.HelpText, nil
 		case "t", "trim":
 			return trim.HelpText, nil
 		case "v", "vet":
 			return vet.HelpText, nil
+		case "check":
+			return check.HelpText, nil
+		case "policy":
+			return policy.HelpText, nil
 		default:
 			err = fmt.Errorf(
 				"%w: '%s'",
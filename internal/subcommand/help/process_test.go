@@ -23,13 +23,21 @@ import (
 	"testing"
 
 	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/subcommand/cat"
+	"github.com/dancsecs/szbck/internal/subcommand/check"
 	"github.com/dancsecs/szbck/internal/subcommand/create"
+	"github.com/dancsecs/szbck/internal/subcommand/diff"
+	"github.com/dancsecs/szbck/internal/subcommand/find"
 	"github.com/dancsecs/szbck/internal/subcommand/help"
+	"github.com/dancsecs/szbck/internal/subcommand/history"
+	"github.com/dancsecs/szbck/internal/subcommand/lstree"
+	"github.com/dancsecs/szbck/internal/subcommand/policy"
 	"github.com/dancsecs/szbck/internal/subcommand/prune"
 	"github.com/dancsecs/szbck/internal/subcommand/restore"
 	"github.com/dancsecs/szbck/internal/subcommand/snapshot"
 	"github.com/dancsecs/szbck/internal/subcommand/status"
 	"github.com/dancsecs/szbck/internal/subcommand/trim"
+	"github.com/dancsecs/szbck/internal/subcommand/undelete"
 	"github.com/dancsecs/szbck/internal/subcommand/vet"
 	"github.com/dancsecs/sztestlog"
 )
@@ -69,8 +77,16 @@ func TestHelpProcess_NoSubcommand(t *testing.T) {
 	wantTxt = append(wantTxt, strings.Split(restore.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(prune.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(status.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(history.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(diff.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(find.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(undelete.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(cat.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(lstree.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(trim.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(vet.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(check.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(policy.HelpText, "\n")...)
 
 	chk.StrSlice(
 		strings.Split(helpText, "\n"),
@@ -93,8 +109,16 @@ func TestHelpProcess_All(t *testing.T) {
 	wantTxt = append(wantTxt, strings.Split(restore.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(prune.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(status.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(history.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(diff.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(find.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(undelete.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(cat.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(lstree.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(trim.HelpText, "\n")...)
 	wantTxt = append(wantTxt, strings.Split(vet.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(check.HelpText, "\n")...)
+	wantTxt = append(wantTxt, strings.Split(policy.HelpText, "\n")...)
 
 	chk.StrSlice(
 		strings.Split(helpText, "\n"),
@@ -267,6 +291,144 @@ func TestHelpProcess_Status(t *testing.T) {
 	)
 }
 
+func TestHelpProcess_History(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "HIST"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(history.HelpText, "\n"),
+	)
+
+	args = szargs.New("", []string{"prg", "HISTORY"})
+	helpText, err = help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(history.HelpText, "\n"),
+	)
+}
+
+func TestHelpProcess_Diff(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "D"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(diff.HelpText, "\n"),
+	)
+
+	args = szargs.New("", []string{"prg", "DIFF"})
+	helpText, err = help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(diff.HelpText, "\n"),
+	)
+}
+
+func TestHelpProcess_Find(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "F"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(find.HelpText, "\n"),
+	)
+
+	args = szargs.New("", []string{"prg", "FIND"})
+	helpText, err = help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(find.HelpText, "\n"),
+	)
+}
+
+func TestHelpProcess_Undelete(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "U"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(undelete.HelpText, "\n"),
+	)
+
+	args = szargs.New("", []string{"prg", "UNDELETE"})
+	helpText, err = help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(undelete.HelpText, "\n"),
+	)
+}
+
+func TestHelpProcess_Cat(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "CAT"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(cat.HelpText, "\n"),
+	)
+
+	args = szargs.New("", []string{"prg", "CAT"})
+	helpText, err = help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(cat.HelpText, "\n"),
+	)
+}
+
+func TestHelpProcess_LsTree(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "LS"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(lstree.HelpText, "\n"),
+	)
+
+	args = szargs.New("", []string{"prg", "LS-TREE"})
+	helpText, err = help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(lstree.HelpText, "\n"),
+	)
+}
+
 func TestHelpProcess_Trim(t *testing.T) {
 	chk := sztestlog.CaptureNothing(t)
 	defer chk.Release()
@@ -312,3 +474,31 @@ func TestHelpProcess_Vet(t *testing.T) {
 		strings.Split(vet.HelpText, "\n"),
 	)
 }
+
+func TestHelpProcess_Check(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "CHECK"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(check.HelpText, "\n"),
+	)
+}
+
+func TestHelpProcess_Policy(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg", "POLICY"})
+	helpText, err := help.Process(args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(helpText, "\n"),
+		strings.Split(policy.HelpText, "\n"),
+	)
+}
@@ -0,0 +1,54 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package history
+
+// HelpText describes the overall operation of the utility.
+const HelpText = `{hist | history} ` +
+	`[-n number] [-t target] config.szb [path]
+
+Reports the transfer statistics recorded in the target's journal for each
+snapshot and restore showing trends across runs.  The number of files
+examined and transferred, the literal data sent, the total bytes sent and
+the elapsed time are listed for each run followed by the snapshot averages.
+Unlike the free space deltas reported by a snapshot the literal data
+separates new file data from metadata churn.
+
+If a path is specified the versions of that file in the snapshots are
+listed instead.  Consecutive snapshots holding the same copy of the file
+(the same inode) are grouped into a single version numbered from 1 for the
+oldest.  The first and last snapshot holding each version are listed along
+with its size, modification time and mode.  A version is restored with the
+restore subcommand's --version option.
+
+   [-n number]
+      Limits the report to the specified number of most recent runs (or
+      versions).
+
+   [-t target]
+      Specifies the backup set to report on.  It is optional if the backup
+      config file specifies a target and mandatory if not specified in the
+      backup config file.
+
+   config.sbc
+      The backup configuration file defining the backup.
+
+   [path]
+      The file to list the versions of.  It is either relative to the
+      configured source or an absolute path within it.
+`
@@ -0,0 +1,354 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+)
+
+const (
+	timeFormat = "2006-01-02 15:04:05"
+	rowFormat  = "%-19s %-8s %-7s %13s %13s %17s %17s %9s"
+	verFormat  = "%7s  %-24s  %-24s  %13s  %-19s  %s"
+	noStats    = "-"
+)
+
+// Result is the structured run history.
+type Result struct {
+	Runs    []journal.Record `json:"runs"`
+	Average *rsync.Stats     `json:"average,omitempty"`
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{
+		"start", "run", "status",
+		"files", "transferred", "literal", "sent", "seconds",
+	}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Runs))
+
+	for _, record := range r.Runs {
+		row := []string{
+			record.Start.Format(time.RFC3339),
+			record.Operation,
+			record.Status,
+		}
+
+		if record.Stats == nil {
+			row = append(row, "", "", "", "", "")
+		} else {
+			row = append(row,
+				strconv.FormatUint(record.Stats.Files, 10),
+				strconv.FormatUint(record.Stats.TransferredFiles, 10),
+				strconv.FormatUint(record.Stats.LiteralData, 10),
+				strconv.FormatUint(record.Stats.BytesSent, 10),
+				strconv.FormatFloat(
+					record.Stats.Elapsed.Seconds(), 'f', -1, 64,
+				),
+			)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// Version is a single version of a file.
+type Version struct {
+	Number   int       `json:"number"`
+	First    string    `json:"first"`
+	Last     string    `json:"last"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+	Mode     string    `json:"mode"`
+}
+
+// Versions is the structured list of the versions of a file.
+type Versions struct {
+	Path     string    `json:"path"`
+	Versions []Version `json:"versions"`
+}
+
+// Header implements out.Tabular.
+func (v Versions) Header() []string {
+	return []string{"version", "first", "last", "size", "modified", "mode"}
+}
+
+// Rows implements out.Tabular.
+func (v Versions) Rows() [][]string {
+	rows := make([][]string, 0, len(v.Versions))
+
+	for _, version := range v.Versions {
+		rows = append(rows, []string{
+			strconv.Itoa(version.Number),
+			version.First,
+			version.Last,
+			strconv.FormatInt(version.Size, 10),
+			version.Modified.Format(time.RFC3339),
+			version.Mode,
+		})
+	}
+
+	return rows
+}
+
+// newVersions returns the structured list of the versions.
+func newVersions(path string, versions []target.Version) Versions {
+	result := Versions{
+		Path:     path,
+		Versions: make([]Version, 0, len(versions)),
+	}
+
+	for _, version := range versions {
+		result.Versions = append(result.Versions, Version{
+			Number:   version.Number,
+			First:    version.First,
+			Last:     version.Last,
+			Size:     version.Size,
+			Modified: version.ModTime,
+			Mode:     version.Mode.String(),
+		})
+	}
+
+	return result
+}
+
+func parseArguments(
+	args *szargs.Args,
+) (*settings.Config, int, string, error) {
+	var (
+		count int
+		found bool
+		cfg   *settings.Config
+		extra []string
+		path  string
+		err   error
+	)
+
+	count, found = args.ValueInt("-n", "")
+	if found && count < 1 {
+		args.PushErr(fmt.Errorf("%w: '%d'", ErrInvalidNum, count))
+	}
+
+	err = args.Err()
+
+	if err == nil {
+		cfg, extra, err = settings.LoadFromArgsWith(args, 1)
+	}
+
+	if err == nil && len(extra) > 0 {
+		path, err = cfg.SnapshotPath(extra[0])
+	}
+
+	return cfg, count, path, err //nolint:wrapcheck // Ok.
+}
+
+func row(start, operation, status string, stats *rsync.Stats) string {
+	if stats == nil {
+		return fmt.Sprintf(rowFormat,
+			start, operation, status,
+			noStats, noStats, noStats, noStats, noStats,
+		)
+	}
+
+	return fmt.Sprintf(rowFormat,
+		start, operation, status,
+		out.Uint(stats.Files),
+		out.Uint(stats.TransferredFiles),
+		out.Uint(stats.LiteralData),
+		out.Uint(stats.BytesSent),
+		rsync.FormatElapsed(stats.Elapsed),
+	)
+}
+
+// average returns the mean statistics of the completed snapshots or nil if
+// there are none.
+func average(records []journal.Record) *rsync.Stats {
+	var (
+		total rsync.Stats
+		count uint64
+	)
+
+	for _, record := range records {
+		if record.Operation != journal.OperationSnapshot ||
+			record.Status == journal.StatusFailed ||
+			record.Stats == nil {
+			continue
+		}
+
+		count++
+		total.Files += record.Stats.Files
+		total.TransferredFiles += record.Stats.TransferredFiles
+		total.LiteralData += record.Stats.LiteralData
+		total.BytesSent += record.Stats.BytesSent
+		total.Elapsed += record.Stats.Elapsed
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	return &rsync.Stats{
+		Files:            total.Files / count,
+		TransferredFiles: total.TransferredFiles / count,
+		LiteralData:      total.LiteralData / count,
+		BytesSent:        total.BytesSent / count,
+		Elapsed:          total.Elapsed / time.Duration(count),
+	}
+}
+
+func buildReport(records []journal.Record) string {
+	var (
+		report strings.Builder
+		counts = make(map[string]int)
+	)
+
+	report.WriteString(fmt.Sprintf(rowFormat,
+		"Start", "Run", "Status",
+		"Files", "Transferred", "Literal", "Sent", "Elapsed",
+	) + "\n")
+
+	for _, record := range records {
+		counts[record.Status]++
+
+		report.WriteString(row(
+			record.Start.Local().Format(timeFormat),
+			record.Operation,
+			record.Status,
+			record.Stats,
+		) + "\n")
+	}
+
+	report.WriteString(
+		row("Average:", journal.OperationSnapshot, "", average(records)) +
+			"\n\n",
+	)
+
+	report.WriteString(fmt.Sprintf(
+		"Runs: %s (Success: %s, Warning: %s, Failed: %s)\n",
+		out.Int(int64(len(records))),
+		out.Int(int64(counts[journal.StatusSuccess])),
+		out.Int(int64(counts[journal.StatusWarning])),
+		out.Int(int64(counts[journal.StatusFailed])),
+	))
+
+	return report.String()
+}
+
+func buildVersionReport(path string, versions []target.Version) string {
+	var report strings.Builder
+
+	report.WriteString("Versions of: " + path + "\n\n")
+
+	report.WriteString(fmt.Sprintf(verFormat,
+		"Version", "First", "Last", "Size", "Modified", "Mode",
+	) + "\n")
+
+	for _, version := range versions {
+		report.WriteString(fmt.Sprintf(verFormat,
+			strconv.Itoa(version.Number),
+			version.First,
+			version.Last,
+			out.Int(version.Size),
+			version.ModTime.Format(timeFormat),
+			version.Mode,
+		) + "\n")
+	}
+
+	return report.String()
+}
+
+// Process parses the remaining arguments reporting on the run history or
+// the versions of a file if a path is specified.
+func Process(args *szargs.Args) (string, error) {
+	var (
+		cfg      *settings.Config
+		count    int
+		path     string
+		records  []journal.Record
+		versions []target.Version
+		outText  string
+		err      error
+	)
+
+	cfg, count, path, err = parseArguments(args)
+
+	if err == nil && path != "" {
+		versions, err = cfg.Target.Versions(path)
+		if err == nil {
+			if count > 0 && count < len(versions) {
+				versions = versions[len(versions)-count:]
+			}
+
+			if out.Structured() {
+				outText, err = out.Result(newVersions(path, versions))
+			} else {
+				outText = "history successful\n\n" +
+					buildVersionReport(path, versions)
+			}
+		}
+
+		if err == nil {
+			return outText, nil
+		}
+	}
+
+	if err == nil {
+		records, err = journal.Load(cfg.Target.Journal())
+	}
+
+	if err == nil && len(records) == 0 {
+		err = ErrNoHistory
+	}
+
+	if err == nil && count > 0 && count < len(records) {
+		records = records[len(records)-count:]
+	}
+
+	if err == nil && out.Structured() {
+		outText, err = out.Result(Result{
+			Runs:    records,
+			Average: average(records),
+		})
+		if err == nil {
+			return outText, nil
+		}
+	}
+
+	if err == nil {
+		return "history successful\n\n" + buildReport(records), nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrHistoryError, err)
+}
@@ -0,0 +1,349 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/history"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+func setup(chk *sztest.Chk) (string, string) {
+	chk.T().Helper()
+
+	dir := chk.CreateTmpDir()
+	source := chk.CreateTmpSubDir("source")
+	trg := chk.CreateTmpSubDir("target")
+
+	bckCfg, err := settings.Create(source, "")
+	chk.NoErr(err)
+
+	cfgFile := filepath.Join(dir, "backup.sbc")
+
+	chk.NoErr(
+		os.WriteFile(cfgFile, []byte(bckCfg), 0o0600),
+	)
+
+	return trg, cfgFile
+}
+
+func addRecords(chk *sztest.Chk, trg string) {
+	chk.T().Helper()
+
+	start := time.Date(2026, time.May, 2, 3, 4, 5, 0, time.Local)
+	journalPath := filepath.Join(trg, target.JournalFile)
+
+	chk.NoErr(journal.Append(journalPath, journal.Record{
+		Operation: journal.OperationSnapshot,
+		Start:     start,
+		Status:    journal.StatusSuccess,
+		Stats: &rsync.Stats{
+			Files:            1000,
+			TransferredFiles: 1000,
+			LiteralData:      5000000,
+			BytesSent:        5100000,
+			Elapsed:          90 * time.Second,
+		},
+	}))
+
+	chk.NoErr(journal.Append(journalPath, journal.Record{
+		Operation: journal.OperationSnapshot,
+		Start:     start.Add(time.Hour),
+		Status:    journal.StatusWarning,
+		Stats: &rsync.Stats{
+			Files:            1002,
+			TransferredFiles: 4,
+			LiteralData:      3000,
+			BytesSent:        60000,
+			Elapsed:          10 * time.Second,
+		},
+	}))
+
+	chk.NoErr(journal.Append(journalPath, journal.Record{
+		Operation: journal.OperationSnapshot,
+		Start:     start.Add(2 * time.Hour),
+		Status:    journal.StatusFailed,
+	}))
+
+	chk.NoErr(journal.Append(journalPath, journal.Record{
+		Operation: journal.OperationRestore,
+		Start:     start.Add(3 * time.Hour),
+		Status:    journal.StatusSuccess,
+		Stats: &rsync.Stats{
+			Files:            1002,
+			TransferredFiles: 1,
+			LiteralData:      100,
+			BytesSent:        20000,
+			Elapsed:          2 * time.Second,
+		},
+	}))
+}
+
+func TestHistory_Process_NoArgs(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{"prg"})
+	outText, err := history.Process(args)
+	chk.Err(
+		err,
+		""+
+			history.ErrHistoryError.Error()+
+			": "+
+			szargs.ErrMissing.Error()+
+			": backup config filename"+
+			"",
+	)
+	chk.Str(outText, "")
+}
+
+func TestHistory_Process_InvalidNum(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, cfgFile := setup(chk)
+
+	args := szargs.New("", []string{"prg", "-n", "0", "-t", trg, cfgFile})
+	outText, err := history.Process(args)
+	chk.Err(
+		err,
+		""+
+			history.ErrHistoryError.Error()+
+			": "+
+			history.ErrInvalidNum.Error()+
+			": '0'"+
+			"",
+	)
+	chk.Str(outText, "")
+}
+
+func TestHistory_Process_NoHistory(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, cfgFile := setup(chk)
+
+	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
+	outText, err := history.Process(args)
+	chk.Err(
+		err,
+		""+
+			history.ErrHistoryError.Error()+
+			": "+
+			history.ErrNoHistory.Error()+
+			"",
+	)
+	chk.Str(outText, "")
+}
+
+func TestHistory_Process_All(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, cfgFile := setup(chk)
+	addRecords(chk, trg)
+
+	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
+	outText, err := history.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"history successful\n"+
+			"\n"+
+			"Start               Run      Status          Files"+
+			"   Transferred           Literal              Sent   Elapsed\n"+
+			"2026-05-02 03:04:05 snapshot success         1,000"+
+			"         1,000         5,000,000         5,100,000     90.0s\n"+
+			"2026-05-02 04:04:05 snapshot warning         1,002"+
+			"             4             3,000            60,000     10.0s\n"+
+			"2026-05-02 05:04:05 snapshot failed              -"+
+			"             -                 -                 -         -\n"+
+			"2026-05-02 06:04:05 restore  success         1,002"+
+			"             1               100            20,000      2.0s\n"+
+			"Average:            snapshot                 1,001"+
+			"           502         2,501,500         2,580,000     50.0s\n"+
+			"\n"+
+			"Runs: 4 (Success: 2, Warning: 1, Failed: 1)\n"+
+			"",
+	)
+}
+
+func TestHistory_Process_Limited(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, cfgFile := setup(chk)
+	addRecords(chk, trg)
+
+	args := szargs.New("", []string{"prg", "-n", "2", "-t", trg, cfgFile})
+	outText, err := history.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"history successful\n"+
+			"\n"+
+			"Start               Run      Status          Files"+
+			"   Transferred           Literal              Sent   Elapsed\n"+
+			"2026-05-02 05:04:05 snapshot failed              -"+
+			"             -                 -                 -         -\n"+
+			"2026-05-02 06:04:05 restore  success         1,002"+
+			"             1               100            20,000      2.0s\n"+
+			"Average:            snapshot                     -"+
+			"             -                 -                 -         -\n"+
+			"\n"+
+			"Runs: 2 (Success: 1, Warning: 0, Failed: 1)\n"+
+			"",
+	)
+}
+
+func TestHistory_Process_CSV(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	trg, cfgFile := setup(chk)
+	addRecords(chk, trg)
+
+	start := time.Date(2026, time.May, 2, 3, 4, 5, 0, time.Local)
+	at := func(hours int) string {
+		return start.Add(time.Duration(hours) * time.Hour).Format(
+			time.RFC3339,
+		)
+	}
+
+	chk.NoErr(out.SetFormat(out.FormatCSV))
+
+	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
+	outText, err := history.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"start,run,status,files,transferred,literal,sent,seconds\n"+
+			at(0)+",snapshot,success,1000,1000,5000000,5100000,90\n"+
+			at(1)+",snapshot,warning,1002,4,3000,60000,10\n"+
+			at(2)+",snapshot,failed,,,,,\n"+
+			at(3)+",restore,success,1002,1,100,20000,2\n"+
+			"",
+	)
+}
+
+func TestHistory_Process_Versions(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, cfgFile := setup(chk)
+
+	trgPath, err := target.New(trg)
+	chk.NoErr(err)
+
+	taken := time.Date(2026, time.May, 2, 3, 4, 5, 0, time.Local)
+	names := make([]string, 0, 3)
+
+	for i := range 3 {
+		dir := trgPath.SnapshotDir(taken.Add(time.Hour * time.Duration(i)))
+		names = append(names, filepath.Base(dir))
+		chk.NoErr(os.MkdirAll(filepath.Join(dir, "source", "docs"), 0o0700))
+	}
+
+	file := func(name string) string {
+		return filepath.Join(trg, name, "source", "docs", "file.txt")
+	}
+
+	chk.NoErr(os.WriteFile(file(names[0]), []byte("one"), 0o0600))
+	chk.NoErr(os.Link(file(names[0]), file(names[1])))
+	chk.NoErr(os.WriteFile(file(names[2]), []byte("three"), 0o0600))
+	chk.NoErr(os.Chtimes(file(names[0]), taken, taken))
+	chk.NoErr(os.Chtimes(file(names[2]), taken, taken))
+	chk.NoErr(trgPath.SetLatest(filepath.Join(trg, names[2])))
+
+	args := szargs.New(
+		"", []string{"prg", "-t", trg, cfgFile, "docs/file.txt"},
+	)
+	outText, err := history.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"history successful\n"+
+			"\n"+
+			"Versions of: source/docs/file.txt\n"+
+			"\n"+
+			"Version  First                     Last                    "+
+			"           Size  Modified             Mode\n"+
+			"      1  "+names[0]+"  "+names[1]+
+			"              3  2026-05-02 03:04:05  -rw-------\n"+
+			"      2  "+names[2]+"  "+names[2]+
+			"              5  2026-05-02 03:04:05  -rw-------\n"+
+			"",
+	)
+
+	args = szargs.New(
+		"", []string{"prg", "-t", trg, cfgFile, "docs/missing.txt"},
+	)
+	outText, err = history.Process(args)
+	chk.Err(
+		err,
+		history.ErrHistoryError.Error()+": "+
+			target.ErrVersions.Error()+": "+
+			target.ErrNoVersions.Error()+": 'source/docs/missing.txt'",
+	)
+	chk.Str(outText, "")
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	args = szargs.New(
+		"", []string{"prg", "-n", "1", "-t", trg, cfgFile, "docs/file.txt"},
+	)
+	outText, err = history.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"{\n"+
+			"  \"path\": \"source/docs/file.txt\",\n"+
+			"  \"versions\": [\n"+
+			"    {\n"+
+			"      \"number\": 2,\n"+
+			"      \"first\": \""+names[2]+"\",\n"+
+			"      \"last\": \""+names[2]+"\",\n"+
+			"      \"size\": 5,\n"+
+			"      \"modified\": \""+taken.Format(time.RFC3339)+"\",\n"+
+			"      \"mode\": \"-rw-------\"\n"+
+			"    }\n"+
+			"  ]\n"+
+			"}\n",
+	)
+}
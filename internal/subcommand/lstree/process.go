@@ -0,0 +1,293 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package lstree
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+)
+
+const (
+	timeFormat = "2006-01-02 15:04"
+	rowFormat  = "%s %3d %-8s %-8s %13s %s %s"
+	linkPrefix = " -> "
+)
+
+// Entry describes a single item held by the snapshot.
+type Entry struct {
+	Name     string    `json:"name"`
+	Mode     string    `json:"mode"`
+	Links    uint64    `json:"links"`
+	Owner    string    `json:"owner"`
+	Group    string    `json:"group"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+	Link     string    `json:"link,omitempty"`
+}
+
+// String returns the ls -l style description of the item.
+func (e Entry) String() string {
+	name := e.Name
+	if e.Link != "" {
+		name += linkPrefix + e.Link
+	}
+
+	return fmt.Sprintf(rowFormat,
+		e.Mode,
+		e.Links,
+		e.Owner,
+		e.Group,
+		out.Int(e.Size),
+		e.Modified.Format(timeFormat),
+		name,
+	)
+}
+
+// Result is the structured listing of a path held by a snapshot.
+type Result struct {
+	Snapshot string  `json:"snapshot"`
+	Path     string  `json:"path"`
+	Entries  []Entry `json:"entries"`
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{
+		"mode", "links", "owner", "group", "size", "modified", "name", "link",
+	}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Entries))
+
+	for _, entry := range r.Entries {
+		rows = append(rows, []string{
+			entry.Mode,
+			strconv.FormatUint(entry.Links, 10),
+			entry.Owner,
+			entry.Group,
+			strconv.FormatInt(entry.Size, 10),
+			entry.Modified.Format(time.RFC3339),
+			entry.Name,
+			entry.Link,
+		})
+	}
+
+	return rows
+}
+
+// owners caches the names of user and group ids.
+type owners struct {
+	users  map[uint32]string
+	groups map[uint32]string
+}
+
+func newOwners() *owners {
+	return &owners{
+		users:  make(map[uint32]string),
+		groups: make(map[uint32]string),
+	}
+}
+
+// user returns the name of the user id or the id if it has no name.
+func (o *owners) user(uid uint32) string {
+	name, ok := o.users[uid]
+	if !ok {
+		name = strconv.FormatUint(uint64(uid), 10)
+		if u, err := user.LookupId(name); err == nil {
+			name = u.Username
+		}
+
+		o.users[uid] = name
+	}
+
+	return name
+}
+
+// group returns the name of the group id or the id if it has no name.
+func (o *owners) group(gid uint32) string {
+	name, ok := o.groups[gid]
+	if !ok {
+		name = strconv.FormatUint(uint64(gid), 10)
+		if g, err := user.LookupGroupId(name); err == nil {
+			name = g.Name
+		}
+
+		o.groups[gid] = name
+	}
+
+	return name
+}
+
+func parseArgs(args *szargs.Args) (*settings.Config, string, string, error) {
+	var (
+		snapshot string
+		path     string
+		cfg      *settings.Config
+		extra    []string
+		err      error
+	)
+
+	snapshot, _ = args.ValueString("-s", "")
+
+	err = args.Err()
+
+	if err == nil {
+		cfg, extra, err = settings.LoadFromArgsWith(args, 1)
+	}
+
+	if err == nil {
+		path = filepath.Base(cfg.Source)
+	}
+
+	if err == nil && len(extra) > 0 {
+		path, err = cfg.SnapshotPath(extra[0])
+	}
+
+	if snapshot == "" {
+		snapshot = target.LatestDirectoryLink
+	}
+
+	return cfg, snapshot, path, err //nolint:wrapcheck // Ok.
+}
+
+// describe returns the description of the item.
+func describe(names *owners, dir string, info fs.FileInfo) (Entry, error) {
+	var (
+		entry = Entry{
+			Name:     info.Name(),
+			Mode:     info.Mode().String(),
+			Links:    1,
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+		}
+		err error
+	)
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		entry.Links = uint64(stat.Nlink)
+		entry.Owner = names.user(stat.Uid)
+		entry.Group = names.group(stat.Gid)
+	}
+
+	if info.Mode()&fs.ModeSymlink != 0 {
+		entry.Link, err = os.Readlink(filepath.Join(dir, entry.Name))
+	}
+
+	return entry, err //nolint:wrapcheck // Ok.
+}
+
+// list returns the items in the directory (or the single item if it is not
+// a directory).
+func list(path string) ([]Entry, error) {
+	var (
+		info    fs.FileInfo
+		dirs    []fs.DirEntry
+		entry   Entry
+		entries []Entry
+		names   = newOwners()
+		err     error
+	)
+
+	info, err = os.Lstat(path)
+
+	if err == nil && !info.IsDir() {
+		entry, err = describe(names, filepath.Dir(path), info)
+
+		return []Entry{entry}, err
+	}
+
+	if err == nil {
+		dirs, err = os.ReadDir(path)
+		entries = make([]Entry, 0, len(dirs))
+	}
+
+	for i := 0; i < len(dirs) && err == nil; i++ {
+		info, err = dirs[i].Info()
+
+		if err == nil {
+			entry, err = describe(names, path, info)
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, err //nolint:wrapcheck // Ok.
+}
+
+// Process parses the remaining arguments listing a directory held by the
+// snapshot.
+func Process(args *szargs.Args) (string, error) {
+	var (
+		cfg      *settings.Config
+		snapshot string
+		path     string
+		entries  []Entry
+		listing  strings.Builder
+		outText  string
+		err      error
+	)
+
+	cfg, snapshot, path, err = parseArgs(args)
+
+	if err == nil {
+		snapshot, err = cfg.Target.Select(snapshot, time.Now())
+	}
+
+	if err == nil {
+		entries, err = list(
+			filepath.Join(cfg.Target.GetPath(), snapshot, path),
+		)
+	}
+
+	if err == nil && out.Structured() {
+		outText, err = out.Result(Result{
+			Snapshot: snapshot,
+			Path:     path,
+			Entries:  entries,
+		})
+		if err == nil {
+			return outText, nil
+		}
+	}
+
+	if err == nil {
+		for _, entry := range entries {
+			listing.WriteString(entry.String() + "\n")
+		}
+
+		return "ls-tree successful\n\n" +
+			snapshot + ": " + path + "\n" +
+			listing.String(), nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrLsTreeError, err)
+}
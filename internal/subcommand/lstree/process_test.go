@@ -0,0 +1,202 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package lstree_test
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/lstree"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// ownerGroup returns the names of the current user and group.
+func ownerGroup() (string, string) {
+	owner := strconv.Itoa(os.Getuid())
+	if u, err := user.LookupId(owner); err == nil {
+		owner = u.Username
+	}
+
+	group := strconv.Itoa(os.Getgid())
+	if g, err := user.LookupGroupId(group); err == nil {
+		group = g.Name
+	}
+
+	return owner, group
+}
+
+// setup creates a snapshot holding a file and a symbolic link returning the
+// config file, the snapshot's name and the expected listing rows.
+func setup(chk *sztest.Chk) (string, string, []string) {
+	chk.T().Helper()
+
+	source := chk.CreateTmpSubDir("source")
+	trgDir := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(source, trgDir)
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	snapDir := trg.SnapshotDir(time.Now())
+	dir := filepath.Join(snapDir, "source", "etc")
+	file := filepath.Join(dir, "foo.conf")
+	link := filepath.Join(dir, "link")
+	modTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+
+	chk.NoErr(os.MkdirAll(dir, 0o0700))
+	chk.NoErr(os.WriteFile(file, []byte("version 1\n"), 0o0600))
+	chk.NoErr(os.Chmod(file, 0o0640))
+	chk.NoErr(os.Chtimes(file, modTime, modTime))
+	chk.NoErr(os.Symlink("foo.conf", link))
+	chk.NoErr(trg.SetLatest(snapDir))
+
+	linkInfo, err := os.Lstat(link)
+	chk.NoErr(err)
+
+	owner, group := ownerGroup()
+
+	return cfgFile, filepath.Base(snapDir), []string{
+		fmt.Sprintf(
+			"-rw-r-----   1 %-8s %-8s            10 %s foo.conf",
+			owner, group, "2025-01-02 03:04",
+		),
+		fmt.Sprintf(
+			"Lrwxrwxrwx   1 %-8s %-8s             8 %s link -> foo.conf",
+			owner, group, linkInfo.ModTime().Format("2006-01-02 15:04"),
+		),
+	}
+}
+
+func TestLsTreeProcess_Directory(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, snapshot, rows := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile, "etc"})
+	outText, err := lstree.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		"ls-tree successful\n\n"+
+			snapshot+": source/etc\n"+
+			rows[0]+"\n"+
+			rows[1]+"\n",
+	)
+}
+
+func TestLsTreeProcess_File(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, snapshot, rows := setup(chk)
+
+	args := szargs.New("", []string{
+		"prg", "-s", "latest", cfgFile, "etc/foo.conf",
+	})
+	outText, err := lstree.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		"ls-tree successful\n\n"+
+			snapshot+": source/etc/foo.conf\n"+
+			rows[0]+"\n",
+	)
+}
+
+func TestLsTreeProcess_Structured(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile, snapshot, _ := setup(chk)
+	owner, group := ownerGroup()
+	modTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+
+	chk.NoErr(out.SetFormat(out.FormatCSV))
+
+	args := szargs.New("", []string{"prg", cfgFile, "etc/foo.conf"})
+	outText, err := lstree.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"mode,links,owner,group,size,modified,name,link\n"+
+			"-rw-r-----,1,"+owner+","+group+",10,"+
+			modTime.Format(time.RFC3339)+",foo.conf,\n",
+	)
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	args = szargs.New("", []string{"prg", cfgFile, "etc/foo.conf"})
+	outText, err = lstree.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"{\n"+
+			"  \"snapshot\": \""+snapshot+"\",\n"+
+			"  \"path\": \"source/etc/foo.conf\",\n"+
+			"  \"entries\": [\n"+
+			"    {\n"+
+			"      \"name\": \"foo.conf\",\n"+
+			"      \"mode\": \"-rw-r-----\",\n"+
+			"      \"links\": 1,\n"+
+			"      \"owner\": \""+owner+"\",\n"+
+			"      \"group\": \""+group+"\",\n"+
+			"      \"size\": 10,\n"+
+			"      \"modified\": \""+modTime.Format(time.RFC3339)+"\"\n"+
+			"    }\n"+
+			"  ]\n"+
+			"}\n",
+	)
+}
+
+func TestLsTreeProcess_Missing(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, snapshot, _ := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile, "etc/bar.conf"})
+	_, err := lstree.Process(args)
+	chk.Err(
+		err,
+		lstree.ErrLsTreeError.Error()+": lstat "+
+			filepath.Join(
+				filepath.Dir(cfgFile), "target", snapshot,
+				"source", "etc", "bar.conf",
+			)+": no such file or directory",
+	)
+}
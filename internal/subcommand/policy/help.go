@@ -0,0 +1,57 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package policy
+
+// HelpText describes the overall operation of the utility.
+const HelpText = `policy simulate ` +
+	`[--period duration] ` +
+	`[--interval duration] ` +
+	`[--keep-hourly duration] ` +
+	`[--keep-daily duration] ` +
+	`config.szb
+
+Simulates the retention policy in the backup config file without reading or
+removing any snapshots.  Synthetic snapshots are taken every interval from
+the start of today for the period and trimmed at the end of each day
+exactly as trim would.  The number of snapshots remaining at the end of
+each day is reported along with how many are still within keepDaily.  The
+steady state is the number within keepDaily once it stops changing.  As a
+snapshot is kept for each ISO week indefinitely the total continues to grow
+by one each week.  Finally every snapshot surviving the period is listed
+with the rule that kept it.  Each duration is a number followed by m
+(minutes), h (hours), d (days) or w (weeks).
+
+   [--period duration]
+      The length of time to simulate.  Defaults to 400d.
+
+   [--interval duration]
+      The time between snapshots.  It must not exceed the period.  Defaults
+      to 1h matching the snapshot daemon.
+
+   [--keep-hourly duration]
+      Replaces keepHourly from the backup config file to preview a change
+      before making it.  It must be at least 24h.
+
+   [--keep-daily duration]
+      Replaces keepDaily from the backup config file to preview a change
+      before making it.  It must be at least 48h and longer than keepHourly.
+
+   config.sbc
+      The backup configuration file defining the retention policy.
+`
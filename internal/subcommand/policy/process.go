@@ -0,0 +1,269 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/trim"
+	"github.com/dancsecs/szbck/internal/target"
+)
+
+// ActionSimulate is the only policy action.
+const ActionSimulate = "simulate"
+
+const day = time.Hour * 24
+
+// Default simulation spanning more than a year of hourly snapshots.
+const (
+	defaultPeriod   = "400d"
+	defaultInterval = "1h"
+)
+
+// options holds the command line options controlling the simulation.
+type options struct {
+	period     time.Duration
+	interval   time.Duration
+	keepHourly time.Duration
+	keepDaily  time.Duration
+}
+
+// Result is the structured outcome of a policy simulation.
+type Result struct {
+	Start      time.Time `json:"start"`
+	Period     string    `json:"period"`
+	Interval   string    `json:"interval"`
+	KeepHourly string    `json:"keepHourly"`
+	KeepDaily  string    `json:"keepDaily"`
+	trim.Simulation
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{"date", "snapshots", "recent"}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Days))
+
+	for _, simulated := range r.Days {
+		rows = append(rows, []string{
+			simulated.Date.Format(time.DateOnly),
+			strconv.Itoa(simulated.Snapshots),
+			strconv.Itoa(simulated.Recent),
+		})
+	}
+
+	return rows
+}
+
+// parseSpan parses a positive duration such as 30m, 1h, 400d or 2w.
+func parseSpan(name, spec string) (time.Duration, error) {
+	span, err := target.ParseDuration(spec)
+	if err == nil && span > 0 {
+		return span, nil
+	}
+
+	return 0, fmt.Errorf("%w: %s: '%s'", ErrInvalidSpan, name, spec)
+}
+
+// formatSpan formats a duration in the largest whole unit.  A duration that
+// is not a whole number of minutes is formatted exactly.
+func formatSpan(span time.Duration) string {
+	switch {
+	case span%day == 0:
+		return strconv.FormatInt(int64(span/day), 10) + "d"
+	case span%time.Hour == 0:
+		return strconv.FormatInt(int64(span/time.Hour), 10) + "h"
+	case span%time.Minute == 0:
+		return strconv.FormatInt(int64(span/time.Minute), 10) + "m"
+	default:
+		return span.String()
+	}
+}
+
+//nolint:cyclop,funlen // Ok.
+func parseArguments(args *szargs.Args) (*settings.Config, options, error) {
+	var (
+		cfg         *settings.Config
+		opts        options
+		action      string
+		periodStr   string
+		intervalStr string
+		hourlyStr   string
+		dailyStr    string
+		found       bool
+		cfgFilename string
+		err         error
+	)
+
+	action = args.NextString("policy action", "")
+
+	periodStr, found = args.ValueString("--period", "")
+	if !found {
+		periodStr = defaultPeriod
+	}
+
+	intervalStr, found = args.ValueString("--interval", "")
+	if !found {
+		intervalStr = defaultInterval
+	}
+
+	hourlyStr, _ = args.ValueString("--keep-hourly", "")
+	dailyStr, _ = args.ValueString("--keep-daily", "")
+
+	cfgFilename = args.NextString("backup config filename", "")
+	args.Done()
+
+	err = args.Err()
+
+	if err == nil && strings.ToLower(action) != ActionSimulate {
+		err = fmt.Errorf("%w: '%s'", ErrUnknownAction, action)
+	}
+
+	if err == nil {
+		opts.period, err = parseSpan("--period", periodStr)
+	}
+
+	if err == nil {
+		opts.interval, err = parseSpan("--interval", intervalStr)
+	}
+
+	if err == nil && opts.interval > opts.period {
+		err = fmt.Errorf(
+			"%w: '%s' > '%s'", ErrSpanOrder, intervalStr, periodStr,
+		)
+	}
+
+	if err == nil && hourlyStr != "" {
+		opts.keepHourly, err = parseSpan("--keep-hourly", hourlyStr)
+	}
+
+	if err == nil && dailyStr != "" {
+		opts.keepDaily, err = parseSpan("--keep-daily", dailyStr)
+	}
+
+	if err == nil {
+		cfg, err = settings.Load(cfgFilename)
+	}
+
+	if err == nil {
+		err = cfg.SetRetention(opts.keepHourly, opts.keepDaily)
+	}
+
+	return cfg, opts, err //nolint:wrapcheck // Ok.
+}
+
+// show returns the human readable report of the simulation.
+func (r Result) show() string {
+	var report strings.Builder
+
+	fmt.Fprintf(&report,
+		"policy simulation (Period: %s, Interval: %s, "+
+			"Keep Hourly: %s, Keep Daily: %s)\n\n"+
+			"Snapshots at End of Day:\n",
+		r.Period, r.Interval, r.KeepHourly, r.KeepDaily,
+	)
+
+	for _, simulated := range r.Days {
+		fmt.Fprintf(&report, "%s: %d (Recent: %d)\n",
+			simulated.Date.Format(time.DateOnly),
+			simulated.Snapshots,
+			simulated.Recent,
+		)
+	}
+
+	fmt.Fprintf(&report, "\nSnapshots Taken: %s\n", out.Int(int64(r.Taken)))
+
+	if r.SteadyState == nil {
+		report.WriteString(
+			"Steady State: not reached (period shorter than keepDaily)\n",
+		)
+	} else {
+		fmt.Fprintf(&report,
+			"Steady State: %d within keepDaily from %s "+
+				"plus one per ISO week\n",
+			*r.SteadyState,
+			r.Settled.Format(time.DateOnly),
+		)
+	}
+
+	fmt.Fprintf(&report, "\nSurvivors (%d):\n", len(r.Survivors))
+
+	for _, survivor := range r.Survivors {
+		fmt.Fprintf(&report, "%s: %s\n",
+			survivor.Time.Format("2006-01-02 15:04 Mon"),
+			survivor.Reason,
+		)
+	}
+
+	return report.String()
+}
+
+// Process parses the remaining arguments simulating the retention policy.
+func Process(args *szargs.Args) (string, error) {
+	var (
+		cfg     *settings.Config
+		opts    options
+		now     = time.Now()
+		start   time.Time
+		result  Result
+		outText string
+		err     error
+	)
+
+	cfg, opts, err = parseArguments(args)
+
+	if err == nil {
+		start = time.Date(
+			now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location(),
+		)
+
+		result = Result{
+			Start:      start,
+			Period:     formatSpan(opts.period),
+			Interval:   formatSpan(opts.interval),
+			KeepHourly: formatSpan(cfg.KeepHourly),
+			KeepDaily:  formatSpan(cfg.KeepDaily),
+			Simulation: trim.Simulate(
+				start, opts.period, opts.interval,
+				cfg.KeepHourly, cfg.KeepDaily,
+			),
+		}
+	}
+
+	if err == nil && out.Structured() {
+		outText, err = out.Result(result)
+	} else if err == nil {
+		outText = result.show()
+	}
+
+	if err == nil {
+		return outText, nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrPolicyError, err)
+}
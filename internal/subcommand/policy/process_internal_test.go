@@ -0,0 +1,37 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestPolicy_FormatSpan(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.Str(formatSpan(day*400), "400d")
+	chk.Str(formatSpan(time.Hour*25), "25h")
+	chk.Str(formatSpan(time.Minute*90), "90m")
+	chk.Str(formatSpan(time.Second*90), "1m30s")
+	chk.Str(formatSpan(time.Millisecond*1500), "1.5s")
+}
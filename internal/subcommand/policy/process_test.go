@@ -0,0 +1,228 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package policy_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/policy"
+	"github.com/dancsecs/szbck/internal/subcommand/trim"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// setup creates a backup config file with the default retention policy.
+func setup(chk *sztest.Chk) string {
+	chk.T().Helper()
+
+	source := chk.CreateTmpSubDir("source")
+	trgDir := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(source, trgDir)
+	chk.NoErr(err)
+
+	return chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+}
+
+func TestPolicyProcess_UnknownAction(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile := setup(chk)
+
+	outText, err := policy.Process(
+		szargs.New("", []string{"prg", "apply", cfgFile}),
+	)
+	chk.Err(
+		err,
+		""+
+			policy.ErrPolicyError.Error()+
+			": "+
+			policy.ErrUnknownAction.Error()+
+			": 'apply'",
+	)
+	chk.Str(outText, "")
+}
+
+func TestPolicyProcess_InvalidSpan(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile := setup(chk)
+
+	_, err := policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile, "--period", "400x",
+	}))
+	chk.Err(
+		err,
+		""+
+			policy.ErrPolicyError.Error()+
+			": "+
+			policy.ErrInvalidSpan.Error()+
+			": --period: '400x'",
+	)
+
+	_, err = policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile, "--keep-daily", "0d",
+	}))
+	chk.Err(
+		err,
+		""+
+			policy.ErrPolicyError.Error()+
+			": "+
+			policy.ErrInvalidSpan.Error()+
+			": --keep-daily: '0d'",
+	)
+
+	_, err = policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile, "--period", "2d", "--interval", "3d",
+	}))
+	chk.Err(
+		err,
+		""+
+			policy.ErrPolicyError.Error()+
+			": "+
+			policy.ErrSpanOrder.Error()+
+			": '3d' > '2d'",
+	)
+}
+
+func TestPolicyProcess_InvalidRetention(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile := setup(chk)
+
+	_, err := policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile, "--keep-hourly", "12h",
+	}))
+	chk.Err(
+		err,
+		""+
+			policy.ErrPolicyError.Error()+
+			": "+
+			settings.ErrInvalidKeepHourly.Error()+
+			": "+
+			settings.ErrRetentionHourlyMin.Error(),
+	)
+
+	_, err = policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile, "--keep-daily", "1d",
+	}))
+	chk.Err(
+		err,
+		""+
+			policy.ErrPolicyError.Error()+
+			": "+
+			settings.ErrInvalidKeepDaily.Error()+
+			": "+
+			settings.ErrRetentionDailyMin.Error(),
+	)
+
+	_, err = policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile,
+		"--keep-hourly", "10d", "--keep-daily", "5d",
+	}))
+	chk.Err(
+		err,
+		""+
+			policy.ErrPolicyError.Error()+
+			": "+
+			settings.ErrInvalidKeepDaily.Error()+
+			": "+
+			settings.ErrRetentionDailyMin.Error(),
+	)
+}
+
+func TestPolicyProcess_Simulate(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile := setup(chk)
+
+	outText, err := policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile, "--period", "3d", "--keep-daily", "1w",
+	}))
+	chk.NoErr(err)
+
+	lines := strings.Split(outText, "\n")
+	chk.Str(
+		lines[0],
+		"policy simulation (Period: 3d, Interval: 1h, "+
+			"Keep Hourly: 1d, Keep Daily: 7d)",
+	)
+	chk.Str(lines[2], "Snapshots at End of Day:")
+	chk.True(strings.HasSuffix(lines[3], ": 23 (Recent: 23)"))
+	chk.True(strings.HasSuffix(lines[4], ": 24 (Recent: 24)"))
+	chk.True(strings.HasSuffix(lines[5], ": 25 (Recent: 25)"))
+	chk.StrSlice(
+		lines[6:10],
+		[]string{
+			"",
+			"Snapshots Taken: 72",
+			"Steady State: not reached (period shorter than keepDaily)",
+			"",
+		},
+	)
+	chk.Str(lines[10], "Survivors (25):")
+	chk.True(strings.HasSuffix(lines[35], ": "+trim.ReasonLatest))
+	chk.Str(lines[36], "")
+}
+
+func TestPolicyProcess_SimulateJSON(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile := setup(chk)
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	outText, err := policy.Process(szargs.New("", []string{
+		"prg", "simulate", cfgFile,
+		"--period", "4w", "--keep-hourly", "24h", "--keep-daily", "7d",
+	}))
+	chk.NoErr(err)
+
+	var result policy.Result
+
+	chk.NoErr(json.Unmarshal([]byte(outText), &result))
+	chk.Str(result.Period, "28d")
+	chk.Str(result.Interval, "1h")
+	chk.Str(result.KeepHourly, "1d")
+	chk.Str(result.KeepDaily, "7d")
+	chk.Int(result.Taken, 28*24)
+	chk.Int(len(result.Days), 28)
+	chk.NotNil(result.SteadyState)
+	chk.Int(*result.SteadyState, 29)
+	chk.NotNil(result.Settled)
+	chk.True(result.Settled.Equal(result.Start.AddDate(0, 0, 6)))
+	chk.Int(len(result.Survivors), result.Days[27].Snapshots)
+	chk.Str(
+		result.Survivors[len(result.Survivors)-1].Reason, trim.ReasonLatest,
+	)
+	chk.True(time.Since(result.Start) < time.Hour*25)
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/metrics"
 	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/purge"
 	"github.com/dancsecs/szbck/internal/settings"
@@ -63,7 +64,80 @@ func parseArguments(
 	return cfg, dryRun, numToDel, err //nolint:wrapcheck // Ok.
 }
 
-func loadBackupDirs(trg string) ([]string, error) {
+// Snapshot actions and reasons.
+const (
+	ActionKeep   = "keep"
+	ActionPurge  = "purge"
+	ReasonOldest = "oldest"
+	ReasonNewer  = "newer than those purged"
+	ReasonLatest = "latest"
+)
+
+// Decision is the action taken on a single snapshot.
+type Decision struct {
+	Snapshot string `json:"snapshot"`
+	Action   string `json:"action"`
+	Reason   string `json:"reason"`
+}
+
+// Result is the structured outcome of a prune.
+type Result struct {
+	DryRun     bool         `json:"dryRun"`
+	Purged     int          `json:"purged"`
+	Snapshots  []Decision   `json:"snapshots"`
+	FileSystem fstat.Change `json:"fileSystem"`
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{"snapshot", "action", "reason"}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Snapshots))
+
+	for _, decision := range r.Snapshots {
+		rows = append(rows, []string{
+			decision.Snapshot, decision.Action, decision.Reason,
+		})
+	}
+
+	return rows
+}
+
+// decide returns the action taken on each snapshot (oldest first) when the
+// oldest num are purged.  The latest is the newest snapshot.
+func decide(num int, dirs []string, latest string) []Decision {
+	decisions := make([]Decision, 0, len(dirs)+1)
+
+	for i, dir := range dirs {
+		decision := Decision{
+			Snapshot: filepath.Base(dir),
+			Action:   ActionKeep,
+			Reason:   ReasonNewer,
+		}
+
+		if i < num {
+			decision.Action = ActionPurge
+			decision.Reason = ReasonOldest
+		}
+
+		decisions = append(decisions, decision)
+	}
+
+	return append(decisions, Decision{
+		Snapshot: filepath.Base(latest),
+		Action:   ActionKeep,
+		Reason:   ReasonLatest,
+	})
+}
+
+// loadBackupDirs returns the snapshot directories that may be purged (oldest
+// first) along with the newest snapshot which is never purged.
+func loadBackupDirs(trg string) ([]string, string, error) {
+	var latest string
+
 	matchingDirs, err := filepath.Glob(
 		filepath.Join(trg, "*"+target.BackupDirectoryExtension),
 	)
@@ -77,11 +151,12 @@ func loadBackupDirs(trg string) ([]string, error) {
 		default:
 			// sort list and remove the newest
 			slices.Sort(matchingDirs)
+			latest = matchingDirs[len(matchingDirs)-1]
 			matchingDirs = matchingDirs[:len(matchingDirs)-1]
 		}
 	}
 
-	return matchingDirs, err
+	return matchingDirs, latest, err
 }
 
 func validateNumberToDelete(rawNum string, maxNum int) (int, error) {
@@ -135,14 +210,16 @@ func Process(args *szargs.Args) (string, error) {
 		numToDel     int
 		cfg          *settings.Config
 		matchingDirs []string
+		latest       string
 		fsStat       *fstat.StatFS
+		outText      string
 		err          error
 	)
 
 	cfg, dryRun, rawNumToDel, err = parseArguments(args)
 
 	if err == nil {
-		matchingDirs, err = loadBackupDirs(cfg.Target.GetPath())
+		matchingDirs, latest, err = loadBackupDirs(cfg.Target.GetPath())
 	}
 
 	if err == nil {
@@ -160,6 +237,22 @@ func Process(args *szargs.Args) (string, error) {
 		err = pruneDirectories(numToDel, matchingDirs, dryRun)
 	}
 
+	if err == nil && dryRun == "" {
+		metrics.Update(cfg, metrics.OperationPrune, numToDel)
+	}
+
+	if err == nil && out.Structured() {
+		outText, err = out.Result(Result{
+			DryRun:     dryRun != "",
+			Purged:     numToDel,
+			Snapshots:  decide(numToDel, matchingDirs, latest),
+			FileSystem: fsStat.Change(),
+		})
+		if err == nil {
+			return outText, nil
+		}
+	}
+
 	//nolint:forbidigo // Ok.
 	if err == nil {
 		fmt.Printf("prune successful%s\nSyncing...\n",
@@ -22,6 +22,16 @@ import "errors"
 
 // Restore errors.
 var (
-	ErrRestoreError   = errors.New("restore error")
-	ErrInvalidSrcPath = errors.New("invalid source path")
+	ErrRestoreError     = errors.New("restore error")
+	ErrInvalidSrcPath   = errors.New("invalid source path")
+	ErrInvalidOutDir    = errors.New("invalid output directory")
+	ErrDeleteUsage      = errors.New("--delete specified without -o")
+	ErrPlanUsage        = errors.New("--plan specified without --preview")
+	ErrAsOfUsage        = errors.New("--as-of specified with -s")
+	ErrUndoUsage        = errors.New("--undo specified with -s or --as-of")
+	ErrVersionUsage     = errors.New("--version used with a snapshot selector")
+	ErrVersionPath      = errors.New("--version specified without a path")
+	ErrPathUsage        = errors.New("path specified without --version")
+	ErrNoSafetySnapshot = errors.New("no pre-restore safety snapshot")
+	ErrSafetySnapshot   = errors.New("safety snapshot failed")
 )
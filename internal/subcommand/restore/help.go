@@ -20,21 +20,88 @@ package restore
 
 // HelpText describes the overall operation of the utility.
 const HelpText = `{r | rest | restore} ` +
-	`[--dry-run] [--keep] [-s snapshot] [-t target] config.szb
+	"[--dry-run] " +
+	"[--preview [--plan file]] " +
+	"[--keep] " +
+	"[--progress] " +
+	"[-s snapshot | --as-of time | --undo | --version N] " +
+	"[-o dir [--delete]] " +
+	"[-t target] " +
+	"config.szb [path]" + `
 
-Restores the specified file or directory tree from the backup.
+Restores the specified file or directory tree from the backup.  By default
+the restore overwrites the configured source.  With -o the snapshot subtree
+is restored into an alternate directory instead (still honouring the
+configured options, exclusions and restoreOptions) so old versions can be
+inspected side by side with the live data.  The transfer statistics reported
+by rsync are displayed and, unless it is a dry run, the restore is recorded
+in the target's szbck.journal file.  Rsync is run with any configured cpuNice
+and ioPriority scheduling priority and the rate limit of the first bandwidth
+window containing the start time.  If rsync runs longer than the configured
+maxRunTime or writes no output or progress for stallTimeout it (and every
+process it started) is killed.  Before any restore that overwrites the
+source (not a dry run and not with -o) a safety snapshot of the current
+source is taken and tagged 'pre-restore' so the restore can be undone.
 
    [--dry-run]
       Identifies all of the actions the utility would take without making any
       changes to the backup source.
 
+   [--preview]
+      Displays the changes the restore would make without making any
+      changes.  They are grouped into files to be created, overwritten (with
+      their size and modification time differences), deleted from the
+      destination and permission only changes followed by their totals.  No
+      safety snapshot is taken.
+
+   [--plan file]
+      Writes the --preview plan to the file.  An argument error occurs if
+      specified without --preview.
+
    [--keep]
       Blocks the restore from deleting source files missing from the target
       backup.
 
+   [--progress]
+      Displays the overall progress of the transfer (percent complete, bytes
+      transferred, rate and estimated time remaining).  When standard output
+      is a terminal a single line is updated in place otherwise a progress
+      line is logged every 30 seconds.
+
    [-s snapshot]
       Specifies the specif snapshot in the target directory to use.  It will
-      default to the symbolic link 'latest' is not provided.
+      default to the symbolic link 'latest' is not provided.  The snapshot
+      may be followed by a path to restore only part of it (IE:
+      latest/user/docs).  Instead of a snapshot directory name it may be a
+      selector: 'latest~N' (N snapshots before latest), 'now', 'yesterday',
+      a time before now (-30m, -3h, -2d or -1w) or a local time formatted
+      as 'YYYY-MM-DD HH:MM' or 'YYYY-MM-DD' (the end of that day).  A time
+      selects the newest snapshot taken at or before it.
+
+   [--as-of time]
+      Selects the newest snapshot taken at or before the time using any of
+      the selectors accepted by -s (IE: --as-of "2026-10-01 14:00").  An
+      argument error occurs if specified with -s.
+
+   [--undo]
+      Restores the 'pre-restore' safety snapshot taken before the last
+      restore using the same options and exclusions, undoing it.  An
+      argument error occurs if specified with -s or --as-of.
+
+   [--version N]
+      Restores version N of the path as listed by the history subcommand
+      (IE: szbck restore --version 3 config.szb docs/report.txt).  An
+      argument error occurs if specified with -s, --as-of or --undo or
+      without a path.
+
+   [-o dir]
+      Restores into the specified directory instead of the configured source.
+      The directory is created if it does not exist.  Existing files in it
+      are only deleted if --delete is also specified.
+
+   [--delete]
+      Deletes files in the -o directory missing from the snapshot subtree.
+      An argument error occurs if specified without -o.
 
    [-t target]
       Specifies the backup set to restore from.  It is optional if the backup
@@ -43,4 +110,8 @@ Restores the specified file or directory tree from the backup.
 
    config.sbc
       the backup configuration file defining the backup.
+
+   [path]
+      The file restored by --version.  It is either relative to the
+      configured source or an absolute path within it.
 `
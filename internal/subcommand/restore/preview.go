@@ -0,0 +1,312 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+)
+
+const (
+	planFilePerm   = 0o0600
+	planTimeFormat = "2006-01-02 15:04:05"
+	planIndent     = "  "
+)
+
+// planItem is a single change a restore would make.
+type planItem struct {
+	change rsync.Change
+	// current is the item at the destination (nil if it does not exist).
+	current os.FileInfo
+	// restored is the item in the snapshot (nil if it does not exist).
+	restored os.FileInfo
+}
+
+// plan groups the changes a restore would make to the destination.
+type plan struct {
+	from        string
+	to          string
+	created     []planItem
+	overwritten []planItem
+	deleted     []planItem
+	permissions []planItem
+	attributes  int
+}
+
+// newPlan categorizes the changes reported by rsync.  Changed items are
+// looked up in both the snapshot and the destination to report their
+// differences.
+func newPlan(changes []rsync.Change, restoreFrom, restoreTo string) plan {
+	result := plan{
+		from: restoreFrom,
+		to:   restoreTo,
+	}
+
+	// Rsync reports names relative to the parent of the directory restored.
+	snapshotRoot := filepath.Dir(restoreFrom)
+
+	for _, change := range changes {
+		item := planItem{change: change}
+		item.current, _ = os.Lstat(filepath.Join(restoreTo, change.Name))
+		item.restored, _ = os.Lstat(filepath.Join(snapshotRoot, change.Name))
+
+		switch change.Kind {
+		case rsync.ChangeCreate:
+			result.created = append(result.created, item)
+		case rsync.ChangeOverwrite:
+			result.overwritten = append(result.overwritten, item)
+		case rsync.ChangeDelete:
+			result.deleted = append(result.deleted, item)
+		case rsync.ChangePermissions:
+			result.permissions = append(result.permissions, item)
+		case rsync.ChangeAttributes:
+			result.attributes++
+		}
+	}
+
+	return result
+}
+
+// size returns the size of the file (0 if it does not exist or is a
+// directory).
+func size(info os.FileInfo) int64 {
+	if info == nil || info.IsDir() {
+		return 0
+	}
+
+	return info.Size()
+}
+
+// modTime returns the formatted modification time of the file.
+func modTime(info os.FileInfo) string {
+	if info == nil {
+		return "-"
+	}
+
+	return info.ModTime().Format(planTimeFormat)
+}
+
+// mode returns the formatted permissions of the file.
+func mode(info os.FileInfo) string {
+	if info == nil {
+		return "-"
+	}
+
+	return info.Mode().String()
+}
+
+// restoredBytes returns the total size of the items restored from the
+// snapshot.
+func restoredBytes(items []planItem) int64 {
+	var total int64
+
+	for _, item := range items {
+		total += size(item.restored)
+	}
+
+	return total
+}
+
+// currentBytes returns the total size of the items currently at the
+// destination.
+func currentBytes(items []planItem) int64 {
+	var total int64
+
+	for _, item := range items {
+		total += size(item.current)
+	}
+
+	return total
+}
+
+func writeSection(
+	buf *strings.Builder,
+	title string,
+	items []planItem,
+	describe func(planItem) string,
+) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "%s (%d):\n", title, len(items))
+
+	for _, item := range items {
+		fmt.Fprintf(buf, "%s%s%s\n",
+			planIndent, item.change.Name, describe(item),
+		)
+	}
+}
+
+// String implements the Stringer interface.
+func (p plan) String() string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "Restore plan: %s -> %s\n", p.from, p.to)
+
+	writeSection(&buf, "Create", p.created, func(item planItem) string {
+		if item.change.IsDir() {
+			return ""
+		}
+
+		return "  " + out.Int(size(item.restored)) + " bytes"
+	})
+
+	writeSection(&buf, "Overwrite", p.overwritten, func(item planItem) string {
+		return fmt.Sprintf("  size %s -> %s  modified %s -> %s",
+			out.Int(size(item.current)),
+			out.Int(size(item.restored)),
+			modTime(item.current),
+			modTime(item.restored),
+		)
+	})
+
+	writeSection(&buf, "Delete", p.deleted, func(item planItem) string {
+		if item.change.IsDir() {
+			return ""
+		}
+
+		return "  " + out.Int(size(item.current)) + " bytes"
+	})
+
+	writeSection(&buf, "Permissions", p.permissions,
+		func(item planItem) string {
+			return "  " + mode(item.current) + " -> " + mode(item.restored)
+		},
+	)
+
+	fmt.Fprintf(&buf, ""+
+		"Totals:\n"+
+		"       Create: %d (%s bytes)\n"+
+		"    Overwrite: %d (%s bytes)\n"+
+		"       Delete: %d (%s bytes)\n"+
+		"  Permissions: %d\n"+
+		"   Attributes: %d",
+		len(p.created), out.Int(restoredBytes(p.created)),
+		len(p.overwritten), out.Int(restoredBytes(p.overwritten)),
+		len(p.deleted), out.Int(currentBytes(p.deleted)),
+		len(p.permissions),
+		p.attributes,
+	)
+
+	return buf.String()
+}
+
+// PlanItem is a single change in a structured restore plan.
+type PlanItem struct {
+	Name          string `json:"name"`
+	CurrentBytes  int64  `json:"currentBytes"`
+	RestoredBytes int64  `json:"restoredBytes"`
+}
+
+// Plan is the structured form of the changes a restore would make.
+type Plan struct {
+	Create      []PlanItem `json:"create"`
+	Overwrite   []PlanItem `json:"overwrite"`
+	Delete      []PlanItem `json:"delete"`
+	Permissions []PlanItem `json:"permissions"`
+	Attributes  int        `json:"attributes"`
+}
+
+// planItems returns the structured form of the items.
+func planItems(items []planItem) []PlanItem {
+	structured := make([]PlanItem, 0, len(items))
+
+	for _, item := range items {
+		structured = append(structured, PlanItem{
+			Name:          item.change.Name,
+			CurrentBytes:  size(item.current),
+			RestoredBytes: size(item.restored),
+		})
+	}
+
+	return structured
+}
+
+// structured returns the structured form of the plan.
+func (p plan) structured() *Plan {
+	return &Plan{
+		Create:      planItems(p.created),
+		Overwrite:   planItems(p.overwritten),
+		Delete:      planItems(p.deleted),
+		Permissions: planItems(p.permissions),
+		Attributes:  p.attributes,
+	}
+}
+
+// preview displays the changes the restore would make without making them
+// returning them.  The plan is also written to the planFile if specified.
+// Nothing is displayed if structured output is selected.
+func preview(
+	ctx context.Context,
+	cfg *settings.Config,
+	deleteMissing bool,
+	restoreFrom string,
+	restoreTo string,
+	planFile string,
+) (*Plan, error) {
+	var (
+		runCtx  context.Context //nolint:containedctx // Ok.
+		cancel  context.CancelFunc
+		changes []rsync.Change
+		result  plan
+		report  string
+		err     error
+	)
+
+	runCtx, cancel = cfg.RunContext(ctx)
+	defer cancel()
+
+	changes, err = rsync.Preview(
+		runCtx,
+		deleteMissing,
+		cfg.Options,
+		cfg.RestoreOptions,
+		restoreFrom,
+		restoreTo,
+		out.TextWriter(),
+		&cfg.Priority,
+	)
+
+	if err == nil {
+		result = newPlan(changes, restoreFrom, restoreTo)
+		report = result.String()
+
+		if !out.Structured() {
+			fmt.Println(report) //nolint:forbidigo // Ok.
+		}
+	}
+
+	if err == nil && planFile != "" {
+		err = os.WriteFile(planFile, []byte(report+"\n"), planFilePerm)
+	}
+
+	if err == nil {
+		return result.structured(), nil
+	}
+
+	return nil, err
+}
@@ -19,18 +19,25 @@
 package restore
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/rsync"
 	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/snapshot"
 	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/szbck/internal/wait"
 )
 
 var reFindBackupSubDir = regexp.MustCompile(
@@ -44,28 +51,138 @@ var reFindBackupSubDir = regexp.MustCompile(
 		`\.szb`,
 )
 
-func parseArgs(
-	args *szargs.Args,
-) (*settings.Config, string, bool, bool, error) {
+// outDirPerm is the permission used when creating an alternate destination.
+const outDirPerm = 0o0700
+
+// options holds the command line options controlling a restore.
+type options struct {
+	snapshot string
+	asOf     string
+	outDir   string
+	planFile string
+	path     string
+	version  int
+	dryRun   bool
+	keep     bool
+	delete   bool
+	progress bool
+	undo     bool
+	preview  bool
+}
+
+func parseArgs(args *szargs.Args) (*settings.Config, options, error) {
 	var (
-		dryRun   bool
-		keep     bool
-		snapshot string
-		cfg      *settings.Config
-		err      error
+		opts  options
+		cfg   *settings.Config
+		extra []string
+		err   error
 	)
 
-	dryRun = args.Is("--dry-run", "")
-	keep = args.Is("--keep", "")
-	snapshot, _ = args.ValueString("-s", "")
+	opts.dryRun = args.Is("--dry-run", "")
+	opts.keep = args.Is("--keep", "")
+	opts.delete = args.Is("--delete", "")
+	opts.progress = args.Is("--progress", "")
+	opts.undo = args.Is("--undo", "")
+	opts.preview = args.Is("--preview", "")
+	opts.snapshot, _ = args.ValueString("-s", "")
+	opts.asOf, _ = args.ValueString("--as-of", "")
+	opts.outDir, _ = args.ValueString("-o", "")
+	opts.planFile, _ = args.ValueString("--plan", "")
+	opts.version, _ = args.ValueInt("--version", "")
+
+	if !args.HasErr() && opts.asOf != "" && opts.snapshot != "" {
+		args.PushErr(ErrAsOfUsage)
+	}
+
+	if !args.HasErr() && opts.undo &&
+		(opts.asOf != "" || opts.snapshot != "") {
+		args.PushErr(ErrUndoUsage)
+	}
+
+	if !args.HasErr() && opts.version != 0 &&
+		(opts.asOf != "" || opts.snapshot != "" || opts.undo) {
+		args.PushErr(ErrVersionUsage)
+	}
+
+	if !args.HasErr() && opts.delete && opts.outDir == "" {
+		opts.delete = false
+
+		args.PushErr(ErrDeleteUsage)
+	}
+
+	if !args.HasErr() && opts.planFile != "" && !opts.preview {
+		args.PushErr(ErrPlanUsage)
+	}
 
 	err = args.Err()
 
 	if err == nil {
-		cfg, err = settings.LoadFromArgs(args)
+		cfg, extra, err = settings.LoadFromArgsWith(args, 1)
+	}
+
+	if err == nil && opts.version != 0 && len(extra) == 0 {
+		err = ErrVersionPath
+	}
+
+	if err == nil && opts.version == 0 && len(extra) > 0 {
+		err = ErrPathUsage
+	}
+
+	if err == nil && len(extra) > 0 {
+		opts.path, err = cfg.SnapshotPath(extra[0])
 	}
 
-	return cfg, snapshot, dryRun, keep, err //nolint:wrapcheck // Ok.
+	return cfg, opts, err //nolint:wrapcheck // Ok.
+}
+
+// selectSnapshot resolves the --as-of time, the --version of the path or the
+// snapshot selector leading the -s path to the name of the snapshot it
+// identifies.
+func selectSnapshot(
+	trg *target.Path, opts options, now time.Time,
+) (string, error) {
+	if opts.undo {
+		if !trg.HasTag(target.PreRestoreTag) {
+			return "", ErrNoSafetySnapshot
+		}
+
+		return target.PreRestoreTag, nil
+	}
+
+	if opts.version != 0 {
+		version, err := trg.Version(opts.path, opts.version)
+		if err != nil {
+			return "", err //nolint:wrapcheck // Ok.
+		}
+
+		return filepath.Join(version.Last, opts.path), nil
+	}
+
+	if opts.asOf != "" {
+		return trg.Select(opts.asOf, now) //nolint:wrapcheck // Ok.
+	}
+
+	return trg.SelectPath(opts.snapshot, now) //nolint:wrapcheck // Ok.
+}
+
+// prepareOutDir creates the alternate destination if it does not exist
+// returning its absolute path.
+func prepareOutDir(outDir string) (string, error) {
+	err := os.MkdirAll(outDir, outDirPerm)
+
+	if err == nil {
+		err = directory.Is(outDir)
+	}
+
+	if err == nil {
+		outDir, err = filepath.Abs(outDir)
+	}
+
+	if err == nil {
+		return outDir, nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrInvalidOutDir, err)
 }
 
 // MakeDirs creates the target string based on the restoreFrom directory
@@ -120,45 +237,221 @@ func MakeDirs(srcPath, toPath string) (string, string, error) {
 	return "", "", err
 }
 
+// Result is the structured outcome of a restore.
+type Result struct {
+	Snapshot       string       `json:"snapshot"`
+	From           string       `json:"from"`
+	To             string       `json:"to"`
+	DryRun         bool         `json:"dryRun"`
+	SafetySnapshot string       `json:"safetySnapshot,omitempty"`
+	Plan           *Plan        `json:"plan,omitempty"`
+	Stats          *rsync.Stats `json:"stats,omitempty"`
+}
+
+// logRestore appends a record of the restore to the target's journal.  The
+// restore's error (if any) is returned along with any error writing to the
+// journal.
+func logRestore(
+	cfg *settings.Config,
+	restoreFrom string,
+	outDir string,
+	start time.Time,
+	stats *rsync.Stats,
+	runErr error,
+) error {
+	record := journal.Record{
+		Operation:   journal.OperationRestore,
+		Snapshot:    reFindBackupSubDir.FindString(restoreFrom),
+		Destination: outDir,
+		Start:       start,
+		End:         time.Now(),
+		Status:      journal.StatusSuccess,
+		ExitCode:    rsync.ExitCode(runErr),
+		Stats:       stats,
+	}
+
+	if runErr != nil {
+		record.Status = journal.StatusFailed
+		record.Error = runErr.Error()
+	}
+
+	err := journal.Append(cfg.Target.Journal(), record)
+
+	switch {
+	case err == nil:
+		return runErr
+	case runErr == nil:
+		return err //nolint:wrapcheck // Ok.
+	default:
+		return fmt.Errorf("%w: %w", runErr, err)
+	}
+}
+
 // Process parses the remaining arguments restoring from a szbackup snapshot.
-func Process(args *szargs.Args) (string, error) {
+//
+//nolint:funlen // Ok.
+func Process(ctx context.Context, args *szargs.Args) (string, error) {
 	var (
-		cfg         *settings.Config
-		dryRun      bool
-		keep        bool
-		snapshot    string
-		restoreFrom string
-		restoreTo   string
-		err         error
+		cfg           *settings.Config
+		opts          options
+		deleteMissing bool
+		restoreFrom   string
+		safetyDir     string
+		restoreTo     string
+		scanner       *rsync.StatsScanner
+		progress      *rsync.ProgressScanner
+		display       *rsync.ProgressDisplay
+		report        func(rsync.Progress)
+		runCtx        context.Context //nolint:containedctx // Ok.
+		cancel        context.CancelFunc
+		stdout        io.Writer
+		rsyncArgs     []string
+		stats         rsync.Stats
+		statsErr      error
+		runStats      *rsync.Stats
+		start         time.Time
+		result        Result
+		err           error
 	)
 
-	cfg, snapshot, dryRun, keep, err = parseArgs(args)
+	cfg, opts, err = parseArgs(args)
+
+	if err == nil {
+		opts.snapshot, err = selectSnapshot(cfg.Target, opts, time.Now())
+	}
 
 	if err == nil {
 		restoreFrom, restoreTo, err = MakeDirs(
-			filepath.Join(cfg.Target.GetPath(), snapshot),
+			filepath.Join(cfg.Target.GetPath(), opts.snapshot),
 			cfg.Source,
 		)
 	}
 
+	// Deleting is off by default when restoring to an alternate destination.
+	deleteMissing = !opts.keep
+
+	if err == nil && opts.outDir != "" {
+		deleteMissing = opts.delete
+		restoreTo, err = prepareOutDir(opts.outDir)
+	}
+
+	result = Result{
+		Snapshot: opts.snapshot,
+		From:     restoreFrom,
+		To:       restoreTo,
+		DryRun:   opts.dryRun || opts.preview,
+	}
+
+	if err == nil && opts.preview {
+		result.Plan, err = preview(
+			ctx, cfg, deleteMissing, restoreFrom, restoreTo, opts.planFile,
+		)
+
+		return restoreResult(result, "restore preview successful\n", err)
+	}
+
+	// The restore's source has been resolved to a specific snapshot so it
+	// is not changed by the safety snapshot becoming latest.
+	if err == nil && !opts.dryRun && opts.outDir == "" {
+		safetyDir, err = snapshot.Tagged(ctx, cfg, target.PreRestoreTag)
+		if err == nil {
+			result.SafetySnapshot = filepath.Base(safetyDir)
+
+			if !out.Structured() {
+				fmt.Printf( //nolint:forbidigo // Ok.
+					"Safety snapshot: %s\n", result.SafetySnapshot,
+				)
+			}
+		} else {
+			err = fmt.Errorf("%w: %w", ErrSafetySnapshot, err)
+		}
+	}
+
 	if err == nil {
+		scanner = rsync.NewStatsScanner(out.TextWriter())
+		stdout = scanner
+		rsyncArgs = rsync.WithStats(rsync.BuildArgs(
+			deleteMissing,
+			opts.dryRun,
+			"", // no linkDesk for restore operations.
+			cfg.Options,
+			cfg.RestoreOptions,
+			restoreFrom,
+			restoreTo,
+		))
+
+		start = time.Now()
+		rsyncArgs = rsync.WithBandwidthLimit(
+			rsyncArgs, wait.BandwidthLimit(cfg.Bandwidth, start),
+		)
+
+		// Progress updates are also requested when detecting stalls as
+		// rsync is otherwise silent while transferring.
+		if opts.progress || cfg.StallTimeout > 0 {
+			if opts.progress {
+				display = rsync.NewProgressDisplay(
+					out.IsTerminal(os.Stdout), rsync.ProgressLogInterval,
+				)
+				report = display.Show
+			}
+
+			progress = rsync.NewProgressScanner(scanner, report)
+			rsyncArgs = rsync.WithProgress(rsyncArgs)
+			stdout = progress
+		}
+
+		runCtx, cancel = cfg.RunContext(ctx)
 		err = rsync.Run(
-			rsync.BuildArgs(
-				!keep, // Delete from target unless keep option was provided.
-				dryRun,
-				"", // no linkDesk for restore operations.
-				cfg.Options,
-				cfg.RestoreOptions,
-				restoreFrom,
-				restoreTo,
-			),
-			os.Stdout,
+			runCtx,
+			rsyncArgs,
+			stdout,
 			os.Stderr,
+			rsync.WithStallTimeout(nil, cfg.StallTimeout),
+			&cfg.Priority,
 		)
+
+		cancel()
+
+		if progress != nil {
+			progress.Flush()
+		}
+
+		if display != nil {
+			display.Done()
+		}
+
+		// Statistics are not available if rsync failed before reporting
+		// them.
+		stats, statsErr = scanner.Result()
+		if statsErr == nil {
+			stats.Elapsed = time.Since(start)
+			runStats = &stats
+		}
+
+		if !opts.dryRun {
+			err = logRestore(
+				cfg, restoreFrom, opts.outDir, start, runStats, err,
+			)
+		}
+	}
+
+	if err == nil && runStats != nil && !out.Structured() {
+		fmt.Println(runStats.Report()) //nolint:forbidigo // Ok.
+	}
+
+	result.Stats = runStats
+
+	return restoreResult(result, "restore successful\n", err)
+}
+
+// restoreResult returns the structured result if selected or the message.
+func restoreResult(result Result, msg string, err error) (string, error) {
+	if err == nil && out.Structured() {
+		msg, err = out.Result(result)
 	}
 
 	if err == nil {
-		return "restore successful\n", nil
+		return msg, nil
 	}
 
 	return "", fmt.Errorf("%w: %w", ErrRestoreError, err)
@@ -0,0 +1,375 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestRestore_LogRestore(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target: trg,
+	}
+
+	start := time.Now()
+	restoreFrom := filepath.Join(
+		trg.SnapshotDir(start), "source", "subDir",
+	)
+
+	chk.NoErr(
+		logRestore(
+			cfg, restoreFrom, "", start, &rsync.Stats{Files: 2}, nil,
+		),
+	)
+
+	chk.Err(
+		logRestore(
+			cfg, restoreFrom, "/tmp/out", start, nil, errors.New("run failed"),
+		),
+		"run failed",
+	)
+
+	records, err := journal.Load(trg.Journal())
+	chk.NoErr(err)
+	chk.Int(len(records), 2)
+
+	chk.Str(records[0].Operation, journal.OperationRestore)
+	chk.Str(records[0].Snapshot, filepath.Base(trg.SnapshotDir(start)))
+	chk.Str(records[0].Status, journal.StatusSuccess)
+	chk.Uint64(records[0].Stats.Files, 2)
+	chk.Str(records[0].Destination, "")
+
+	chk.Str(records[1].Status, journal.StatusFailed)
+	chk.Str(records[1].Error, "run failed")
+	chk.Str(records[1].Destination, "/tmp/out")
+	chk.Nil(records[1].Stats)
+}
+
+func TestRestore_ParseArgsOutDir(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{
+		"szbck",
+		"-o", "/tmp/recovered", "--delete", "-t", chk.CreateTmpDir(),
+	})
+
+	_, opts, err := parseArgs(args)
+
+	chk.Err(err, szargs.ErrMissing.Error()+": backup config filename")
+	chk.Str(opts.outDir, "/tmp/recovered")
+	chk.True(opts.delete)
+	chk.False(opts.keep)
+
+	args = szargs.New("", []string{"szbck", "--delete", "config.sbc"})
+
+	_, opts, err = parseArgs(args)
+
+	chk.Err(err, ErrDeleteUsage.Error())
+	chk.False(opts.delete)
+}
+
+func TestRestore_PrepareOutDir(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+
+	outDir, err := prepareOutDir(filepath.Join(dir, "new", "recovered"))
+	chk.NoErr(err)
+	chk.Str(outDir, filepath.Join(dir, "new", "recovered"))
+	chk.NoErr(directory.Is(outDir))
+
+	// Existing directories are used as is.
+	outDir, err = prepareOutDir(filepath.Join(dir, "new"))
+	chk.NoErr(err)
+	chk.Str(outDir, filepath.Join(dir, "new"))
+
+	file := filepath.Join(dir, "file")
+	chk.NoErr(os.WriteFile(file, []byte("data"), 0o0600))
+
+	_, err = prepareOutDir(file)
+	chk.True(errors.Is(err, ErrInvalidOutDir))
+}
+
+func TestRestore_ParseArgsAsOf(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{
+		"szbck", "-s", "-3h", "--as-of", "2026-10-01 14:00", "config.sbc",
+	})
+
+	_, opts, err := parseArgs(args)
+
+	chk.Err(err, ErrAsOfUsage.Error())
+	chk.Str(opts.snapshot, "-3h")
+	chk.Str(opts.asOf, "2026-10-01 14:00")
+}
+
+func TestRestore_SelectSnapshot(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.Local)
+	older := now.Add(-time.Hour * 5)
+	newer := now.Add(-time.Hour)
+
+	chk.NoErr(os.Mkdir(trg.SnapshotDir(older), 0o0700))
+	chk.NoErr(os.Mkdir(trg.SnapshotDir(newer), 0o0700))
+
+	snapshot, err := selectSnapshot(
+		trg, options{snapshot: "-3h/source/subDir"}, now,
+	)
+	chk.NoErr(err)
+	chk.Str(
+		snapshot,
+		filepath.Join(
+			filepath.Base(trg.SnapshotDir(older)), "source", "subDir",
+		),
+	)
+
+	snapshot, err = selectSnapshot(trg, options{asOf: "now"}, now)
+	chk.NoErr(err)
+	chk.Str(snapshot, filepath.Base(trg.SnapshotDir(newer)))
+
+	snapshot, err = selectSnapshot(trg, options{}, now)
+	chk.NoErr(err)
+	chk.Str(snapshot, "")
+
+	_, err = selectSnapshot(trg, options{asOf: "-1w"}, now)
+	chk.True(errors.Is(err, target.ErrNoSnapshot))
+}
+
+func TestRestore_ParseArgsUndo(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{
+		"szbck", "--undo", "-s", "latest", "config.sbc",
+	})
+
+	_, opts, err := parseArgs(args)
+
+	chk.Err(err, ErrUndoUsage.Error())
+	chk.True(opts.undo)
+}
+
+func TestRestore_SelectSnapshotUndo(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.Local)
+
+	_, err = selectSnapshot(trg, options{undo: true}, now)
+	chk.Err(err, ErrNoSafetySnapshot.Error())
+
+	chk.NoErr(os.Mkdir(trg.SnapshotDir(now), 0o0700))
+	chk.NoErr(trg.SetTag(target.PreRestoreTag, trg.SnapshotDir(now)))
+
+	snapshot, err := selectSnapshot(trg, options{undo: true}, now)
+	chk.NoErr(err)
+	chk.Str(snapshot, target.PreRestoreTag)
+}
+
+func TestRestore_ParseArgsPreview(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	args := szargs.New("", []string{
+		"szbck", "--plan", "plan.txt", "config.sbc",
+	})
+
+	_, opts, err := parseArgs(args)
+
+	chk.Err(err, ErrPlanUsage.Error())
+	chk.Str(opts.planFile, "plan.txt")
+	chk.False(opts.preview)
+}
+
+func TestRestore_Plan(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+	snapshotDir := filepath.Join(dir, "snapshot")
+	restoreFrom := filepath.Join(snapshotDir, "source")
+	restoreTo := filepath.Join(dir, "live")
+	modified := time.Date(2026, time.October, 1, 9, 30, 0, 0, time.Local)
+
+	write := func(path, data string, perm os.FileMode) {
+		chk.NoErr(os.MkdirAll(filepath.Dir(path), 0o0700))
+		chk.NoErr(os.WriteFile(path, []byte(data), perm))
+		chk.NoErr(os.Chmod(path, perm))
+		chk.NoErr(os.Chtimes(path, modified, modified))
+	}
+
+	write(filepath.Join(restoreFrom, "new.txt"), "new", 0o0600)
+	write(filepath.Join(restoreFrom, "file.txt"), "restored", 0o0600)
+	write(filepath.Join(restoreTo, "source", "file.txt"), "live", 0o0600)
+	write(filepath.Join(restoreTo, "source", "extra.txt"), "extra", 0o0600)
+	write(filepath.Join(restoreFrom, "run.sh"), "run", 0o0700)
+	write(filepath.Join(restoreTo, "source", "run.sh"), "run", 0o0600)
+
+	changes := []rsync.Change{
+		{
+			Kind:  rsync.ChangeAttributes,
+			Flags: ".d..t......",
+			Name:  "source/",
+		},
+		{
+			Kind:  rsync.ChangeCreate,
+			Flags: ">f+++++++++",
+			Name:  "source/new.txt",
+		},
+		{
+			Kind:  rsync.ChangeOverwrite,
+			Flags: ">f.st......",
+			Name:  "source/file.txt",
+		},
+		{
+			Kind:  rsync.ChangeDelete,
+			Flags: "*deleting  ",
+			Name:  "source/extra.txt",
+		},
+		{
+			Kind:  rsync.ChangePermissions,
+			Flags: ".f...p.....",
+			Name:  "source/run.sh",
+		},
+	}
+
+	chk.Str(
+		newPlan(changes, restoreFrom, restoreTo).String(),
+		""+
+			"Restore plan: "+restoreFrom+" -> "+restoreTo+"\n"+
+			"Create (1):\n"+
+			"  source/new.txt  3 bytes\n"+
+			"Overwrite (1):\n"+
+			"  source/file.txt  size 4 -> 8  modified "+
+			"2026-10-01 09:30:00 -> 2026-10-01 09:30:00\n"+
+			"Delete (1):\n"+
+			"  source/extra.txt  5 bytes\n"+
+			"Permissions (1):\n"+
+			"  source/run.sh  -rw------- -> -rwx------\n"+
+			"Totals:\n"+
+			"       Create: 1 (3 bytes)\n"+
+			"    Overwrite: 1 (8 bytes)\n"+
+			"       Delete: 1 (5 bytes)\n"+
+			"  Permissions: 1\n"+
+			"   Attributes: 1",
+	)
+}
+
+func TestRestore_ParseArgsVersion(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	source := chk.CreateTmpSubDir("source")
+
+	cfgData, err := settings.Create(source, chk.CreateTmpSubDir("target"))
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+
+	args := szargs.New("", []string{
+		"szbck", "--version", "2", cfgFile, "docs/file.txt",
+	})
+
+	_, opts, err := parseArgs(args)
+	chk.NoErr(err)
+	chk.Int(opts.version, 2)
+	chk.Str(opts.path, filepath.Join("source", "docs", "file.txt"))
+
+	args = szargs.New("", []string{
+		"szbck", "--version", "2", "-s", "latest", cfgFile, "file.txt",
+	})
+
+	_, _, err = parseArgs(args)
+	chk.Err(err, ErrVersionUsage.Error())
+
+	args = szargs.New("", []string{"szbck", "--version", "2", cfgFile})
+
+	_, _, err = parseArgs(args)
+	chk.Err(err, ErrVersionPath.Error())
+
+	args = szargs.New("", []string{"szbck", cfgFile, "file.txt"})
+
+	_, _, err = parseArgs(args)
+	chk.Err(err, ErrPathUsage.Error())
+}
+
+func TestRestore_SelectSnapshotVersion(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.Local)
+	path := filepath.Join("source", "file.txt")
+	names := make([]string, 0, 3)
+
+	for i := range 3 {
+		dir := trg.SnapshotDir(now.Add(-time.Hour * time.Duration(3-i)))
+		names = append(names, filepath.Base(dir))
+		chk.NoErr(os.MkdirAll(filepath.Join(dir, "source"), 0o0700))
+	}
+
+	file := func(name string) string {
+		return filepath.Join(trg.GetPath(), name, path)
+	}
+
+	chk.NoErr(os.WriteFile(file(names[0]), []byte("one"), 0o0600))
+	chk.NoErr(os.Link(file(names[0]), file(names[1])))
+	chk.NoErr(os.WriteFile(file(names[2]), []byte("two"), 0o0600))
+
+	snapshot, err := selectSnapshot(
+		trg, options{version: 1, path: path}, now,
+	)
+	chk.NoErr(err)
+	chk.Str(snapshot, filepath.Join(names[1], path))
+
+	_, err = selectSnapshot(trg, options{version: 3, path: path}, now)
+	chk.True(errors.Is(err, target.ErrInvalidVersion))
+}
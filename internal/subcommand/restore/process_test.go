@@ -19,6 +19,7 @@
 package restore_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -49,6 +50,17 @@ const (
 		"                    # (     #%)\n" +
 		"       Delta:                    # (     #%)" +
 		"                    # (     #%)"
+	summaryEstimate = "" +
+		"   Estimated:                    # (     #%)" +
+		"                    # (     #%)"
+	summaryStats = "" +
+		"       Files:                    #\n" +
+		" Transferred:                    #\n" +
+		"     Literal:                    #\n" +
+		"     Matched:                    #\n" +
+		"        Sent:                    #\n" +
+		"     Speedup:                 #. #\n" +
+		"     Elapsed:                 #.#s"
 )
 
 const basicOptions = "" +
@@ -64,6 +76,25 @@ const basicOptions = "" +
 	" " + "--exclude=go/pkg" +
 	""
 
+// estimateOptions are the basicOptions without any output related flags.
+const estimateOptions = "" +
+	" " + "--archive" +
+	" " + "--human-readable" +
+	" " + "--acls" +
+	" " + "--xattrs" +
+	" " + "--atimes" +
+	" " + "--hard-links" +
+	" " + "--fsync" +
+	" " + "--exclude=.cache" +
+	" " + "--exclude=go/pkg" +
+	""
+
+// statsFlags are added to every rsync run to capture its statistics.
+const statsFlags = "" +
+	" " + rsync.FlgInfoStats +
+	" " + rsync.FlgNoHumanReadable +
+	""
+
 //nolint:goCheckNoGlobals // Ok.
 var rsyncCmd string
 
@@ -156,6 +187,32 @@ func setupBackupConfig(chk *sztest.Chk) (string, string) {
 	return source, cfgFile
 }
 
+// safetySnapshot returns the output of the pre-restore safety snapshot taken
+// before restoring into the source.
+func safetySnapshot(source, trg string) string {
+	return "" +
+		"Running command: " +
+		rsyncCmd + estimateOptions +
+		" " + rsync.FlgDelete +
+		" " + rsync.FlgDryRun +
+		" " + rsync.FlgLinkDest +
+		filepath.Join(trg, target.LatestDirectoryLink) +
+		statsFlags +
+		" " + source +
+		" " + filepath.Join(trg, squashFName) +
+		"\n" +
+		"Running command: " +
+		rsyncCmd + basicOptions +
+		" " + rsync.FlgDelete +
+		" " + rsync.FlgLinkDest +
+		filepath.Join(trg, target.LatestDirectoryLink) +
+		statsFlags +
+		" " + source +
+		" " + filepath.Join(trg, squashFName) +
+		"\n" +
+		"Safety snapshot: " + squashFName
+}
+
 func TestRestore_MakeDirs(t *testing.T) {
 	chk := sztestlog.CaptureNothing(t)
 	defer chk.Release()
@@ -218,7 +275,7 @@ func TestRestoreProcess_noArgs(t *testing.T) {
 	defer chk.Release()
 
 	args := szargs.New("", []string{"prg"})
-	textOut, err := restore.Process(args)
+	textOut, err := restore.Process(context.Background(), args)
 
 	chk.Err(
 		err,
@@ -241,7 +298,7 @@ func TestRestoreProcess_InvalidSpecificDirectory(t *testing.T) {
 	trg := chk.CreateTmpSubDir("target")
 
 	args := szargs.New("", []string{"prg", "-s", ".", "-t", trg, cfgFile})
-	textOut, err := restore.Process(args)
+	textOut, err := restore.Process(context.Background(), args)
 
 	chk.Err(
 		err,
@@ -268,12 +325,12 @@ func TestRestoreProcess_FromLatestTargetDirectory(t *testing.T) {
 	file := chk.CreateTmpFileIn(source, []byte("file1"))
 
 	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
 	args = szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -291,7 +348,7 @@ func TestRestoreProcess_FromLatestTargetDirectory(t *testing.T) {
 			cfgFile,
 		},
 	)
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -299,27 +356,44 @@ func TestRestoreProcess_FromLatestTargetDirectory(t *testing.T) {
 	squashNumbers(chk)
 	chk.Log()
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName)+
+			"",
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName)+
 			"",
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
+		safetySnapshot(source, trg),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+filepath.Join(trg, squashFName, "source")+
 			" "+dir+
 			"",
+		summaryStats,
+		safetySnapshot(source, trg),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+filepath.Join(trg, squashFName, "source")+
 			" "+dir+
 			"",
+		summaryStats,
 	)
 }
 
@@ -334,12 +408,12 @@ func TestRestoreProcess_FromBaseTargetDirectory(t *testing.T) {
 	file := chk.CreateTmpFileIn(source, []byte("file1"))
 
 	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
 	args = szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -347,7 +421,7 @@ func TestRestoreProcess_FromBaseTargetDirectory(t *testing.T) {
 	chk.NoErr(os.Remove(file))
 
 	args = szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -355,27 +429,44 @@ func TestRestoreProcess_FromBaseTargetDirectory(t *testing.T) {
 	squashNumbers(chk)
 	chk.Log()
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName)+
+			"",
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName)+
 			"",
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
+		safetySnapshot(source, trg),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+filepath.Join(trg, squashFName, "source")+
 			" "+dir+
 			"",
+		summaryStats,
+		safetySnapshot(source, trg),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+filepath.Join(trg, squashFName, "source")+
 			" "+dir+
 			"",
+		summaryStats,
 	)
 }
 
@@ -390,12 +481,12 @@ func TestRestoreProcess_DryRun_And_Keep(t *testing.T) {
 	file := chk.CreateTmpFileIn(source, []byte("file1"))
 
 	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
 	args = szargs.New("", []string{"prg", "--dry-run", "-t", trg, cfgFile})
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -403,7 +494,7 @@ func TestRestoreProcess_DryRun_And_Keep(t *testing.T) {
 	chk.NoErr(os.Remove(file))
 
 	args = szargs.New("", []string{"prg", "--keep", "-t", trg, cfgFile})
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -411,28 +502,44 @@ func TestRestoreProcess_DryRun_And_Keep(t *testing.T) {
 	squashNumbers(chk)
 	chk.Log()
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName)+
+			"",
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName)+
 			"",
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
 			" "+rsync.FlgDryRun+ // --dry-run
+			statsFlags+
 			" "+filepath.Join(trg, squashFName, "source")+
 			" "+dir+
 			"",
+		summaryStats,
+		safetySnapshot(source, trg),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			// " "+rsync.FlgDelete+  --keep
+			statsFlags+
 			" "+filepath.Join(trg, squashFName, "source")+
 			" "+dir+
 			"",
+		summaryStats,
 	)
 }
 
@@ -454,7 +561,7 @@ func TestRestoreProcess_SpecificDirectoryTree(t *testing.T) {
 	fileSub2 := chk.CreateTmpFileIn(srcSubDir2, []byte("fileSub2"))
 
 	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
@@ -469,7 +576,7 @@ func TestRestoreProcess_SpecificDirectoryTree(t *testing.T) {
 			cfgFile,
 		},
 	)
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -488,7 +595,7 @@ func TestRestoreProcess_SpecificDirectoryTree(t *testing.T) {
 			cfgFile,
 		},
 	)
-	outText, err = restore.Process(args)
+	outText, err = restore.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	chk.Str(outText, "restore successful\n")
@@ -507,29 +614,46 @@ func TestRestoreProcess_SpecificDirectoryTree(t *testing.T) {
 	squashNumbers(chk)
 	chk.Log()
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName)+
+			"",
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName)+
 			"",
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
+		safetySnapshot(source, trg),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+
 			filepath.Join(
 				trg, squashFName, "source", "subDir2",
 			)+
 			" "+source+
 			"",
+		summaryStats,
+		safetySnapshot(source, trg),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+filepath.Join(trg, squashFName, "source", "subDir2")+
 			" "+source+
 			"",
+		summaryStats,
 	)
 }
@@ -31,5 +31,9 @@ var (
 	ErrAtUsage      = errors.New("--at specified without --daemon")
 	ErrMonitorUsage = errors.New("--monitor specified without --daemon")
 
+	ErrInsufficientSpace = errors.New("insufficient target space")
+	ErrLowSpace          = errors.New("target space below floor")
+	ErrSnapshotFailed    = errors.New("snapshot marked as failed")
+
 	ErrTrimNotImplement = errors.New("trim retention not yet implemented")
 )
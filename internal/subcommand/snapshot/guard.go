@@ -0,0 +1,80 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+)
+
+// guardInterval defines how often the target's free space is checked while
+// a snapshot is running.
+const guardInterval = time.Second * 30
+
+// checkFloor insures the target's free bytes and iNodes have not dropped
+// below the configured floors.
+func checkFloor(cfg *settings.Config) error {
+	fsStat, err := fstat.New(cfg.Target.GetPath())
+
+	if err == nil &&
+		(fsStat.FreeBytes() < cfg.MinFreeBytes ||
+			fsStat.FreeINodes() < cfg.MinFreeINodes) {
+		err = fmt.Errorf(
+			"%w: free %s bytes and %s iNodes: floor %s bytes and %s iNodes",
+			ErrLowSpace,
+			out.Uint(fsStat.FreeBytes()),
+			out.Uint(fsStat.FreeINodes()),
+			out.Uint(cfg.MinFreeBytes),
+			out.Uint(cfg.MinFreeINodes),
+		)
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
+
+// spaceGuard returns a guard stopping rsync if the target's free space drops
+// below the configured floors or nil if no floors have been configured.
+func spaceGuard(cfg *settings.Config) *rsync.Guard {
+	if cfg.MinFreeBytes == 0 && cfg.MinFreeINodes == 0 {
+		return nil
+	}
+
+	return &rsync.Guard{
+		Interval: guardInterval,
+		Check: func() error {
+			return checkFloor(cfg)
+		},
+	}
+}
+
+// markFailed renames the partial snapshot identifying it as failed leaving
+// latest pointing at the previous successful snapshot.
+func markFailed(cfg *settings.Config, newDir string, runErr error) error {
+	failedDir, err := cfg.Target.MarkFailed(newDir)
+	if err == nil {
+		return fmt.Errorf("%w: '%s': %w", ErrSnapshotFailed, failedDir, runErr)
+	}
+
+	return fmt.Errorf("%w: %w", runErr, err)
+}
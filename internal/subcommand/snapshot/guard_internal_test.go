@@ -0,0 +1,102 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestSnapshotGuard_SpaceGuard(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target: trg,
+	}
+
+	chk.Nil(spaceGuard(cfg))
+
+	cfg.MinFreeINodes = 1
+
+	guard := spaceGuard(cfg)
+	chk.NotNil(guard)
+	chk.Dur(guard.Interval, guardInterval)
+	chk.NoErr(guard.Check())
+
+	cfg.MinFreeBytes = math.MaxUint64
+
+	chk.AddSub(`[\d,]+ bytes`, "# bytes")
+	chk.AddSub(`[\d,]+ iNodes`, "# iNodes")
+
+	chk.Err(
+		guard.Check(),
+		""+
+			ErrLowSpace.Error()+
+			": free # bytes and # iNodes: floor # bytes and # iNodes"+
+			"",
+	)
+}
+
+func TestSnapshotGuard_MarkFailed(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target: trg,
+	}
+
+	newDir, err := trg.Create(time.Now(), initialBackupDirPerm)
+	chk.NoErr(err)
+
+	runErr := errors.New("run failed")
+
+	err = markFailed(cfg, newDir, runErr)
+	chk.Err(
+		err,
+		""+
+			ErrSnapshotFailed.Error()+
+			": '"+newDir+target.FailedDirectoryExtension+"'"+
+			": run failed"+
+			"",
+	)
+	chk.True(errors.Is(err, runErr))
+
+	chk.Err(
+		markFailed(cfg, newDir, runErr),
+		""+
+			"run failed: "+
+			target.ErrMarkFailed.Error()+
+			": rename "+newDir+" "+newDir+target.FailedDirectoryExtension+
+			": no such file or directory"+
+			"",
+	)
+}
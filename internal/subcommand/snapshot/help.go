@@ -23,11 +23,30 @@ const HelpText = `{s | snap | snapshot} ` +
 	"[--dry-run] " +
 	"[--daemon [--at minute] [--monitor]] " +
 	"[--trim] " +
+	"[--progress] " +
 	"[-t target] " +
 	"config.szb" + `
 
 Create a new snapshot of the source listed in the configuration file located
-in the target directory.
+in the target directory.  Before each snapshot an rsync dry run estimates the
+bytes and iNodes required.  The snapshot is aborted if the target does not
+have enough free space.  The estimate is reported with the run summary.
+While rsync is running the target's free space is checked periodically
+against the configured minFreeBytes and minFreeINodes floors.  If either is
+crossed rsync is stopped, the partial snapshot is renamed with a .failed
+extension and latest continues to point at the previous snapshot.  If rsync
+exits with a code listed by a warningExitCode entry (IE: 24 some source files
+vanished) the snapshot is still completed and latest updated.  The outcome of
+each snapshot, including any files that vanished or could not be read, is
+appended to the szbck.journal file in the target directory.  The transfer
+statistics reported by rsync (files examined and transferred, literal and
+matched data, bytes sent, speedup and elapsed time) are displayed with the
+run summary and recorded in the journal.  See the history subcommand.
+Rsync is run with any configured cpuNice and ioPriority scheduling priority
+and the rate limit of the first bandwidth window containing the start time.
+If rsync runs longer than the configured maxRunTime or writes no output or
+progress for stallTimeout it (and every process it started) is killed and
+the snapshot is marked as failed.
 
    [--dry-run]
       Identifies all of the actions the utility would take without making any
@@ -48,7 +67,15 @@ in the target directory.
 
    [--trim]
       Executes the retention policy as specified in the configuration file
-      after the snapshot has been successfully completed.
+      after the snapshot has been successfully completed.  If the target does
+      not have enough free space for the estimated snapshot the retention
+      policy is also executed before the snapshot in an attempt to free space.
+
+   [--progress]
+      Displays the overall progress of the transfer (percent complete, bytes
+      transferred, rate and estimated time remaining).  When standard output
+      is a terminal a single line is updated in place otherwise a progress
+      line is logged every 30 seconds.
 
    [-t target]
       Specifies the backup set to create the new snapshot in.  It is optional
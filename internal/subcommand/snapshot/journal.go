@@ -0,0 +1,114 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+)
+
+// outcome classifies the error returned by rsync.  An exit code configured
+// as a warning is accepted returning the warning status and the accepted
+// error in place of the error.
+func outcome(cfg *settings.Config, runErr error) (string, error, error) {
+	if runErr == nil {
+		return journal.StatusSuccess, nil, nil
+	}
+
+	code := rsync.ExitCode(runErr)
+	if code > 0 && cfg.IsWarningExitCode(code) {
+		return journal.StatusWarning, runErr, nil
+	}
+
+	return journal.StatusFailed, nil, runErr
+}
+
+// classify returns the failure class of the error returned by a failed
+// snapshot.
+func classify(runErr error) string {
+	switch {
+	case errors.Is(runErr, ErrLowSpace),
+		errors.Is(runErr, ErrInsufficientSpace):
+		return journal.ClassSpace
+	case errors.Is(runErr, rsync.ErrStalled):
+		return journal.ClassStalled
+	case errors.Is(runErr, rsync.ErrTimeout):
+		return journal.ClassTimeout
+	case errors.Is(runErr, context.Canceled):
+		return journal.ClassCancelled
+	case rsync.ExitCode(runErr) > 0:
+		return journal.ClassRsync
+	default:
+		return journal.ClassOther
+	}
+}
+
+// logAborted records a snapshot abandoned before its directory was created
+// (IE: by the preflight space check) as failed returning the error as logRun
+// does.
+func logAborted(
+	cfg *settings.Config,
+	runTime, start time.Time,
+	tag string,
+	runErr error,
+) error {
+	return logRun(cfg, journal.Record{
+		Operation: journal.OperationSnapshot,
+		Snapshot:  filepath.Base(cfg.Target.SnapshotDir(runTime)),
+		Tag:       tag,
+		Start:     start,
+		Status:    journal.StatusFailed,
+	}, runErr)
+}
+
+// logRun completes the record appending it to the target's journal.  A
+// failed snapshot's error is returned along with any error writing to the
+// journal.  Otherwise only an error writing to the journal is returned.
+func logRun(
+	cfg *settings.Config, record journal.Record, runErr error,
+) error {
+	record.End = time.Now()
+	record.ExitCode = rsync.ExitCode(runErr)
+
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+
+	if record.Status == journal.StatusFailed {
+		record.Class = classify(runErr)
+	}
+
+	err := journal.Append(cfg.Target.Journal(), record)
+
+	switch {
+	case record.Status != journal.StatusFailed:
+		return err //nolint:wrapcheck // Ok.
+	case err == nil:
+		return runErr
+	default:
+		return fmt.Errorf("%w: %w", runErr, err)
+	}
+}
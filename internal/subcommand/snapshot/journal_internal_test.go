@@ -0,0 +1,222 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestSnapshotJournal_Outcome(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfg := &settings.Config{
+		WarningExitCodes: []int{24},
+	}
+
+	status, accepted, err := outcome(cfg, nil)
+	chk.Str(status, journal.StatusSuccess)
+	chk.NoErr(accepted)
+	chk.NoErr(err)
+
+	runErr := exec.Command("sh", "-c", "exit 24").Run()
+
+	status, accepted, err = outcome(cfg, runErr)
+	chk.Str(status, journal.StatusWarning)
+	chk.Err(accepted, "exit status 24")
+	chk.NoErr(err)
+
+	runErr = exec.Command("sh", "-c", "exit 23").Run()
+
+	status, accepted, err = outcome(cfg, runErr)
+	chk.Str(status, journal.StatusFailed)
+	chk.NoErr(accepted)
+	chk.Err(err, "exit status 23")
+
+	runErr = errors.New("not an exit")
+
+	status, accepted, err = outcome(cfg, runErr)
+	chk.Str(status, journal.StatusFailed)
+	chk.NoErr(accepted)
+	chk.Err(err, "not an exit")
+}
+
+func TestSnapshotJournal_LogRun(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target: trg,
+	}
+
+	start := time.Now()
+	newDir := trg.SnapshotDir(start)
+	record := journal.Record{
+		Operation: journal.OperationSnapshot,
+		Snapshot:  filepath.Base(newDir),
+		Start:     start,
+		Status:    journal.StatusSuccess,
+		Stats:     &rsync.Stats{Files: 3},
+	}
+
+	chk.NoErr(logRun(cfg, record, nil))
+
+	record.Status = journal.StatusWarning
+	record.Stats = nil
+	record.Warnings = []rsync.Warning{
+		{Reason: rsync.WarnVanished, Path: "/src/a.txt"},
+	}
+	runErr := exec.Command("sh", "-c", "exit 24").Run()
+
+	chk.NoErr(logRun(cfg, record, runErr))
+
+	record.Status = journal.StatusFailed
+	record.Warnings = nil
+	runErr = errors.New("run failed")
+
+	chk.Err(logRun(cfg, record, runErr), "run failed")
+
+	records, err := journal.Load(trg.Journal())
+	chk.NoErr(err)
+	chk.Int(len(records), 3)
+
+	chk.Str(records[0].Snapshot, filepath.Base(newDir))
+	chk.Str(records[0].Status, journal.StatusSuccess)
+	chk.Str(records[0].Error, "")
+	chk.Str(records[0].Class, "")
+	chk.Str(records[0].Operation, journal.OperationSnapshot)
+	chk.Uint64(records[0].Stats.Files, 3)
+	chk.True(!records[0].End.Before(start))
+
+	chk.Str(records[1].Status, journal.StatusWarning)
+	chk.Str(records[1].Error, "exit status 24")
+	chk.Int(records[1].ExitCode, 24)
+	chk.Int(len(records[1].Warnings), 1)
+
+	chk.Str(records[2].Status, journal.StatusFailed)
+	chk.Str(records[2].Error, "run failed")
+	chk.Str(records[2].Class, journal.ClassOther)
+	chk.Int(records[2].ExitCode, 0)
+}
+
+func TestSnapshotJournal_Classify(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.Str(
+		classify(fmt.Errorf("%w: %w", rsync.ErrStopped, ErrLowSpace)),
+		journal.ClassSpace,
+	)
+	chk.Str(
+		classify(fmt.Errorf("%w: %w", rsync.ErrTimeout, rsync.ErrStalled)),
+		journal.ClassStalled,
+	)
+	chk.Str(
+		classify(fmt.Errorf("%w: %w", rsync.ErrTimeout, rsync.ErrMaxRunTime)),
+		journal.ClassTimeout,
+	)
+	chk.Str(classify(context.Canceled), journal.ClassCancelled)
+	chk.Str(
+		classify(exec.Command("sh", "-c", "exit 23").Run()),
+		journal.ClassRsync,
+	)
+	chk.Str(classify(errors.New("other")), journal.ClassOther)
+	chk.Str(
+		classify(fmt.Errorf("%w: need more", ErrInsufficientSpace)),
+		journal.ClassSpace,
+	)
+}
+
+func TestSnapshotJournal_LogAborted(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target: trg,
+	}
+
+	runTime := time.Now()
+	spaceErr := fmt.Errorf("%w: need more", ErrInsufficientSpace)
+
+	chk.Err(
+		logAborted(cfg, runTime, runTime, "pre-restore", spaceErr),
+		spaceErr.Error(),
+	)
+
+	records, err := journal.Load(trg.Journal())
+	chk.NoErr(err)
+	chk.Int(len(records), 1)
+	chk.Str(records[0].Operation, journal.OperationSnapshot)
+	chk.Str(
+		records[0].Snapshot, filepath.Base(trg.SnapshotDir(runTime)),
+	)
+	chk.Str(records[0].Tag, "pre-restore")
+	chk.Str(records[0].Status, journal.StatusFailed)
+	chk.Str(records[0].Class, journal.ClassSpace)
+	chk.Str(records[0].Error, spaceErr.Error())
+}
+
+func TestSnapshotJournal_LogRun_AppendFailed(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	cfg := &settings.Config{
+		Target: trg,
+	}
+
+	// A directory in place of the journal prevents it being appended to.
+	_ = chk.CreateTmpSubDir(target.JournalFile)
+
+	record := journal.Record{
+		Snapshot: filepath.Base(trg.SnapshotDir(time.Now())),
+		Status:   journal.StatusSuccess,
+	}
+	appendErr := journal.ErrAppend.Error() +
+		": open " + trg.Journal() + ": is a directory"
+
+	chk.Err(logRun(cfg, record, nil), appendErr)
+
+	record.Status = journal.StatusFailed
+
+	chk.Err(
+		logRun(cfg, record, errors.New("run failed")),
+		"run failed: "+appendErr,
+	)
+}
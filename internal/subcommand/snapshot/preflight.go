@@ -0,0 +1,107 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/trim"
+)
+
+// checkSpace insures the target file system has enough free bytes and
+// iNodes for the estimated snapshot.
+func checkSpace(estimate rsync.Stats, fsStat *fstat.StatFS) error {
+	if estimate.NeededBytes() <= fsStat.FreeBytes() &&
+		estimate.NeededINodes() <= fsStat.FreeINodes() {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: need %s bytes and %s iNodes: free %s bytes and %s iNodes",
+		ErrInsufficientSpace,
+		out.Uint(estimate.NeededBytes()),
+		out.Uint(estimate.NeededINodes()),
+		out.Uint(fsStat.FreeBytes()),
+		out.Uint(fsStat.FreeINodes()),
+	)
+}
+
+// preflight estimates the space required for the new snapshot using an rsync
+// dry run and compares it to the space available on the target.  If there is
+// not enough room and trimming was requested the retention policy is applied
+// first in an attempt to free enough space.
+func preflight(
+	ctx context.Context,
+	cfg *settings.Config,
+	linkDest string,
+	newDir string,
+	trimFirst bool,
+) (rsync.Stats, int, error) {
+	var (
+		estimate    rsync.Stats
+		fsStat      *fstat.StatFS
+		purgedCount int
+		err         error
+	)
+
+	estimate, err = rsync.Estimate(
+		ctx,
+		linkDest,
+		cfg.Options,
+		cfg.SnapshotOptions,
+		cfg.Source,
+		newDir,
+		&cfg.Priority,
+	)
+
+	if err == nil {
+		fsStat, err = fstat.New(cfg.Target.GetPath())
+	}
+
+	if err == nil {
+		err = checkSpace(estimate, fsStat)
+	}
+
+	if errors.Is(err, ErrInsufficientSpace) && trimFirst {
+		out.Printf("%v: trimming before snapshot\n", err)
+
+		purgedCount, err = trim.PurgeSnapshots(cfg, time.Now(), "")
+		if errors.Is(err, trim.ErrNoBackups) ||
+			errors.Is(err, trim.ErrOnlyLatest) {
+			err = nil
+		}
+
+		if err == nil {
+			fsStat, err = fstat.New(cfg.Target.GetPath())
+		}
+
+		if err == nil {
+			err = checkSpace(estimate, fsStat)
+		}
+	}
+
+	return estimate, purgedCount, err
+}
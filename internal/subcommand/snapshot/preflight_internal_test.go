@@ -0,0 +1,79 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestSnapshotPreflight_CheckSpace(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	fsStat, err := fstat.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	chk.NoErr(checkSpace(rsync.Stats{}, fsStat))
+
+	chk.NoErr(
+		checkSpace(
+			rsync.Stats{
+				CreatedFiles:        1,
+				TransferredFiles:    1,
+				CreatedRegular:      1,
+				TransferredFileSize: 1,
+			},
+			fsStat,
+		),
+	)
+
+	chk.AddSub(`[\d,]+ bytes`, "# bytes")
+	chk.AddSub(`[\d,]+ iNodes`, "# iNodes")
+
+	chk.Err(
+		checkSpace(
+			rsync.Stats{
+				TransferredFileSize: math.MaxUint64,
+			},
+			fsStat,
+		),
+		""+
+			ErrInsufficientSpace.Error()+
+			": need # bytes and # iNodes: free # bytes and # iNodes"+
+			"",
+	)
+
+	chk.Err(
+		checkSpace(
+			rsync.Stats{
+				CreatedFiles: math.MaxUint64,
+			},
+			fsStat,
+		),
+		""+
+			ErrInsufficientSpace.Error()+
+			": need # bytes and # iNodes: free # bytes and # iNodes"+
+			"",
+	)
+}
@@ -19,13 +19,18 @@
 package snapshot
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/metrics"
 	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/rsync"
 	"github.com/dancsecs/szbck/internal/settings"
@@ -39,7 +44,7 @@ const initialBackupDirPerm = 0o0700
 func parseArgs(
 	args *szargs.Args,
 	startTime time.Time,
-) (*settings.Config, string, bool, bool, int, bool, error) {
+) (*settings.Config, string, bool, bool, int, bool, bool, error) {
 	const maxMinute = 59
 
 	var (
@@ -51,6 +56,7 @@ func parseArgs(
 		runAtMin  uint8
 		foundAt   bool
 		monitor   bool
+		progress  bool
 		err       error
 	)
 
@@ -65,6 +71,8 @@ func parseArgs(
 
 	monitor = args.Is("--monitor", "")
 
+	progress = args.Is("--progress", "")
+
 	runAtMin, foundAt = args.ValueUint8("--at", "")
 
 	if !args.HasErr() {
@@ -100,29 +108,106 @@ func parseArgs(
 		cfg, err = settings.LoadFromArgs(args)
 	}
 
-	return cfg, dryRun, trimAfter, daemon, int(runAtMin), monitor, err
+	return cfg, dryRun, trimAfter, daemon, int(runAtMin), monitor, progress,
+		err
 }
 
-func run(dryRun bool, linkDest, newDir string, cfg *settings.Config) error {
-	return rsync.Run( //nolint:wrapcheck // Ok.
-		rsync.BuildArgs(
-			true, // Delete from target
-			dryRun,
-			linkDest,
-			cfg.Options,
-			cfg.SnapshotOptions,
-			cfg.Source,
-			newDir,
-		),
-		os.Stdout,
-		os.Stderr,
+//nolint:funlen // Ok.
+func run(
+	ctx context.Context,
+	dryRun, showProgress bool,
+	linkDest, newDir string,
+	cfg *settings.Config,
+) (journal.Record, error) {
+	var (
+		guard    *rsync.Guard
+		scanner  = rsync.NewStatsScanner(out.TextWriter())
+		warnings = new(rsync.WarningScanner)
+		progress *rsync.ProgressScanner
+		display  *rsync.ProgressDisplay
+		report   func(rsync.Progress)
+		stdout   io.Writer
+		args     []string
+		stats    rsync.Stats
+		statsErr error
+		record   journal.Record
+		err      error
+	)
+
+	if !dryRun {
+		guard = spaceGuard(cfg)
+	}
+
+	guard = rsync.WithStallTimeout(guard, cfg.StallTimeout)
+
+	record = journal.Record{
+		Operation: journal.OperationSnapshot,
+		Snapshot:  filepath.Base(newDir),
+		Start:     time.Now(),
+	}
+
+	args = rsync.WithStats(rsync.BuildArgs(
+		true, // Delete from target
+		dryRun,
+		linkDest,
+		cfg.Options,
+		cfg.SnapshotOptions,
+		cfg.Source,
+		newDir,
+	))
+	args = rsync.WithBandwidthLimit(
+		args, wait.BandwidthLimit(cfg.Bandwidth, record.Start),
+	)
+	stdout = scanner
+
+	// Progress updates are also requested when detecting stalls as rsync
+	// is otherwise silent while transferring.
+	if showProgress || cfg.StallTimeout > 0 {
+		if showProgress {
+			display = rsync.NewProgressDisplay(
+				out.IsTerminal(os.Stdout), rsync.ProgressLogInterval,
+			)
+			report = display.Show
+		}
+
+		progress = rsync.NewProgressScanner(scanner, report)
+		args = rsync.WithProgress(args)
+		stdout = progress
+	}
+
+	err = rsync.Run(
+		ctx,
+		args,
+		stdout,
+		io.MultiWriter(os.Stderr, warnings),
+		guard,
+		&cfg.Priority,
 	)
+
+	if progress != nil {
+		progress.Flush()
+	}
+
+	if display != nil {
+		display.Done()
+	}
+
+	// Statistics are not available if rsync failed before reporting them.
+	stats, statsErr = scanner.Result()
+	if statsErr == nil {
+		stats.Elapsed = time.Since(record.Start)
+		record.Stats = &stats
+	}
+
+	record.SetWarnings(warnings.Warnings())
+
+	return record, err //nolint:wrapcheck // Ok.
 }
 
 // Process parses the remaining arguments creating a szbackup snapshot.
 //
-//nolint:cyclop,funlen,gocognit // Ok.
-func Process(args *szargs.Args) (string, error) {
+//nolint:cyclop,funlen // Ok.
+func Process(ctx context.Context, args *szargs.Args) (string, error) {
 	var (
 		cfg            *settings.Config
 		dryRunMsg      string
@@ -130,21 +215,20 @@ func Process(args *szargs.Args) (string, error) {
 		daemon         bool
 		runAtMin       int
 		monitor        bool
+		showProgress   bool
 		purgedCount    int
 		purgedMsg      string
 		totalPurged    int
 		totalPurgedMsg string
-		hasLatest      bool
-		linkDest       string
-		newDir         string
+		result         taken
+		warningMsg     string
 		fsStat         *fstat.StatFS
+		attempted      bool
 		err            error
 	)
 
-	cfg, dryRunMsg, trimAfter, daemon, runAtMin, monitor, err = parseArgs(
-		args,
-		time.Now(),
-	)
+	cfg, dryRunMsg, trimAfter, daemon, runAtMin, monitor, showProgress, err =
+		parseArgs(args, time.Now())
 
 	if err == nil {
 		fsStat, err = fstat.New(cfg.Target.GetPath())
@@ -154,33 +238,31 @@ func Process(args *szargs.Args) (string, error) {
 	targetRunTime := time.Now()
 
 	for (runOnce || daemon) && err == nil {
-		wait.Until("Next Backup", monitor, targetRunTime)
+		err = wait.Until(ctx, "Next Backup", monitor, targetRunTime)
 
 		runOnce = false
-
-		newDir, err = cfg.Target.Create(time.Now(), initialBackupDirPerm)
+		attempted = err == nil
+		purgedCount = 0
 
 		if err == nil {
-			hasLatest, err = cfg.Target.HasLatest()
-			if err == nil && hasLatest {
-				linkDest = cfg.Target.Latest()
+			result, err = take(ctx, cfg, time.Now(), runOptions{
+				dryRun:       dryRunMsg != "",
+				showProgress: showProgress,
+				trimFirst:    trimAfter,
+			})
+
+			totalPurged += result.prePurged
+			if trimAfter && dryRunMsg == "" {
+				totalPurgedMsg = " (Total Purged: " +
+					out.Int(int64(totalPurged)) + ")"
 			}
 		}
 
-		if err == nil {
-			err = run(dryRunMsg != "", linkDest, newDir, cfg)
-		}
-
-		if err == nil && dryRunMsg == "" {
-			err = os.Chmod(newDir, cfg.Permission)
-		}
-
-		if err == nil && dryRunMsg == "" {
-			err = cfg.Target.SetLatest(newDir)
-		}
-
-		if err == nil && dryRunMsg != "" {
-			err = os.RemoveAll(newDir)
+		warningMsg = ""
+		if err == nil && result.accepted != nil {
+			warningMsg = fmt.Sprintf(" (Warning %d: %d items)",
+				rsync.ExitCode(result.accepted), result.record.WarningCount,
+			)
 		}
 
 		if err == nil && trimAfter {
@@ -190,19 +272,27 @@ func Process(args *szargs.Args) (string, error) {
 				err = nil
 			}
 
-			purgedMsg = " (Purged: " + out.Int(int64(purgedCount)) + ")"
+			purgedMsg = " (Purged: " +
+				out.Int(int64(result.prePurged+purgedCount)) + ")"
 			totalPurged += purgedCount
 			totalPurgedMsg = " (Total Purged: " +
 				out.Int(int64(totalPurged)) + ")"
 		}
 
-		//nolint:forbidigo // Ok.
-		if err == nil {
-			fmt.Printf("snapshot successful%s%s\nSyncing...\n",
-				purgedMsg,
-				dryRunMsg,
+		// Failed snapshots are included as the metrics count them.
+		if attempted && dryRunMsg == "" {
+			metrics.Update(
+				cfg, metrics.OperationSnapshot, result.prePurged+purgedCount,
 			)
-			fmt.Println(fsStat.Delta())
+		}
+
+		if err == nil && out.Structured() {
+			err = showResult(cfg, result, purgedCount, dryRunMsg != "", fsStat)
+		} else if err == nil {
+			showReport(result, warningMsg, purgedMsg, dryRunMsg, fsStat)
+		}
+
+		if err == nil {
 			fsStat, err = fstat.New(cfg.Target.GetPath())
 		}
 
@@ -215,3 +305,66 @@ func Process(args *szargs.Args) (string, error) {
 
 	return "", fmt.Errorf("%w%s: %w", ErrSnapshotError, totalPurgedMsg, err)
 }
+
+// showReport writes the human readable report of a snapshot.
+//
+//nolint:forbidigo // Ok.
+func showReport(
+	result taken,
+	warningMsg, purgedMsg, dryRunMsg string,
+	fsStat *fstat.StatFS,
+) {
+	fmt.Printf("snapshot successful%s%s%s\nSyncing...\n",
+		warningMsg,
+		purgedMsg,
+		dryRunMsg,
+	)
+	fmt.Println(fsStat.Delta())
+
+	if dryRunMsg == "" {
+		fmt.Println(fsStat.EstimateStatus(
+			result.estimate.NeededBytes(),
+			result.estimate.NeededINodes(),
+		))
+	}
+
+	if result.record.Stats != nil {
+		fmt.Println(result.record.Stats.Report())
+	}
+}
+
+// showResult writes the structured result of a snapshot.
+func showResult(
+	cfg *settings.Config,
+	result taken,
+	purgedCount int,
+	dryRun bool,
+	fsStat *fstat.StatFS,
+) error {
+	structured := Result{
+		Source:       cfg.Source,
+		Target:       cfg.Target.GetPath(),
+		Snapshot:     result.dir,
+		DryRun:       dryRun,
+		ExitCode:     rsync.ExitCode(result.accepted),
+		Warnings:     result.record.Warnings,
+		WarningCount: result.record.WarningCount,
+		Purged:       result.prePurged + purgedCount,
+		Stats:        result.record.Stats,
+		FileSystem:   fsStat.Change(),
+	}
+
+	if !dryRun {
+		structured.Estimate = &Estimate{
+			Bytes:  result.estimate.NeededBytes(),
+			INodes: result.estimate.NeededINodes(),
+		}
+	}
+
+	report, err := out.Result(structured)
+	if err == nil {
+		fmt.Print(report) //nolint:forbidigo // Ok.
+	}
+
+	return err //nolint:wrapcheck // Ok.
+}
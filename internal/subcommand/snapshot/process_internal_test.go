@@ -34,7 +34,7 @@ func TestSnapshotProcess_ParseArgDaemonAt(t *testing.T) {
 
 	startTime := time.Date(2026, time.May, 15, 10, 22, 0, 0, time.Local)
 
-	_, _, _, daemon, runAtMin, monitor, err := parseArgs(
+	_, _, _, daemon, runAtMin, monitor, _, err := parseArgs(
 		szargs.New(
 			"programDesc",
 			[]string{
@@ -55,7 +55,7 @@ func TestSnapshotProcess_ParseArgDaemonAt(t *testing.T) {
 		),
 	)
 
-	_, _, _, daemon, runAtMin, monitor, err = parseArgs(
+	_, _, _, daemon, runAtMin, monitor, _, err = parseArgs(
 		szargs.New(
 			"programDesc",
 			[]string{
@@ -76,7 +76,7 @@ func TestSnapshotProcess_ParseArgDaemonAt(t *testing.T) {
 		),
 	)
 
-	_, _, _, daemon, runAtMin, monitor, err = parseArgs(
+	_, _, _, daemon, runAtMin, monitor, _, err = parseArgs(
 		szargs.New(
 			"programDesc",
 			[]string{
@@ -98,7 +98,7 @@ func TestSnapshotProcess_ParseArgDaemonAt(t *testing.T) {
 		),
 	)
 
-	_, _, _, daemon, runAtMin, monitor, err = parseArgs(
+	_, _, _, daemon, runAtMin, monitor, _, err = parseArgs(
 		szargs.New(
 			"programDesc",
 			[]string{
@@ -119,7 +119,7 @@ func TestSnapshotProcess_ParseArgDaemonAt(t *testing.T) {
 		),
 	)
 
-	_, _, _, daemon, runAtMin, monitor, err = parseArgs(
+	_, _, _, daemon, runAtMin, monitor, _, err = parseArgs(
 		szargs.New(
 			"programDesc",
 			[]string{
@@ -140,7 +140,7 @@ func TestSnapshotProcess_ParseArgDaemonAt(t *testing.T) {
 		),
 	)
 
-	_, _, _, daemon, runAtMin, monitor, err = parseArgs(
+	_, _, _, daemon, runAtMin, monitor, _, err = parseArgs(
 		szargs.New(
 			"programDesc",
 			[]string{
@@ -165,3 +165,28 @@ func TestSnapshotProcess_ParseArgDaemonAt(t *testing.T) {
 		),
 	)
 }
+
+//nolint:dogsled // Ok.
+func TestSnapshotProcess_ParseArgProgress(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	_, _, _, _, _, _, progress, err := parseArgs(
+		szargs.New(
+			"programDesc",
+			[]string{
+				"programName", "--progress", "MISSING_CONFIG_FILE",
+			}),
+		time.Now(),
+	)
+
+	chk.True(progress)
+	chk.Err(
+		err,
+		chk.ErrChain(
+			settings.ErrLoad,
+			"open MISSING_CONFIG_FILE",
+			"no such file or directory",
+		),
+	)
+}
@@ -19,6 +19,7 @@
 package snapshot_test
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -47,6 +48,17 @@ const (
 		"                    # (     #%)\n" +
 		"       Delta:                    # (     #%)" +
 		"                    # (     #%)"
+	summaryEstimate = "" +
+		"   Estimated:                    # (     #%)" +
+		"                    # (     #%)"
+	summaryStats = "" +
+		"       Files:                    #\n" +
+		" Transferred:                    #\n" +
+		"     Literal:                    #\n" +
+		"     Matched:                    #\n" +
+		"        Sent:                    #\n" +
+		"     Speedup:                 #. #\n" +
+		"     Elapsed:                 #.#s"
 )
 
 const basicOptions = "" +
@@ -62,6 +74,25 @@ const basicOptions = "" +
 	" " + "--exclude=go/pkg" +
 	""
 
+// estimateOptions are the basicOptions without any output related flags.
+const estimateOptions = "" +
+	" " + "--archive" +
+	" " + "--human-readable" +
+	" " + "--acls" +
+	" " + "--xattrs" +
+	" " + "--atimes" +
+	" " + "--hard-links" +
+	" " + "--fsync" +
+	" " + "--exclude=.cache" +
+	" " + "--exclude=go/pkg" +
+	""
+
+// statsFlags are added to every rsync run to capture its statistics.
+const statsFlags = "" +
+	" " + rsync.FlgInfoStats +
+	" " + rsync.FlgNoHumanReadable +
+	""
+
 //nolint:goCheckNoGlobals // Ok.
 var rsyncCmd string
 
@@ -158,7 +189,7 @@ func TestSnapshotProcess_MissingArgs(t *testing.T) {
 	defer chk.Release()
 
 	args := szargs.New("", []string{"prg"})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.Err(
 		err,
 		""+
@@ -179,7 +210,7 @@ func TestSnapshotProcess_NoFiles(t *testing.T) {
 	trg := chk.CreateTmpSubDir("target")
 
 	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
@@ -187,14 +218,24 @@ func TestSnapshotProcess_NoFiles(t *testing.T) {
 	chk.Log()
 
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+"--delete"+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName),
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
 	)
 }
 
@@ -211,7 +252,7 @@ func TestSnapshotProcess_DryRun(t *testing.T) {
 		"",
 		[]string{"prg", "--dry-run", "-t", trg, cfgFile},
 	)
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
@@ -222,11 +263,13 @@ func TestSnapshotProcess_DryRun(t *testing.T) {
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
 			" "+rsync.FlgDryRun+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName),
 		"snapshot successful (DRY RUN)",
 		"Syncing...",
 		summaryUsage,
+		summaryStats,
 	)
 }
 
@@ -240,36 +283,58 @@ func TestSnapshotProcess_OneFile(t *testing.T) {
 	_ = chk.CreateTmpFileIn(source, []byte("file"))
 
 	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
 	args = szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err = snapshot.Process(args)
+	outText, err = snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
 	squashNumbers(chk)
 	chk.Log()
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName),
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			" "+rsync.FlgLinkDest+
+			filepath.Join(trg, target.LatestDirectoryLink)+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
 			" "+rsync.FlgLinkDest+
 			filepath.Join(trg, target.LatestDirectoryLink)+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName),
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
 	)
 }
 
@@ -283,38 +348,60 @@ func TestSnapshotProcess_TwoFiles(t *testing.T) {
 	_ = chk.CreateTmpFileIn(source, []byte("file1"))
 
 	args := szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
 	_ = chk.CreateTmpFileIn(source, []byte("file1"))
 
 	args = szargs.New("", []string{"prg", "-t", trg, cfgFile})
-	outText, err = snapshot.Process(args)
+	outText, err = snapshot.Process(context.Background(), args)
 	chk.NoErr(err)
 	chk.Str(outText, "")
 
 	squashNumbers(chk)
 	chk.Log()
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName),
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			" "+rsync.FlgLinkDest+
+			filepath.Join(trg, target.LatestDirectoryLink)+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
 			" "+rsync.FlgLinkDest+
 			filepath.Join(trg, target.LatestDirectoryLink)+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName),
 		"snapshot successful",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
 	)
 }
 
@@ -329,7 +416,7 @@ func TestSnapshotProcess_Trim(t *testing.T) {
 		"",
 		[]string{"prg", "--trim", "-t", trg, cfgFile},
 	)
-	outText, err := snapshot.Process(args)
+	outText, err := snapshot.Process(context.Background(), args)
 
 	chk.NoErr(err)
 	// chk.Err(
@@ -345,13 +432,23 @@ func TestSnapshotProcess_Trim(t *testing.T) {
 	squashNumbers(chk)
 	chk.Log()
 	chk.Stdout(
+		"Running command: "+
+			rsyncCmd+estimateOptions+
+			" "+rsync.FlgDelete+
+			" "+rsync.FlgDryRun+
+			statsFlags+
+			" "+source+
+			" "+filepath.Join(trg, squashFName),
 		"Running command: "+
 			rsyncCmd+basicOptions+
 			" "+rsync.FlgDelete+
+			statsFlags+
 			" "+source+
 			" "+filepath.Join(trg, squashFName),
 		"snapshot successful (Purged: 0)",
 		"Syncing...",
 		summaryUsage,
+		summaryEstimate,
+		summaryStats,
 	)
 }
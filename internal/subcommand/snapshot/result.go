@@ -0,0 +1,45 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/rsync"
+)
+
+// Estimate is the space a snapshot was estimated to require before it ran.
+type Estimate struct {
+	Bytes  uint64 `json:"bytes"`
+	INodes uint64 `json:"iNodes"`
+}
+
+// Result is the structured outcome of a single snapshot.
+type Result struct {
+	Source       string          `json:"source"`
+	Target       string          `json:"target"`
+	Snapshot     string          `json:"snapshot"`
+	DryRun       bool            `json:"dryRun"`
+	ExitCode     int             `json:"exitCode"`
+	Warnings     []rsync.Warning `json:"warnings,omitempty"`
+	WarningCount int             `json:"warningCount,omitempty"`
+	Purged       int             `json:"purged"`
+	Estimate     *Estimate       `json:"estimate,omitempty"`
+	Stats        *rsync.Stats    `json:"stats,omitempty"`
+	FileSystem   fstat.Change    `json:"fileSystem"`
+}
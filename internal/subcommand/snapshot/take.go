@@ -0,0 +1,153 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+)
+
+// runOptions control a single snapshot.
+type runOptions struct {
+	dryRun       bool
+	showProgress bool
+	trimFirst    bool
+	tag          string
+}
+
+// taken describes a single snapshot.
+type taken struct {
+	dir       string
+	estimate  rsync.Stats
+	record    journal.Record
+	accepted  error
+	prePurged int
+}
+
+// take creates a single snapshot of the source in the target hard linking
+// unchanged files to the latest snapshot (if any).  A real snapshot is
+// preceded by a preflight space check and followed by updating latest (and
+// the tag link if tagged) and recording the outcome in the journal.  A
+// failed snapshot is marked as failed.  A dry run's directory is removed.
+//
+//nolint:cyclop,funlen // Ok.
+func take(
+	ctx context.Context,
+	cfg *settings.Config,
+	runTime time.Time,
+	opts runOptions,
+) (taken, error) {
+	var (
+		result    taken
+		hasLatest bool
+		linkDest  string
+		runCtx    context.Context //nolint:containedctx // Ok.
+		cancel    context.CancelFunc
+		start     = time.Now()
+		err       error
+	)
+
+	hasLatest, err = cfg.Target.HasLatest()
+
+	if err == nil && hasLatest {
+		linkDest = cfg.Target.Latest()
+	}
+
+	runCtx, cancel = cfg.RunContext(ctx)
+	defer cancel()
+
+	if err == nil && !opts.dryRun {
+		result.estimate, result.prePurged, err = preflight(
+			runCtx,
+			cfg,
+			linkDest,
+			cfg.Target.SnapshotDir(runTime),
+			opts.trimFirst,
+		)
+
+		// Abandoning the snapshot for lack of space is a failed snapshot.
+		if errors.Is(err, ErrInsufficientSpace) {
+			err = logAborted(cfg, runTime, start, opts.tag, err)
+		}
+	}
+
+	if err == nil {
+		result.dir, err = cfg.Target.Create(runTime, initialBackupDirPerm)
+	}
+
+	if err == nil {
+		result.record, err = run(
+			runCtx,
+			opts.dryRun, opts.showProgress, linkDest, result.dir, cfg,
+		)
+		result.record.Tag = opts.tag
+		result.record.Status, result.accepted, err = outcome(cfg, err)
+
+		if err != nil && !opts.dryRun {
+			err = logRun(
+				cfg, result.record, markFailed(cfg, result.dir, err),
+			)
+		}
+	}
+
+	if err == nil && !opts.dryRun {
+		err = os.Chmod(result.dir, cfg.Permission)
+	}
+
+	if err == nil && !opts.dryRun {
+		err = cfg.Target.SetLatest(result.dir)
+	}
+
+	if err == nil && !opts.dryRun && opts.tag != "" {
+		err = cfg.Target.SetTag(opts.tag, result.dir)
+	}
+
+	if err == nil && !opts.dryRun {
+		err = logRun(cfg, result.record, result.accepted)
+	}
+
+	if err == nil && opts.dryRun {
+		err = os.RemoveAll(result.dir)
+	}
+
+	return result, err
+}
+
+// Tagged takes a snapshot of the source through the normal snapshot path
+// (see the snapshot subcommand).  The snapshot is tagged in the journal and a
+// link named by the tag is pointed at it.  The snapshot's directory is
+// returned.
+func Tagged(
+	ctx context.Context, cfg *settings.Config, tag string,
+) (string, error) {
+	result, err := take(ctx, cfg, time.Now(), runOptions{tag: tag})
+
+	if err == nil {
+		return result.dir, nil
+	}
+
+	return "", fmt.Errorf("%w: %s: %w", ErrSnapshotError, tag, err)
+}
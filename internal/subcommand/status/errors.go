@@ -25,4 +25,6 @@ var (
 	ErrNoBackups    = errors.New("no backups found")
 	ErrReportFailed = errors.New("report generation failed")
 	ErrStatusError  = errors.New("status error")
+	ErrInvalidSort  = errors.New("invalid sort")
+	ErrIfDeleted    = errors.New("invalid if deleted snapshot")
 )
@@ -20,9 +20,50 @@ package status
 
 // HelpText describes the overall operation of the utility.
 const HelpText = `{stat | status} ` +
+	`[--sort {date | exclusive}] ` +
+	`[--if-deleted snapshot[,snapshot...]] ` +
+	`[--forecast] ` +
+	`[--retention] ` +
 	`[-t target] config.szb
 
-Reports the status on the specified backup set.
+Reports the status on the specified backup set.  Each snapshot is listed
+(newest first) with its total bytes followed by the bytes it does not share
+with the next older snapshot (in parentheses) and the bytes that would be
+freed if it were deleted (in brackets).  The freed bytes only count files
+whose every hard link is inside that snapshot.  The target is measured in a
+single pass counting each file once no matter how many snapshots hard link
+it.  The number of directories read concurrently is set by duWorkers in the
+backup config file.
+
+   [--sort {date | exclusive}]
+      Orders the snapshots newest first (date) or by the bytes freed if they
+      were deleted (exclusive) largest first.  Defaults to date.
+
+   [--if-deleted snapshot[,snapshot...]]
+      Also reports the combined bytes freed if all of the listed snapshots
+      were deleted.  Files hard linked only by the listed snapshots are
+      freed even if they are shared between them.  Each snapshot may be any
+      selector accepted by restore's -s option (IE: latest~2 or -1w).
+
+   [--forecast]
+      Also reports the rate the target is growing, fitted to the bytes each
+      snapshot added (its bytes not shared with the next older snapshot)
+      over the times they were taken, and the days until the target's free
+      bytes are used up at that rate.  The size the retention policy settles
+      at is projected as the latest snapshot plus keepDaily of growth.  As
+      weekly snapshots are kept indefinitely the target continues to grow
+      by about a week of growth each week.  The projection is an upper
+      bound as files changed more than once between the retained snapshots
+      are freed by trim.
+
+   [--retention]
+      Also reports the retention decision trim would make for each snapshot
+      (newest first): whether it is kept or purged and the rule responsible.
+      A snapshot is kept as the latest, within keepHourly, as the newest of
+      its day within keepDaily, as the newest of its ISO week or if pinned
+      by a tag.  Each kept snapshot's projected expiry assumes snapshots
+      continue to be taken hourly.  Snapshots kept for their ISO week or
+      pinned never expire.
 
    [-t target]
       Specifies the backup set to create the new snapshot in.  It is optional
@@ -19,27 +19,72 @@
 package status
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal/du"
+	"github.com/dancsecs/szbck/internal/forecast"
+	"github.com/dancsecs/szbck/internal/fstat"
 	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/trim"
 	"github.com/dancsecs/szbck/internal/target"
 	"github.com/dancsecs/szlog"
 )
 
-func parseArguments(args *szargs.Args) (*settings.Config, error) {
+// Sort orders for the snapshot rows.
+const (
+	sortDate      = "date"
+	sortExclusive = "exclusive"
+)
+
+// options holds the command line options controlling the report.
+type options struct {
+	sort      string
+	ifDeleted []string
+	forecast  bool
+	retention bool
+}
+
+func parseArguments(args *szargs.Args) (*settings.Config, options, error) {
 	var (
-		cfg *settings.Config
-		err error
+		cfg       *settings.Config
+		opts      options
+		ifDeleted string
+		err       error
 	)
 
-	cfg, err = settings.LoadFromArgs(args)
+	opts.sort, _ = args.ValueString("--sort", "")
+	ifDeleted, _ = args.ValueString("--if-deleted", "")
+	opts.forecast = args.Is("--forecast", "")
+	opts.retention = args.Is("--retention", "")
+
+	if opts.sort == "" {
+		opts.sort = sortDate
+	}
+
+	if !args.HasErr() && opts.sort != sortDate && opts.sort != sortExclusive {
+		args.PushErr(fmt.Errorf("%w: '%s'", ErrInvalidSort, opts.sort))
+	}
 
-	return cfg, err //nolint:wrapcheck // Ok.
+	if ifDeleted != "" {
+		opts.ifDeleted = strings.Split(ifDeleted, ",")
+	}
+
+	err = args.Err()
+
+	if err == nil {
+		cfg, err = settings.LoadFromArgs(args)
+	}
+
+	return cfg, opts, err //nolint:wrapcheck // Ok.
 }
 
 func loadBackupDirs(trg string) ([]string, error) {
@@ -63,72 +108,341 @@ func loadBackupDirs(trg string) ([]string, error) {
 	return nil, err
 }
 
-func buildReport(trg string) (string, error) {
-	const outFmt = "%s: %22s (%22s)\n"
+// selectDeleted resolves the snapshot selectors returning the index of each
+// snapshot in the sorted directories.
+func selectDeleted(
+	trg *target.Path, dirs []string, specs []string, now time.Time,
+) ([]string, []int, error) {
+	var (
+		names   = make([]string, 0, len(specs))
+		indexes = make([]int, 0, len(specs))
+		name    string
+		err     error
+	)
+
+	for i := 0; i < len(specs) && err == nil; i++ {
+		name, err = trg.Select(strings.TrimSpace(specs[i]), now)
+		if err == nil {
+			names = append(names, name)
+			indexes = append(
+				indexes,
+				slices.Index(dirs, filepath.Join(trg.GetPath(), name)),
+			)
+		}
+	}
+
+	if err == nil {
+		return names, indexes, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: %w", ErrIfDeleted, err)
+}
+
+// Snapshot is the disk usage of a single snapshot.
+type Snapshot struct {
+	Name           string `json:"name"`
+	TotalBytes     int64  `json:"totalBytes"`
+	ChangedBytes   int64  `json:"changedBytes"`
+	ExclusiveBytes int64  `json:"exclusiveBytes"`
+}
+
+// Report is the status of a backup set.
+type Report struct {
+	Snapshots        []Snapshot `json:"snapshots"`
+	BackupSets       int        `json:"backupSets"`
+	TotalBytes       int64      `json:"totalBytes"`
+	IfDeleted        []string   `json:"ifDeleted,omitempty"`
+	ReclaimableBytes int64      `json:"reclaimableBytes,omitempty"`
+	// Forecast is only reported if requested and there is sufficient
+	// history otherwise ForecastError explains why not.
+	Forecast      *forecast.Forecast `json:"forecast,omitempty"`
+	ForecastError string             `json:"forecastError,omitempty"`
+	// Retention is the trim decision for each snapshot (newest first) if
+	// requested.
+	Retention []trim.Decision `json:"retention,omitempty"`
+}
 
+// Header implements out.Tabular.
+func (r Report) Header() []string {
+	header := []string{"name", "totalBytes", "changedBytes", "exclusiveBytes"}
+
+	if r.Retention != nil {
+		header = append(header, "action", "reason", "expires")
+	}
+
+	return header
+}
+
+// Rows implements out.Tabular.
+func (r Report) Rows() [][]string {
 	var (
-		dirs        []string
-		prevDirSize int64
-		hardSize    int64
-		totalSize   int64
-		dirSize     int64
-		dirName     string
-		err         error
+		rows      = make([][]string, 0, len(r.Snapshots))
+		decisions = make(map[string]trim.Decision, len(r.Retention))
+		row       []string
 	)
 
-	dirs, err = loadBackupDirs(trg)
+	for _, decision := range r.Retention {
+		decisions[decision.Snapshot] = decision
+	}
+
+	for _, snapshot := range r.Snapshots {
+		row = []string{
+			snapshot.Name,
+			strconv.FormatInt(snapshot.TotalBytes, 10),
+			strconv.FormatInt(snapshot.ChangedBytes, 10),
+			strconv.FormatInt(snapshot.ExclusiveBytes, 10),
+		}
+
+		if r.Retention != nil {
+			decision := decisions[snapshot.Name]
+			expires := ""
+
+			if decision.Expires != nil {
+				expires = decision.Expires.Format(time.RFC3339)
+			}
 
-	if err == nil && len(dirs) > 0 {
-		dirName = filepath.Base(dirs[len(dirs)-1])
-		dirSize, err = du.Total(dirs[len(dirs)-1])
+			row = append(row, decision.Action, decision.Reason, expires)
+		}
+
+		rows = append(rows, row)
 	}
 
-	for i := len(dirs) - 1; i > 0 && err == nil; i-- {
-		prevDirSize, hardSize, err = du.Totals(dirs[i-1], dirs[i])
-		if err == nil {
-			szlog.Say0f(outFmt, dirName, out.Int(dirSize), out.Int(hardSize))
+	return rows
+}
+
+// show writes each snapshot's row returning the report's summary.
+func (r Report) show() string {
+	const outFmt = "%s: %22s (%22s) [%22s]\n"
+
+	for _, snapshot := range r.Snapshots {
+		szlog.Say0f(outFmt,
+			snapshot.Name,
+			out.Int(snapshot.TotalBytes),
+			out.Int(snapshot.ChangedBytes),
+			out.Int(snapshot.ExclusiveBytes),
+		)
+	}
+
+	summary := fmt.Sprintf(
+		"Backup Sets: %s\n"+
+			"Total Bytes: %s\n",
+		out.Int(int64(r.BackupSets)),
+		out.Int(r.TotalBytes),
+	)
+
+	if len(r.IfDeleted) > 0 {
+		summary += fmt.Sprintf(
+			"If Deleted: %s\n"+
+				"Reclaimable Bytes: %s\n",
+			strings.Join(r.IfDeleted, ", "),
+			out.Int(r.ReclaimableBytes),
+		)
+	}
+
+	if r.ForecastError != "" {
+		summary += "Forecast: " + r.ForecastError + "\n"
+	}
+
+	if r.Forecast != nil {
+		summary += r.showForecast()
+	}
+
+	if r.Retention != nil {
+		summary += r.showRetention()
+	}
 
-			dirSize = prevDirSize
-			dirName = filepath.Base(dirs[i-1])
+	return summary
+}
+
+// showRetention returns the trim decision made for each snapshot.
+func (r Report) showRetention() string {
+	retention := "Retention:\n"
+
+	for _, decision := range r.Retention {
+		retention += fmt.Sprintf("%s: %s (%s)\n",
+			decision.Snapshot, decision.Action, decision.Explanation(),
+		)
+	}
+
+	return retention
+}
+
+// showForecast returns the forecast's summary.
+func (r Report) showForecast() string {
+	full := "never (not growing)"
+	if r.Forecast.DaysUntilFull != nil {
+		full = "~" + out.Int(*r.Forecast.DaysUntilFull) +
+			" days at current rate"
+	}
+
+	return fmt.Sprintf(
+		"Growth Rate: %s bytes/day\n"+
+			"Free Bytes: %s\n"+
+			"Full In: %s\n"+
+			"Retention Settles At: %s bytes (then %s bytes/week)\n",
+		out.Int(r.Forecast.BytesPerDay),
+		out.Uint(r.Forecast.FreeBytes),
+		full,
+		out.Int(r.Forecast.SettleBytes),
+		out.Int(r.Forecast.WeeklyBytes),
+	)
+}
+
+// growth returns the bytes added by the snapshots (oldest first) up to the
+// time each was taken.  The oldest snapshot's own bytes are not counted as
+// they were not added by a snapshot in the measured history.
+func growth(snapshots []du.SnapshotUsage) ([]forecast.Point, error) {
+	var (
+		points = make([]forecast.Point, 0, len(snapshots))
+		total  int64
+		taken  time.Time
+		err    error
+	)
+
+	for i := 0; i < len(snapshots) && err == nil; i++ {
+		taken, err = target.SnapshotTime(snapshots[i].Dir)
+
+		if i > 0 {
+			total += snapshots[i].Changed
+		}
+
+		points = append(points, forecast.Point{Time: taken, Bytes: total})
+	}
+
+	return points, err //nolint:wrapcheck // Ok.
+}
+
+// addForecast adds the growth forecast to the report.  Insufficient history
+// is reported rather than failing the status.
+func addForecast(
+	report *Report, cfg *settings.Config, usage du.Usage,
+) error {
+	var (
+		points      []forecast.Point
+		fsStat      *fstat.StatFS
+		result      forecast.Forecast
+		latest      = usage.Snapshots[len(usage.Snapshots)-1].Total
+		forecastErr error
+		err         error
+	)
+
+	points, err = growth(usage.Snapshots)
+
+	if err == nil {
+		fsStat, err = fstat.New(cfg.Target.GetPath())
+	}
+
+	if err == nil {
+		result, forecastErr = forecast.New(
+			points, fsStat.FreeBytes(), latest, cfg.KeepDaily,
+		)
+		if forecastErr == nil {
+			report.Forecast = &result
+		} else {
+			report.ForecastError = forecastErr.Error()
 		}
 	}
 
-	if err == nil && len(dirs) > 0 {
-		szlog.Say0f(outFmt, dirName, out.Int(dirSize), out.Int(dirSize))
+	return err //nolint:wrapcheck // Ok.
+}
+
+// buildReport measures the target in a single walk reporting each snapshot
+// (newest first unless sorted by exclusive size) with its total bytes, the
+// bytes not hard linked to the next older snapshot and the bytes freed if
+// it were deleted.
+func buildReport(
+	ctx context.Context, cfg *settings.Config, opts options,
+) (Report, error) {
+	var (
+		trg     = cfg.Target
+		dirs    []string
+		usage   du.Usage
+		rows    []du.SnapshotUsage
+		report  Report
+		indexes []int
+		err     error
+	)
+
+	dirs, err = loadBackupDirs(trg.GetPath())
+
+	if err == nil && len(opts.ifDeleted) > 0 {
+		report.IfDeleted, indexes, err = selectDeleted(
+			trg, dirs, opts.ifDeleted, time.Now(),
+		)
+	}
+
+	if err == nil {
+		usage, err = du.Measure(ctx, trg.GetPath(), dirs, cfg.DuWorkers)
 	}
 
 	if err == nil {
-		totalSize, err = du.Total(trg)
+		rows = slices.Clone(usage.Snapshots)
+		slices.Reverse(rows)
+
+		if opts.sort == sortExclusive {
+			slices.SortStableFunc(rows, func(a, b du.SnapshotUsage) int {
+				return cmp.Compare(b.Exclusive, a.Exclusive)
+			})
+		}
+
+		report.Snapshots = make([]Snapshot, 0, len(rows))
+		for _, row := range rows {
+			report.Snapshots = append(report.Snapshots, Snapshot{
+				Name:           filepath.Base(row.Dir),
+				TotalBytes:     row.Total,
+				ChangedBytes:   row.Changed,
+				ExclusiveBytes: row.Exclusive,
+			})
+		}
+
+		report.BackupSets = len(dirs)
+		report.TotalBytes = usage.Total
+
+		if len(indexes) > 0 {
+			report.ReclaimableBytes = usage.Reclaimable(indexes...)
+		}
+	}
+
+	if err == nil && opts.forecast {
+		err = addForecast(&report, cfg, usage)
+	}
+
+	if err == nil && opts.retention {
+		report.Retention, err = trim.Explain(cfg, time.Now())
+		slices.Reverse(report.Retention)
 	}
 
 	if err == nil {
-		return fmt.Sprintf(
-			"Backup Sets: %s\n"+
-				"Total Bytes: %s\n",
-			out.Int(int64(len(dirs))),
-			out.Int(totalSize),
-		), nil
+		return report, nil
 	}
 
-	return "", fmt.Errorf("%w: %w", ErrReportFailed, err)
+	return Report{}, fmt.Errorf("%w: %w", ErrReportFailed, err)
 }
 
 // Process parses the remaining arguments deleting previous backups.
-func Process(args *szargs.Args) (string, error) {
+func Process(ctx context.Context, args *szargs.Args) (string, error) {
 	var (
-		cfg    *settings.Config
-		report string
-		err    error
+		cfg     *settings.Config
+		opts    options
+		report  Report
+		outText string
+		err     error
 	)
 
-	cfg, err = parseArguments(args)
+	cfg, opts, err = parseArguments(args)
 
 	if err == nil {
-		report, err = buildReport(cfg.Target.GetPath())
+		report, err = buildReport(ctx, cfg, opts)
+	}
+
+	if err == nil && out.Structured() {
+		outText, err = out.Result(report)
+	} else if err == nil {
+		outText = "status successful\n\n" + report.show()
 	}
 
 	if err == nil {
-		return "status successful\n\n" + report, nil
+		return outText, nil
 	}
 
 	return "", fmt.Errorf("%w: %w", ErrStatusError, err)
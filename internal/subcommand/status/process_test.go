@@ -19,17 +19,24 @@
 package status_test
 
 import (
+	"context"
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/du"
+	"github.com/dancsecs/szbck/internal/forecast"
+	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/settings"
 	"github.com/dancsecs/szbck/internal/subcommand/status"
+	"github.com/dancsecs/szbck/internal/subcommand/trim"
 	"github.com/dancsecs/szbck/internal/target"
 	"github.com/dancsecs/sztest"
 	"github.com/dancsecs/sztestlog"
@@ -87,6 +94,21 @@ func setupBackupConfig(chk *sztest.Chk) string {
 	return cfgFile
 }
 
+// duTotal returns the bytes du reports for the directory tree.
+func duTotal(chk *sztest.Chk, dir string) int64 {
+	chk.T().Helper()
+
+	report, err := du.Run(
+		context.Background(), []string{"-s", "-b", dir}, os.Stderr,
+	)
+	chk.NoErr(err)
+
+	total, err := strconv.ParseInt(strings.Split(report, "\t")[0], 10, 64)
+	chk.NoErr(err)
+
+	return total
+}
+
 func makeSnapshotDir(chk *sztest.Chk, dir string, delta int) string {
 	chk.T().Helper()
 
@@ -109,7 +131,7 @@ func TestStatus_Process_NoArgs(t *testing.T) {
 	defer chk.Release()
 
 	args := szargs.New("", []string{"prg"})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.Err(
 		err,
 		""+
@@ -129,7 +151,7 @@ func TestStatus_Process_InvalidConfigFileDir(t *testing.T) {
 	dir := chk.CreateTmpDir()
 
 	args := szargs.New("", []string{"prg", dir})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.Err(
 		err,
 		""+
@@ -150,7 +172,7 @@ func TestStatus_Process_BlankBackupDir(t *testing.T) {
 	cfgFile := setupBackupConfig(chk)
 
 	args := szargs.New("", []string{"prg", cfgFile})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.Err(
 		err,
 		""+
@@ -170,7 +192,7 @@ func TestStatus_Process_InvalidBackupDir(t *testing.T) {
 	cfgFile := setupBackupConfig(chk)
 
 	args := szargs.New("", []string{"prg", "-t", dir, cfgFile})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.Err(
 		err,
 		""+
@@ -205,7 +227,7 @@ func TestStatus_Process_InvalidBackupDirContent(t *testing.T) {
 	)
 
 	args := szargs.New("", []string{"prg", "-t", dir, cfgFile})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.Err(
 		err,
 		""+
@@ -231,7 +253,7 @@ func TestStatus_Process_EmptyBackupDir(t *testing.T) {
 	trgDir := chk.CreateTmpSubDir("target")
 
 	args := szargs.New("", []string{"prg", "-t", trgDir, cfgFile})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.Err(
 		err,
 		""+
@@ -255,7 +277,7 @@ func TestStatus_Process_OneEmptyBackupDir(t *testing.T) {
 	bkDir := makeSnapshotDir(chk, trgDir, 0)
 
 	args := szargs.New("", []string{"prg", "-t", trgDir, cfgFile})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.NoErr(err)
 
 	chk.AddSub(`\-?\d[\d\,]*`, "#")
@@ -273,7 +295,8 @@ func TestStatus_Process_OneEmptyBackupDir(t *testing.T) {
 
 	chk.Stdout(
 		filepath.Base(bkDir) +
-			":                      # (                     #)",
+			":                      # (                     #)" +
+			" [                     #]",
 	)
 }
 
@@ -291,7 +314,7 @@ func TestStatus_Process_TwoBackupDirsWithOneFileEach(t *testing.T) {
 	_ = chk.CreateTmpFileIn(bkDir2, []byte("This is a file in dir 2"))
 
 	args := szargs.New("", []string{"prg", "-t", trgDir, cfgFile})
-	outText, err := status.Process(args)
+	outText, err := status.Process(context.Background(), args)
 	chk.NoErr(err)
 
 	chk.AddSub(`\-?\d[\d\,]*`, "#")
@@ -308,8 +331,292 @@ func TestStatus_Process_TwoBackupDirsWithOneFileEach(t *testing.T) {
 
 	chk.Stdout(
 		filepath.Base(bkDir1)+
-			":                     # (                    #)",
+			":                     # (                    #)"+
+			" [                    #]",
 		filepath.Base(bkDir2)+
-			":                     # (                    #)",
+			":                     # (                    #)"+
+			" [                    #]",
+	)
+}
+
+func TestStatus_Process_InvalidSort(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile := setupBackupConfig(chk)
+
+	args := szargs.New("", []string{"prg", "--sort", "size", cfgFile})
+	outText, err := status.Process(context.Background(), args)
+	chk.Err(
+		err,
+		""+
+			status.ErrStatusError.Error()+
+			": "+
+			status.ErrInvalidSort.Error()+
+			": 'size'"+
+			"",
+	)
+	chk.Str(outText, "")
+}
+
+func TestStatus_Process_InvalidIfDeleted(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile := setupBackupConfig(chk)
+	trgDir := chk.CreateTmpSubDir("target")
+
+	_ = makeSnapshotDir(chk, trgDir, 0)
+
+	args := szargs.New("", []string{
+		"prg", "-t", trgDir, "--if-deleted", "latest,latest~1", cfgFile,
+	})
+	outText, err := status.Process(context.Background(), args)
+	chk.Err(
+		err,
+		""+
+			status.ErrStatusError.Error()+
+			": "+
+			status.ErrReportFailed.Error()+
+			": "+
+			status.ErrIfDeleted.Error()+
+			": "+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrNoSnapshot.Error()+
+			": 'latest~1': only 1 snapshots"+
+			"",
+	)
+	chk.Str(outText, "")
+}
+
+func TestStatus_Process_IfDeleted(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	const shared = "This file is shared by both snapshots"
+
+	cfgFile := setupBackupConfig(chk)
+	trgDir := chk.CreateTmpSubDir("target")
+
+	bkDir1 := makeSnapshotDir(chk, trgDir, 0)
+	bkDir2 := makeSnapshotDir(chk, trgDir, 30)
+
+	sharedFile := chk.CreateTmpFileIn(bkDir1, []byte(shared))
+	chk.NoErr(
+		os.Link(sharedFile, filepath.Join(bkDir2, filepath.Base(sharedFile))),
+	)
+	_ = chk.CreateTmpFileIn(bkDir2, []byte("Only in the newest snapshot"))
+
+	size1 := duTotal(chk, bkDir1)
+	size2 := duTotal(chk, bkDir2)
+
+	args := szargs.New("", []string{
+		"prg", "-t", trgDir,
+		"--sort", "exclusive",
+		"--if-deleted", "latest~1",
+		cfgFile,
+	})
+	outText, err := status.Process(context.Background(), args)
+	chk.NoErr(err)
+	chk.StrSlice(
+		strings.Split(outText, "\n")[4:],
+		[]string{
+			"If Deleted: " + filepath.Base(bkDir1),
+			"Reclaimable Bytes: " + out.Int(size1-int64(len(shared))),
+			"",
+		},
+	)
+
+	args = szargs.New("", []string{
+		"prg", "-t", trgDir, "--if-deleted", "latest, latest~1", cfgFile,
+	})
+	outText, err = status.Process(context.Background(), args)
+	chk.NoErr(err)
+	chk.StrSlice(
+		strings.Split(outText, "\n")[4:],
+		[]string{
+			"If Deleted: " + filepath.Base(bkDir2) + ", " +
+				filepath.Base(bkDir1),
+			"Reclaimable Bytes: " + out.Int(size1+size2-int64(len(shared))),
+			"",
+		},
+	)
+
+	// Sorted by exclusive bytes the newest snapshot holding the unshared
+	// file is listed first in both reports.
+	chk.AddSub(`\s+[\d,]+`, " #")
+	chk.Stdout(
+		filepath.Base(bkDir2)+": # ( #) [ #]",
+		filepath.Base(bkDir1)+": # ( #) [ #]",
+		filepath.Base(bkDir2)+": # ( #) [ #]",
+		filepath.Base(bkDir1)+": # ( #) [ #]",
+	)
+}
+
+func TestStatus_Process_Structured(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile := setupBackupConfig(chk)
+	trgDir := chk.CreateTmpSubDir("target")
+
+	bkDir1 := makeSnapshotDir(chk, trgDir, 0)
+	bkDir2 := makeSnapshotDir(chk, trgDir, 30)
+
+	size1 := duTotal(chk, bkDir1)
+	size2 := duTotal(chk, bkDir2)
+	total := duTotal(chk, trgDir)
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	args := szargs.New("", []string{
+		"prg", "-t", trgDir, "--if-deleted", "latest~1", cfgFile,
+	})
+	outText, err := status.Process(context.Background(), args)
+	chk.NoErr(err)
+
+	var report status.Report
+
+	chk.NoErr(json.Unmarshal([]byte(outText), &report))
+	chk.Int(report.BackupSets, 2)
+	chk.Int64(report.TotalBytes, total)
+	chk.StrSlice(report.IfDeleted, []string{filepath.Base(bkDir1)})
+	chk.Int64(report.ReclaimableBytes, size1)
+	chk.Int(len(report.Snapshots), 2)
+	chk.Str(report.Snapshots[0].Name, filepath.Base(bkDir2))
+	chk.Int64(report.Snapshots[0].TotalBytes, size2)
+	chk.Str(report.Snapshots[1].Name, filepath.Base(bkDir1))
+	chk.Int64(report.Snapshots[1].TotalBytes, size1)
+
+	chk.NoErr(out.SetFormat(out.FormatCSV))
+
+	args = szargs.New("", []string{"prg", "-t", trgDir, cfgFile})
+	outText, err = status.Process(context.Background(), args)
+	chk.NoErr(err)
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"name,totalBytes,changedBytes,exclusiveBytes",
+			filepath.Base(bkDir2) + "," +
+				strconv.FormatInt(size2, 10) + "," +
+				strconv.FormatInt(size2, 10) + "," +
+				strconv.FormatInt(size2, 10),
+			filepath.Base(bkDir1) + "," +
+				strconv.FormatInt(size1, 10) + "," +
+				strconv.FormatInt(size1, 10) + "," +
+				strconv.FormatInt(size1, 10),
+			"",
+		},
+	)
+
+	chk.Stdout()
+}
+
+func TestStatus_Process_Forecast(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile := setupBackupConfig(chk)
+	trgDir := chk.CreateTmpSubDir("target")
+
+	makeSnapshotDir(chk, trgDir, 0)
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	args := szargs.New("", []string{
+		"prg", "-t", trgDir, "--forecast", cfgFile,
+	})
+	outText, err := status.Process(context.Background(), args)
+	chk.NoErr(err)
+
+	var report status.Report
+
+	chk.NoErr(json.Unmarshal([]byte(outText), &report))
+	chk.Nil(report.Forecast)
+	chk.Str(
+		report.ForecastError,
+		forecast.ErrInsufficient.Error()+": 1 snapshots",
+	)
+
+	// Thirty minutes later.
+	bkDir2 := makeSnapshotDir(chk, trgDir, 30)
+
+	size2 := duTotal(chk, bkDir2)
+
+	args = szargs.New("", []string{
+		"prg", "-t", trgDir, "--forecast", cfgFile,
+	})
+	outText, err = status.Process(context.Background(), args)
+	chk.NoErr(err)
+
+	report = status.Report{}
+
+	chk.NoErr(json.Unmarshal([]byte(outText), &report))
+	chk.Str(report.ForecastError, "")
+	chk.NotNil(report.Forecast)
+	chk.Int64(report.Forecast.BytesPerDay, size2*48)
+	chk.NotNil(report.Forecast.DaysUntilFull)
+	chk.True(report.Forecast.SettleBytes > size2)
+	chk.Int64(report.Forecast.WeeklyBytes, size2*48*7)
+
+	chk.Stdout()
+}
+
+func TestStatus_Process_Retention(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile := setupBackupConfig(chk)
+	trgDir := chk.CreateTmpSubDir("target")
+
+	bkDir1 := makeSnapshotDir(chk, trgDir, 0)
+	bkDir2 := makeSnapshotDir(chk, trgDir, 30) // Thirty minutes later.
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	args := szargs.New("", []string{
+		"prg", "-t", trgDir, "--retention", cfgFile,
+	})
+	outText, err := status.Process(context.Background(), args)
+	chk.NoErr(err)
+
+	var report status.Report
+
+	chk.NoErr(json.Unmarshal([]byte(outText), &report))
+	chk.Int(len(report.Retention), 2)
+	chk.Str(report.Retention[0].Snapshot, filepath.Base(bkDir2))
+	chk.Str(report.Retention[0].Action, trim.ActionKeep)
+	chk.Str(report.Retention[0].Reason, trim.ReasonLatest)
+	chk.Nil(report.Retention[0].Expires)
+	chk.Str(report.Retention[1].Snapshot, filepath.Base(bkDir1))
+	chk.Str(report.Retention[1].Action, trim.ActionPurge)
+	chk.Str(report.Retention[1].Reason, trim.ReasonSameWeek)
+	chk.Nil(report.Retention[1].Expires)
+
+	chk.NoErr(out.SetFormat(out.FormatText))
+
+	args = szargs.New("", []string{
+		"prg", "-t", trgDir, "--retention", cfgFile,
+	})
+	outText, err = status.Process(context.Background(), args)
+	chk.NoErr(err)
+	chk.True(strings.HasSuffix(outText, ""+
+		"Retention:\n"+
+		filepath.Base(bkDir2)+": keep ("+trim.ReasonLatest+
+		", never expires)\n"+
+		filepath.Base(bkDir1)+": purge ("+trim.ReasonSameWeek+")\n",
+	))
+
+	chk.AddSub(`\s+\d[\d\,]*`, " #")
+	chk.Stdout(
+		filepath.Base(bkDir2)+": # ( #) [ #]",
+		filepath.Base(bkDir1)+": # ( #) [ #]",
 	)
 }
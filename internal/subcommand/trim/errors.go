@@ -27,4 +27,13 @@ var (
 	ErrOnlyLatest          = errors.New("only latest backup exists")
 	ErrPurgeFailed         = errors.New("purge failed")
 	ErrTrimError           = errors.New("trim error")
+	ErrPlanAndApply        = errors.New("--plan and --apply are exclusive")
+	ErrPlanWrite           = errors.New("could not write trim plan")
+	ErrPlanLoad            = errors.New("could not load trim plan")
+	ErrPlanInvalid         = errors.New("trim plan no longer valid")
+	ErrPlanTarget          = errors.New("plan is for a different target")
+	ErrPlanNotSnapshot     = errors.New("not a snapshot")
+	ErrPlanMissing         = errors.New("snapshot no longer exists")
+	ErrPlanLatest          = errors.New("snapshot is latest")
+	ErrPlanPinned          = errors.New("snapshot is pinned")
 )
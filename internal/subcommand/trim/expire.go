@@ -0,0 +1,134 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package trim
+
+import (
+	"slices"
+	"time"
+)
+
+// futureInterval is the time between the snapshots assumed to be taken after
+// the reference time when projecting expiry.  It matches the daemon's hourly
+// schedule.
+const futureInterval = time.Hour
+
+// futureSnapshots returns the snapshots assumed to be taken after the
+// reference time.  Only those in the reference time's ISO week are needed as
+// later snapshots never share a day or week with an existing snapshot.
+func futureSnapshots(now time.Time) []time.Time {
+	var (
+		future []time.Time
+		tme    = now.Add(futureInterval)
+	)
+
+	for sameWeek, _ := getProximity(now, tme); sameWeek; {
+		future = append(future, tme)
+		tme = tme.Add(futureInterval)
+		sameWeek, _ = getProximity(now, tme)
+	}
+
+	return future
+}
+
+// expiryCandidates returns the times after the reference time (sorted
+// ascending) that any snapshot leaves the hourly or daily retention.  These
+// are the only times a snapshot's retention can change.
+func expiryCandidates(
+	tms []time.Time, keepHourly, keepDaily time.Duration, now time.Time,
+) []time.Time {
+	candidates := make([]time.Time, 0, len(tms)*2) //nolint:mnd // Ok.
+
+	for _, tme := range tms {
+		for _, keep := range []time.Duration{keepHourly, keepDaily} {
+			if tme.Add(keep).After(now) {
+				candidates = append(candidates, tme.Add(keep))
+			}
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	return slices.CompactFunc(candidates, time.Time.Equal)
+}
+
+// projectExpiry returns the time each snapshot (oldest first) will be purged
+// by a future trim assuming snapshots continue to be taken hourly.  Snapshots
+// removed now and those never removed (kept for their ISO week or pinned)
+// have no expiry.
+func projectExpiry(
+	tms []time.Time,
+	pinned []bool,
+	keepHourly, keepDaily time.Duration,
+	now time.Time,
+) []*time.Time {
+	var (
+		expires = make([]*time.Time, len(tms))
+		removed = make([]bool, len(tms))
+		all     = append(slices.Clone(tms), futureSnapshots(now)...)
+		present []int
+		remove  []bool
+		times   []time.Time
+	)
+
+	times = append(
+		[]time.Time{now},
+		expiryCandidates(all, keepHourly, keepDaily, now)...,
+	)
+
+	for _, tme := range times {
+		// Trim the snapshots remaining and those taken by the candidate time.
+		present = present[:0]
+
+		for i := range all {
+			if (i < len(tms) && !removed[i]) ||
+				(i >= len(tms) && !all[i].After(tme)) {
+				present = append(present, i)
+			}
+		}
+
+		remove = identifyRemovals(
+			timesOf(all, present), tme.Add(-keepHourly), tme.Add(-keepDaily),
+		)
+
+		for j, i := range present {
+			if i < len(tms) && remove[j] && !pinned[i] {
+				removed[i] = true
+
+				if tme.After(now) {
+					expires[i] = &tme
+				}
+			}
+		}
+	}
+
+	return expires
+}
+
+// timesOf returns the times at the indexes.
+func timesOf(tms []time.Time, indexes []int) []time.Time {
+	selected := make([]time.Time, len(indexes))
+
+	for j, i := range indexes {
+		selected[j] = tms[i]
+	}
+
+	return selected
+}
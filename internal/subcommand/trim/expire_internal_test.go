@@ -0,0 +1,108 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package trim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+func mkLocal(m, d, h int) time.Time {
+	return time.Date(2026, time.Month(m), d, h, 0, 0, 0, time.Local)
+}
+
+func fmtExpires(expires []*time.Time) []string {
+	formatted := make([]string, len(expires))
+
+	for i, expire := range expires {
+		formatted[i] = "never"
+		if expire != nil {
+			formatted[i] = expire.Format(time.RFC3339)
+		}
+	}
+
+	return formatted
+}
+
+func TestInternalTrim_Expire_FutureSnapshots(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	// Sunday evening leaves two hours in the ISO week.
+	now := mkLocal(10, 18, 21).Add(time.Minute * 30)
+
+	future := futureSnapshots(now)
+	chk.Int(len(future), 2)
+	chk.True(future[0].Equal(now.Add(time.Hour)))
+	chk.True(future[1].Equal(now.Add(time.Hour * 2)))
+
+	chk.Int(len(futureSnapshots(mkLocal(10, 18, 23).Add(time.Minute))), 0)
+}
+
+func TestInternalTrim_Expire_ProjectExpiry(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	const (
+		keepHourly = time.Hour * 24
+		keepDaily  = time.Hour * 24 * 7
+	)
+
+	now := mkLocal(10, 14, 12) // Wednesday.
+	tms := []time.Time{
+		mkLocal(9, 30, 12),  // Only snapshot of its ISO week.
+		mkLocal(10, 8, 8),   // Purged now for its day.
+		mkLocal(10, 8, 10),  // Replaced by Sunday for its ISO week.
+		mkLocal(10, 11, 10), // Newest of its ISO week.
+		mkLocal(10, 14, 9),  // Replaced by later snapshots today.
+		mkLocal(10, 14, 11), // Latest.
+	}
+
+	chk.StrSlice(
+		fmtExpires(projectExpiry(
+			tms, make([]bool, len(tms)), keepHourly, keepDaily, now,
+		)),
+		[]string{
+			"never",
+			"never",
+			mkLocal(10, 15, 10).Format(time.RFC3339),
+			"never",
+			mkLocal(10, 15, 9).Format(time.RFC3339),
+			mkLocal(10, 15, 11).Format(time.RFC3339),
+		},
+	)
+
+	chk.StrSlice(
+		fmtExpires(projectExpiry(
+			tms,
+			[]bool{false, false, false, false, true, false},
+			keepHourly, keepDaily, now,
+		)),
+		[]string{
+			"never",
+			"never",
+			mkLocal(10, 15, 10).Format(time.RFC3339),
+			"never",
+			"never",
+			mkLocal(10, 15, 11).Format(time.RFC3339),
+		},
+	)
+}
@@ -20,16 +20,35 @@ package trim
 
 // HelpText describes the overall operation of the utility.
 const HelpText = `{t | trim} ` +
-	`[--dry-run] [-t target] config.szb
+	`[--dry-run] ` +
+	`[--plan plan.json | --apply plan.json] ` +
+	`[-t target] config.szb
 
 Implements the specified retention policy as defined in the backup
 configuration file deleting backups as appropriate. The most recent snapshot
-pointed to by the "latest" symbolic link is never deleted.
+pointed to by the "latest" symbolic link is never deleted.  Neither is a
+snapshot pinned by a tag (any other symbolic link in the target pointing at
+it except the pre-restore link maintained by restore).  Each snapshot is
+listed with the rule that kept or purged it and, if kept, its projected
+expiry assuming snapshots continue to be taken hourly.
 
    [--dry-run]
       Identifies all of the actions the utility would take without making any
       changes to the backup source.
 
+   [--plan plan.json]
+      Writes the snapshots the retention policy would purge now, with the
+      reason for each, to the plan file without purging anything.  The
+      snapshots only kept as they are pinned by a tag are also listed.  The
+      plan may be reviewed before it is applied.
+
+   [--apply plan.json]
+      Purges exactly the snapshots listed in the plan file.  The plan must
+      have been written for the same target and every snapshot it lists must
+      still exist and be neither latest nor pinned.  Nothing is purged if
+      any snapshot fails these checks.  It may be combined with --dry-run to
+      only validate the plan.
+
    [-t target]
       Specifies the backup set to prune.  It is optional if the backup config
       file specifies a target and mandatory if not specified in the backup
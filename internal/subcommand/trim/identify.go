@@ -37,21 +37,49 @@ func getProximity(fTme, tTme time.Time) (bool, bool) {
 	return sameWeek, sameDay
 }
 
+// Retention reasons explaining why a snapshot is kept or removed.
+const (
+	ReasonLatest   = "latest"
+	ReasonHourly   = "within hourly retention"
+	ReasonDaily    = "newest of its day"
+	ReasonWeekly   = "newest of its ISO week"
+	ReasonSameDay  = "newer snapshot kept for its day"
+	ReasonSameWeek = "newer snapshot kept for its ISO week"
+	ReasonPinned   = "pinned by tag"
+)
+
 func identifyRemovals(tms []time.Time, dayCut, weekCut time.Time) []bool {
+	remove, _ := identifyReasons(tms, dayCut, weekCut)
+
+	return remove
+}
+
+// identifyReasons identifies the snapshots to remove along with the reason
+// each snapshot is kept or removed.
+func identifyReasons(
+	tms []time.Time, dayCut, weekCut time.Time,
+) ([]bool, []string) {
 	var (
 		prevIndex int
 		currIndex int
 		remove    []bool
+		reasons   []string
 	)
 
 	remove = make([]bool, len(tms))
+	reasons = make([]string, len(tms))
 
 	currIndex = len(tms) - 1
 	prevIndex = currIndex - 1
 
+	if currIndex >= 0 {
+		reasons[currIndex] = ReasonLatest
+	}
+
 	for ; prevIndex >= 0; prevIndex-- {
 		if dayCut.Before(tms[prevIndex]) {
 			currIndex = prevIndex
+			reasons[prevIndex] = ReasonHourly
 
 			continue
 		}
@@ -61,17 +89,21 @@ func identifyRemovals(tms []time.Time, dayCut, weekCut time.Time) []bool {
 		if weekCut.Before(tms[prevIndex]) { //nolint:nestif // Ok.
 			if !sameDay {
 				currIndex = prevIndex
+				reasons[prevIndex] = ReasonDaily
 			} else {
 				remove[prevIndex] = true
+				reasons[prevIndex] = ReasonSameDay
 			}
 		} else {
 			if !sameWeek {
 				currIndex = prevIndex
+				reasons[prevIndex] = ReasonWeekly
 			} else {
 				remove[prevIndex] = true
+				reasons[prevIndex] = ReasonSameWeek
 			}
 		}
 	}
 
-	return remove
+	return remove, reasons
 }
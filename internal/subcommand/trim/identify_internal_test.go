@@ -223,3 +223,39 @@ func TestInternalTrim_Identify_Dec31_Tuesday(t *testing.T) {
 		mkRemovedDaily(tme),
 	)
 }
+
+func TestInternalTrim_IdentifyReasons(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.Local)
+	tms := []time.Time{
+		time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local),  // Tue.
+		time.Date(2024, 1, 3, 10, 0, 0, 0, time.Local),  // Wed.
+		time.Date(2024, 1, 29, 8, 0, 0, 0, time.Local),  // Mon.
+		time.Date(2024, 1, 29, 20, 0, 0, 0, time.Local), // Mon.
+		time.Date(2024, 1, 31, 9, 0, 0, 0, time.Local),  // Wed.
+		time.Date(2024, 1, 31, 11, 0, 0, 0, time.Local), // Wed.
+	}
+
+	remove, reasons := identifyReasons(
+		tms, now.Add(-time.Hour*24), now.Add(-time.Hour*24*14),
+	)
+
+	chk.BoolSlice(remove, []bool{true, false, true, false, false, false})
+	chk.StrSlice(
+		reasons,
+		[]string{
+			ReasonSameWeek,
+			ReasonWeekly,
+			ReasonSameDay,
+			ReasonDaily,
+			ReasonHourly,
+			ReasonLatest,
+		},
+	)
+
+	remove, reasons = identifyReasons(nil, now, now)
+	chk.BoolSlice(remove, []bool{})
+	chk.StrSlice(reasons, []string{})
+}
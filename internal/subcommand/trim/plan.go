@@ -0,0 +1,208 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package trim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+)
+
+const (
+	planPerm  = 0o0600
+	planLabel = " (PLAN)"
+)
+
+// Plan is the list of snapshots a trim would purge written for review
+// before it is applied.  The snapshots only kept as they are pinned by a tag
+// are listed for reference.
+type Plan struct {
+	Target  string     `json:"target"`
+	Created time.Time  `json:"created"`
+	Purge   []Decision `json:"purge"`
+	Pinned  []Decision `json:"pinned"`
+}
+
+// writePlan writes the snapshots the decisions purge (and those only kept as
+// they are pinned) to the plan file.
+func writePlan(
+	path string, cfg *settings.Config, decisions []Decision, now time.Time,
+) (Plan, error) {
+	var (
+		plan = Plan{
+			Target:  cfg.Target.GetPath(),
+			Created: now,
+			Purge:   []Decision{},
+			Pinned:  []Decision{},
+		}
+		data []byte
+		err  error
+	)
+
+	for _, decision := range decisions {
+		switch {
+		case decision.Action == ActionPurge:
+			plan.Purge = append(plan.Purge, decision)
+		case strings.HasPrefix(decision.Reason, ReasonPinned):
+			plan.Pinned = append(plan.Pinned, decision)
+		}
+	}
+
+	data, err = json.MarshalIndent(plan, "", "  ")
+
+	if err == nil {
+		err = os.WriteFile(path, append(data, '\n'), planPerm)
+	}
+
+	if err == nil {
+		return plan, nil
+	}
+
+	return Plan{}, fmt.Errorf("%w: '%s': %w", ErrPlanWrite, path, err)
+}
+
+// loadPlan reads a plan file.
+func loadPlan(path string) (Plan, error) {
+	var (
+		plan Plan
+		data []byte
+		err  error
+	)
+
+	data, err = os.ReadFile(path) //nolint:gosec // Ok.
+
+	if err == nil {
+		err = json.Unmarshal(data, &plan)
+	}
+
+	if err == nil {
+		return plan, nil
+	}
+
+	return Plan{}, fmt.Errorf("%w: '%s': %w", ErrPlanLoad, path, err)
+}
+
+// samePath returns true if both paths are the same absolute path.
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+
+	return errA == nil && errB == nil && absA == absB
+}
+
+// validatePlan insures the plan was made for the target and that every
+// snapshot it purges still exists and is neither latest nor pinned.  Every
+// problem is reported.
+func validatePlan(cfg *settings.Config, plan Plan) error {
+	var (
+		latest   string
+		pins     map[string][]string
+		name     string
+		problems []error
+		err      error
+	)
+
+	if !samePath(plan.Target, cfg.Target.GetPath()) {
+		return fmt.Errorf(
+			"%w: %w: '%s'", ErrPlanInvalid, ErrPlanTarget, plan.Target,
+		)
+	}
+
+	latest, err = cfg.Target.LatestSnapshot()
+
+	if err == nil {
+		pins, err = cfg.Target.Pins()
+	}
+
+	for i := 0; i < len(plan.Purge) && err == nil; i++ {
+		name = plan.Purge[i].Snapshot
+
+		switch {
+		case name != filepath.Base(name) ||
+			!strings.HasSuffix(name, target.BackupDirectoryExtension):
+			problems = append(problems,
+				fmt.Errorf("%w: '%s'", ErrPlanNotSnapshot, name),
+			)
+		case directory.Is(filepath.Join(cfg.Target.GetPath(), name)) != nil:
+			problems = append(problems,
+				fmt.Errorf("%w: '%s'", ErrPlanMissing, name),
+			)
+		case name == latest:
+			problems = append(problems,
+				fmt.Errorf("%w: '%s'", ErrPlanLatest, name),
+			)
+		case len(pins[name]) > 0:
+			problems = append(problems, fmt.Errorf(
+				"%w: '%s': '%s'",
+				ErrPlanPinned, name, strings.Join(pins[name], "', '"),
+			))
+		}
+	}
+
+	if err == nil {
+		err = errors.Join(problems...)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrPlanInvalid, err)
+}
+
+// Apply purges exactly the snapshots listed in the plan file after
+// validating that each may still be purged.  Nothing is purged if any
+// snapshot fails validation.
+func Apply(
+	cfg *settings.Config, path string, dryRun string,
+) ([]Decision, int, error) {
+	var (
+		plan        Plan
+		dirs        []string
+		purgedCount int
+		err         error
+	)
+
+	plan, err = loadPlan(path)
+
+	if err == nil {
+		err = validatePlan(cfg, plan)
+	}
+
+	if err == nil {
+		dirs = make([]string, len(plan.Purge))
+
+		for i, decision := range plan.Purge {
+			dirs[i] = filepath.Join(cfg.Target.GetPath(), decision.Snapshot)
+			plan.Purge[i].Action = ActionPurge
+		}
+
+		purgedCount, err = processPurge(dirs, plan.Purge, dryRun)
+	}
+
+	return plan.Purge, purgedCount, err
+}
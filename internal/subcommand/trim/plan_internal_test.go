@@ -0,0 +1,196 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package trim
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// setupPlan creates four snapshots taken on the same day a week ago (the
+// oldest three purged by the retention policy) and a latest snapshot taken
+// now.
+func setupPlan(chk *sztest.Chk) (*settings.Config, []string, time.Time) {
+	chk.T().Helper()
+
+	trg, err := target.New(chk.CreateTmpSubDir("target"))
+	chk.NoErr(err)
+
+	now := time.Now()
+	lastWeek := time.Date(
+		now.Year(), now.Month(), now.Day()-7, 10, 0, 0, 0, time.Local,
+	)
+	dirs := make([]string, 0, 5) //nolint:mnd // Ok.
+
+	for i := range 4 {
+		dirs = append(dirs, trg.SnapshotDir(
+			lastWeek.Add(time.Hour*time.Duration(i)),
+		))
+	}
+
+	dirs = append(dirs, trg.SnapshotDir(now))
+
+	for _, dir := range dirs {
+		chk.NoErr(os.Mkdir(dir, permWrite))
+	}
+
+	chk.NoErr(trg.SetLatest(dirs[4]))
+
+	return &settings.Config{
+		Target:     trg,
+		KeepHourly: time.Hour * 24,
+		KeepDaily:  time.Hour * 24 * 30,
+	}, dirs, now
+}
+
+func TestInternalTrim_Plan_WriteAndApply(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfg, dirs, now := setupPlan(chk)
+	planFile := filepath.Join(chk.CreateTmpSubDir("plan"), "plan.json")
+
+	decisions, purged, err := Purge(cfg, now, planLabel)
+	chk.NoErr(err)
+	chk.Int(purged, 0)
+
+	plan, err := writePlan(planFile, cfg, decisions, now)
+	chk.NoErr(err)
+	chk.Int(len(plan.Purge), 3)
+
+	loaded, err := loadPlan(planFile)
+	chk.NoErr(err)
+	chk.Str(loaded.Target, cfg.Target.GetPath())
+	chk.True(loaded.Created.Equal(now))
+	chk.Int(len(loaded.Purge), 3)
+
+	for i, decision := range loaded.Purge {
+		chk.Str(decision.Snapshot, filepath.Base(dirs[i]))
+		chk.Str(decision.Action, ActionPurge)
+		chk.Str(decision.Reason, ReasonSameDay)
+	}
+
+	// A dry run only validates.
+	applied, purged, err := Apply(cfg, planFile, " (DRY RUN)")
+	chk.NoErr(err)
+	chk.Int(len(applied), 3)
+	chk.Int(purged, 0)
+	chk.NoErr(directory.Is(dirs[0]))
+
+	applied, purged, err = Apply(cfg, planFile, "")
+	chk.NoErr(err)
+	chk.Int(len(applied), 3)
+	chk.Int(purged, 3)
+
+	for i, dir := range dirs {
+		chk.Bool(directory.Is(dir) == nil, i > 2)
+	}
+}
+
+func TestInternalTrim_Plan_Pinned(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfg, dirs, now := setupPlan(chk)
+	planFile := filepath.Join(chk.CreateTmpSubDir("plan"), "plan.json")
+
+	// The automatic pre-restore link does not pin its snapshot.
+	chk.NoErr(cfg.Target.SetTag(target.PreRestoreTag, dirs[0]))
+	chk.NoErr(cfg.Target.SetTag("release", dirs[1]))
+
+	decisions, _, err := Purge(cfg, now, planLabel)
+	chk.NoErr(err)
+	chk.Str(decisions[1].Action, ActionKeep)
+	chk.Str(decisions[1].Reason, ReasonPinned+" 'release'")
+	chk.Str(decisions[0].Action, ActionPurge)
+	chk.Str(decisions[2].Action, ActionPurge)
+
+	plan, err := writePlan(planFile, cfg, decisions, now)
+	chk.NoErr(err)
+	chk.Int(len(plan.Purge), 2)
+	chk.Int(len(plan.Pinned), 1)
+	chk.Str(plan.Pinned[0].Snapshot, filepath.Base(dirs[1]))
+	chk.Str(plan.Pinned[0].Reason, ReasonPinned+" 'release'")
+}
+
+func TestInternalTrim_Plan_Invalid(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfg, dirs, now := setupPlan(chk)
+	planFile := filepath.Join(chk.CreateTmpSubDir("plan"), "plan.json")
+
+	_, _, err := Apply(cfg, planFile, "")
+	chk.True(errors.Is(err, ErrPlanLoad))
+
+	decisions, _, err := Purge(cfg, now, planLabel)
+	chk.NoErr(err)
+
+	// Snapshots the reviewer did not expect.
+	decisions[3].Action = ActionPurge
+	decisions[4].Action = ActionPurge
+	decisions = append(decisions, Decision{
+		Snapshot: "../elsewhere" + target.BackupDirectoryExtension,
+		Action:   ActionPurge,
+	})
+
+	_, err = writePlan(planFile, cfg, decisions, now)
+	chk.NoErr(err)
+
+	// Changes since the plan was written.
+	chk.NoErr(os.Remove(dirs[0]))
+	chk.NoErr(cfg.Target.SetTag("keep", dirs[3]))
+
+	applied, purged, err := Apply(cfg, planFile, "")
+	chk.Err(
+		err,
+		ErrPlanInvalid.Error()+": "+
+			ErrPlanMissing.Error()+": '"+filepath.Base(dirs[0])+"'\n"+
+			ErrPlanPinned.Error()+": '"+filepath.Base(dirs[3])+"': 'keep'\n"+
+			ErrPlanLatest.Error()+": '"+filepath.Base(dirs[4])+"'\n"+
+			ErrPlanNotSnapshot.Error()+": '../elsewhere"+
+			target.BackupDirectoryExtension+"'",
+	)
+	chk.Int(len(applied), 6)
+	chk.Int(purged, 0)
+
+	// Nothing is purged if any snapshot is invalid.
+	for _, dir := range dirs[1:] {
+		chk.NoErr(directory.Is(dir))
+	}
+
+	other, err := target.New(chk.CreateTmpSubDir("other"))
+	chk.NoErr(err)
+
+	_, _, err = Apply(&settings.Config{Target: other}, planFile, "")
+	chk.Err(
+		err,
+		ErrPlanInvalid.Error()+": "+
+			ErrPlanTarget.Error()+": '"+cfg.Target.GetPath()+"'",
+	)
+}
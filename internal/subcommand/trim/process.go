@@ -19,6 +19,7 @@
 package trim
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"slices"
@@ -27,21 +28,37 @@ import (
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal/fstat"
+	"github.com/dancsecs/szbck/internal/metrics"
+	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/settings"
 	"github.com/dancsecs/szbck/internal/target"
 )
 
-func parseArguments(args *szargs.Args) (*settings.Config, string, error) {
+// options holds the command line options controlling a trim.
+type options struct {
+	dryRun string
+	plan   string
+	apply  string
+}
+
+func parseArguments(args *szargs.Args) (*settings.Config, options, error) {
 	var (
 		isDryRun bool
-		dryRun   string
+		opts     options
 		cfg      *settings.Config
 		err      error
 	)
 
 	isDryRun = args.Is("--dry-run", "")
 	if isDryRun {
-		dryRun = " (DRY RUN)"
+		opts.dryRun = " (DRY RUN)"
+	}
+
+	opts.plan, _ = args.ValueString("--plan", "")
+	opts.apply, _ = args.ValueString("--apply", "")
+
+	if opts.plan != "" && opts.apply != "" {
+		args.PushErr(ErrPlanAndApply)
 	}
 
 	err = args.Err()
@@ -50,7 +67,7 @@ func parseArguments(args *szargs.Args) (*settings.Config, string, error) {
 		cfg, err = settings.LoadFromArgs(args)
 	}
 
-	return cfg, dryRun, err //nolint:wrapcheck // Ok.
+	return cfg, opts, err //nolint:wrapcheck // Ok.
 }
 
 func getTimestamp(fName string) (time.Time, error) {
@@ -90,37 +107,116 @@ func loadBackupDirs(trg string) ([]string, error) {
 	return matchingDirs, err
 }
 
-// PurgeSnapshots removes snapshots based on the configured retention policy
-// using the provide time as the root to base snapshot expiring on.  The most
-// recent snapshot pointed to by the "latest" symbolic link is never deleted.
-func PurgeSnapshots(
-	cfg *settings.Config,
-	tme time.Time, // The reference timestamp to base trim functions on.
-	dryRun string,
-) (int, error) {
-	var (
-		hourlyCutoff time.Time
-		dailyCutoff  time.Time
-		tms          []time.Time
-		dirs         []string
-		remove       []bool
-		purgedCount  int
-		err          error
-	)
+// Snapshot actions.
+const (
+	ActionKeep  = "keep"
+	ActionPurge = "purge"
+)
 
-	hourlyCutoff = tme.Add(-cfg.KeepHourly)
-	dailyCutoff = tme.Add((-cfg.KeepDaily))
+// Decision is the retention decision made for a single snapshot.
+type Decision struct {
+	Snapshot string    `json:"snapshot"`
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	Reason   string    `json:"reason"`
+	// Expires is the projected time a kept snapshot will be purged.  It is
+	// not set if the snapshot is purged now or will never be purged.
+	Expires *time.Time `json:"expires,omitempty"`
+}
 
-	dirs, err = loadBackupDirs(cfg.Target.GetPath())
+// Explanation returns the reason for the decision along with when a kept
+// snapshot expires.
+func (d Decision) Explanation() string {
+	switch {
+	case d.Action == ActionPurge:
+		return d.Reason
+	case d.Expires == nil:
+		return d.Reason + ", never expires"
+	default:
+		return d.Reason + ", expires " + d.Expires.Format(time.RFC1123)
+	}
+}
 
-	if err != nil || len(dirs) < 2 {
-		if len(dirs) == 0 {
-			err = ErrNoBackups
-		} else if len(dirs) == 1 {
-			err = ErrOnlyLatest
+// Result is the structured outcome of a trim.
+type Result struct {
+	DryRun     bool         `json:"dryRun"`
+	Purged     int          `json:"purged"`
+	Snapshots  []Decision   `json:"snapshots"`
+	FileSystem fstat.Change `json:"fileSystem"`
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{"snapshot", "time", "action", "reason", "expires"}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Snapshots))
+
+	for _, decision := range r.Snapshots {
+		expires := ""
+		if decision.Expires != nil {
+			expires = decision.Expires.Format(time.RFC3339)
+		}
+
+		rows = append(rows, []string{
+			decision.Snapshot,
+			decision.Time.Format(time.RFC3339),
+			decision.Action,
+			decision.Reason,
+			expires,
+		})
+	}
+
+	return rows
+}
+
+// pin keeps the snapshots pinned by a tag that would otherwise be removed
+// returning which snapshots are pinned.
+func pin(
+	dirs []string, pins map[string][]string, remove []bool, reasons []string,
+) []bool {
+	pinned := make([]bool, len(dirs))
+
+	for i, dir := range dirs {
+		tags := pins[filepath.Base(dir)]
+		if len(tags) > 0 {
+			pinned[i] = true
+		}
+
+		if remove[i] && pinned[i] {
+			remove[i] = false
+			reasons[i] = ReasonPinned + " '" + strings.Join(tags, "', '") + "'"
 		}
 	}
 
+	return pinned
+}
+
+// decide returns the target's snapshots (oldest first) with the retention
+// decision for each using the provided time as the root to base snapshot
+// expiring on.
+func decide(
+	cfg *settings.Config, tme time.Time,
+) ([]string, []Decision, error) {
+	var (
+		tms       []time.Time
+		dirs      []string
+		remove    []bool
+		reasons   []string
+		pins      map[string][]string
+		pinned    []bool
+		expires   []*time.Time
+		decisions []Decision
+		err       error
+	)
+
+	dirs, err = loadBackupDirs(cfg.Target.GetPath())
+	if errors.Is(err, ErrOnlyLatest) {
+		err = nil
+	}
+
 	// Convert dir names to real timestamps all at once.
 	if err == nil {
 		tms = make([]time.Time, len(dirs))
@@ -130,34 +226,160 @@ func PurgeSnapshots(
 	}
 
 	if err == nil {
-		remove = identifyRemovals(tms, hourlyCutoff, dailyCutoff)
+		pins, err = cfg.Target.Pins()
 	}
 
 	if err == nil {
-		purgedCount, err = processPurge(dirs, tms, remove, dryRun)
+		remove, reasons = identifyReasons(
+			tms, tme.Add(-cfg.KeepHourly), tme.Add(-cfg.KeepDaily),
+		)
+		pinned = pin(dirs, pins, remove, reasons)
+		expires = projectExpiry(
+			tms, pinned, cfg.KeepHourly, cfg.KeepDaily, tme,
+		)
+
+		decisions = make([]Decision, len(dirs))
+
+		for i, dir := range dirs {
+			decisions[i] = Decision{
+				Snapshot: filepath.Base(dir),
+				Time:     tms[i],
+				Action:   ActionKeep,
+				Reason:   reasons[i],
+				Expires:  expires[i],
+			}
+
+			if remove[i] {
+				decisions[i].Action = ActionPurge
+				decisions[i].Expires = nil
+			}
+		}
 	}
 
+	return dirs, decisions, err
+}
+
+// Explain returns the retention decision for each snapshot (oldest first)
+// without purging any of them.  Each kept snapshot's projected expiry
+// assumes snapshots continue to be taken hourly.
+func Explain(cfg *settings.Config, tme time.Time) ([]Decision, error) {
+	_, decisions, err := decide(cfg, tme)
+
+	return decisions, err
+}
+
+// PurgeSnapshots removes snapshots based on the configured retention policy
+// using the provide time as the root to base snapshot expiring on.  The most
+// recent snapshot pointed to by the "latest" symbolic link and snapshots
+// pinned by a tag are never deleted.
+func PurgeSnapshots(
+	cfg *settings.Config,
+	tme time.Time, // The reference timestamp to base trim functions on.
+	dryRun string,
+) (int, error) {
+	_, purgedCount, err := Purge(cfg, tme, dryRun)
+
 	return purgedCount, err
 }
 
-// Process parses the remaining arguments deleting previous backups.
+// Purge removes snapshots as PurgeSnapshots does also returning the decision
+// made for each snapshot (oldest first).
+func Purge(
+	cfg *settings.Config,
+	tme time.Time, // The reference timestamp to base trim functions on.
+	dryRun string,
+) ([]Decision, int, error) {
+	var (
+		dirs        []string
+		decisions   []Decision
+		purgedCount int
+		err         error
+	)
+
+	dirs, decisions, err = decide(cfg, tme)
+
+	if err == nil && len(dirs) < 2 {
+		err = ErrOnlyLatest
+	}
+
+	if err == nil {
+		purgedCount, err = processPurge(dirs, decisions, dryRun)
+	}
+
+	return decisions, purgedCount, err
+}
+
+// Process parses the remaining arguments trimming the snapshots as defined
+// by the retention policy, writing the snapshots it would purge to a plan
+// file or purging exactly those listed in a plan file.
+//
+//nolint:cyclop,funlen // Ok.
 func Process(args *szargs.Args) (string, error) {
 	var (
+		opts        options
 		dryRun      string
 		cfg         *settings.Config
+		decisions   []Decision
+		plan        Plan
 		purgedCount int
 		fsStat      *fstat.StatFS
+		outText     string
+		now         = time.Now()
 		err         error
 	)
 
-	cfg, dryRun, err = parseArguments(args)
+	cfg, opts, err = parseArguments(args)
+
+	dryRun = opts.dryRun
+	if opts.plan != "" {
+		dryRun = planLabel
+	}
 
 	if err == nil {
 		fsStat, err = fstat.New(cfg.Target.GetPath())
 	}
 
-	if err == nil {
-		purgedCount, err = PurgeSnapshots(cfg, time.Now(), dryRun)
+	if err == nil && opts.apply != "" {
+		decisions, purgedCount, err = Apply(cfg, opts.apply, dryRun)
+	} else if err == nil {
+		decisions, purgedCount, err = Purge(cfg, now, dryRun)
+	}
+
+	if err == nil && opts.plan != "" {
+		plan, err = writePlan(opts.plan, cfg, decisions, now)
+	}
+
+	if err == nil && dryRun == "" {
+		metrics.Update(cfg, metrics.OperationTrim, purgedCount)
+	}
+
+	if err == nil && out.Structured() {
+		outText, err = out.Result(Result{
+			DryRun:     dryRun != "",
+			Purged:     purgedCount,
+			Snapshots:  decisions,
+			FileSystem: fsStat.Change(),
+		})
+		if err == nil {
+			return outText, nil
+		}
+	}
+
+	//nolint:forbidigo // Ok.
+	if err == nil && opts.plan != "" {
+		fmt.Printf(
+			"trim plan written: %s (Purge: %d, Pinned: %d)\n",
+			opts.plan, len(plan.Purge), len(plan.Pinned),
+		)
+
+		for _, decision := range plan.Pinned {
+			fmt.Printf(
+				"Pinned snapshot: %s (%s)\n",
+				decision.Snapshot, decision.Reason,
+			)
+		}
+
+		return "", nil
 	}
 
 	//nolint:forbidigo // Ok.
@@ -98,6 +98,9 @@ func init() {
 }
 
 func squashNumbers(chk *sztest.Chk) {
+	// Projected expiry depends on the current day of the week.
+	chk.AddSub(`(never expires|expires [^)]+)`, "expires #")
+
 	// FileNames
 	chk.AddSub(`\d{8,8}_\d\d\d\d\d\d\.\d\d\d\d`, "########_######.####")
 
@@ -358,8 +361,10 @@ func TestTrim_Process_TwoBackupDirs_DryRun(t *testing.T) {
 
 	squashNumbers(chk)
 	chk.Stdout(
-		"Keeping snapshot (DRY RUN): "+fmtTS(snap1),
-		"Keeping snapshot (DRY RUN): "+fmtTS(snap2),
+		"Keeping snapshot (DRY RUN): "+fmtTS(snap1)+
+			" ("+trim.ReasonHourly+", expires #)",
+		"Keeping snapshot (DRY RUN): "+fmtTS(snap2)+
+			" ("+trim.ReasonLatest+", expires #)",
 		"trim successful (Purged: 0) (DRY RUN)",
 		"Syncing...",
 		summaryUsage,
@@ -392,8 +397,10 @@ func TestTrim_Process_TwoBackupDirs_PurgeNoneDryRun(t *testing.T) {
 
 	squashNumbers(chk)
 	chk.Stdout(
-		"Keeping snapshot (DRY RUN): "+fmtTS(snap1),
-		"Keeping snapshot (DRY RUN): "+fmtTS(snap2),
+		"Keeping snapshot (DRY RUN): "+fmtTS(snap1)+
+			" ("+trim.ReasonWeekly+", expires #)",
+		"Keeping snapshot (DRY RUN): "+fmtTS(snap2)+
+			" ("+trim.ReasonLatest+", expires #)",
 		"trim successful (Purged: 0) (DRY RUN)",
 		"Syncing...",
 		summaryUsage,
@@ -423,8 +430,10 @@ func TestTrim_Process_TwoBackupDirs_PurgeNone(t *testing.T) {
 
 	squashNumbers(chk)
 	chk.Stdout(
-		"Keeping snapshot: "+fmtTS(snap1),
-		"Keeping snapshot: "+fmtTS(snap2),
+		"Keeping snapshot: "+fmtTS(snap1)+
+			" ("+trim.ReasonWeekly+", expires #)",
+		"Keeping snapshot: "+fmtTS(snap2)+
+			" ("+trim.ReasonLatest+", expires #)",
 		"trim successful (Purged: 0)",
 		"Syncing...",
 		summaryUsage,
@@ -460,10 +469,11 @@ func TestTrim_Process_TwoBackupDirs_PurgeDaily(t *testing.T) {
 
 	squashNumbers(chk)
 	chk.Stdout(
-		"*Purged snapshot: "+fmtTS(purge1)+" **",
-		"*Purged snapshot: "+fmtTS(purge2)+" **",
-		"Keeping snapshot: "+fmtTS(keep3),
-		"Keeping snapshot: "+fmtTS(keepRoot),
+		"*Purged snapshot: "+fmtTS(purge1)+" ("+trim.ReasonSameDay+") **",
+		"*Purged snapshot: "+fmtTS(purge2)+" ("+trim.ReasonSameDay+") **",
+		"Keeping snapshot: "+fmtTS(keep3)+" ("+trim.ReasonDaily+", expires #)",
+		"Keeping snapshot: "+fmtTS(keepRoot)+
+			" ("+trim.ReasonLatest+", expires #)",
 		"trim successful (Purged: 2)",
 		"Syncing...",
 		summaryUsage,
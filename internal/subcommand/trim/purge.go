@@ -27,7 +27,7 @@ import (
 )
 
 func processPurge(
-	dirs []string, tms []time.Time, remove []bool, dryRun string,
+	dirs []string, decisions []Decision, dryRun string,
 ) (int, error) {
 	var (
 		purgedCount int
@@ -35,7 +35,9 @@ func processPurge(
 	)
 
 	for i, dir := range dirs {
-		if dryRun == "" && remove[i] {
+		remove := decisions[i].Action == ActionPurge
+
+		if dryRun == "" && remove {
 			err = purge.Directory(dir)
 
 			if err == nil {
@@ -44,13 +46,15 @@ func processPurge(
 		}
 
 		if err == nil {
-			if remove[i] {
+			if remove {
 				out.Print("*Purged snapshot"+dryRun+": "+dir+": "+
-					tms[i].Format(time.RFC1123), " **\n",
+					decisions[i].Time.Format(time.RFC1123),
+					" ("+decisions[i].Explanation()+") **\n",
 				)
 			} else {
 				out.Print("Keeping snapshot"+dryRun+": "+dir+": "+
-					tms[i].Format(time.RFC1123), "\n",
+					decisions[i].Time.Format(time.RFC1123),
+					" ("+decisions[i].Explanation()+")\n",
 				)
 			}
 		}
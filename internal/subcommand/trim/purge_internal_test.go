@@ -49,6 +49,10 @@ func fmtTS(fName string) string {
 	return fName + ": " + fileTime.Format(time.RFC1123)
 }
 
+func purgeDecision(tme time.Time) Decision {
+	return Decision{Time: tme, Action: ActionPurge, Reason: ReasonSameDay}
+}
+
 func TestInternalTrim_ProcessPurge_RootPermissionFailure(t *testing.T) {
 	chk := sztestlog.CaptureLog(t)
 	defer chk.Release()
@@ -71,8 +75,7 @@ func TestInternalTrim_ProcessPurge_RootPermissionFailure(t *testing.T) {
 
 	purgedCount, err := processPurge(
 		[]string{dirToDelete},
-		[]time.Time{startTime},
-		[]bool{true},
+		[]Decision{purgeDecision(startTime)},
 		"",
 	)
 
@@ -108,8 +111,7 @@ func TestInternalTrim_ProcessPurge_FailureAfterSuccess(t *testing.T) {
 
 	purgedCount, err := processPurge(
 		[]string{dirToDelete, "INVALID_DIR"},
-		[]time.Time{startTime, startTime},
-		[]bool{true, true},
+		[]Decision{purgeDecision(startTime), purgeDecision(startTime)},
 		"",
 	)
 
@@ -127,7 +129,8 @@ func TestInternalTrim_ProcessPurge_FailureAfterSuccess(t *testing.T) {
 
 	chk.Log()
 	chk.Stdout(
-		"*Purged snapshot: " + fmtTS(dirToDelete) + " **",
+		"*Purged snapshot: " + fmtTS(dirToDelete) +
+			" (" + ReasonSameDay + ") **",
 	)
 }
 
@@ -150,8 +153,14 @@ func TestInternalTrim_ProcessPurge_Success(t *testing.T) {
 
 	purgedCount, err := processPurge(
 		[]string{dirToDelete, dirToKeep},
-		[]time.Time{startTime, startTime.Add(time.Minute)},
-		[]bool{true, false},
+		[]Decision{
+			purgeDecision(startTime),
+			{
+				Time:   startTime.Add(time.Minute),
+				Action: ActionKeep,
+				Reason: ReasonLatest,
+			},
+		},
 		"",
 	)
 
@@ -160,7 +169,8 @@ func TestInternalTrim_ProcessPurge_Success(t *testing.T) {
 
 	chk.Log()
 	chk.Stdout(
-		"*Purged snapshot: "+fmtTS(dirToDelete)+" **",
-		"Keeping snapshot: "+fmtTS(dirToKeep),
+		"*Purged snapshot: "+fmtTS(dirToDelete)+" ("+ReasonSameDay+") **",
+		"Keeping snapshot: "+fmtTS(dirToKeep)+
+			" ("+ReasonLatest+", never expires)",
 	)
 }
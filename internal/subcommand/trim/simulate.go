@@ -0,0 +1,154 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package trim
+
+import "time"
+
+// SimulatedDay is the number of snapshots remaining after the trim at the
+// end of a simulated day.
+type SimulatedDay struct {
+	Date      time.Time `json:"date"`
+	Snapshots int       `json:"snapshots"`
+	// Recent counts the snapshots still within keepDaily.  The rest are
+	// kept for their ISO week.
+	Recent int `json:"recent"`
+}
+
+// Survivor is a snapshot remaining at the end of a simulation with the
+// reason it was kept.
+type Survivor struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+}
+
+// Simulation is the outcome of trimming synthetic snapshots with a retention
+// policy.
+type Simulation struct {
+	Taken     int            `json:"taken"`
+	Days      []SimulatedDay `json:"days"`
+	Survivors []Survivor     `json:"survivors"`
+	// SteadyState is the number of snapshots within keepDaily once it no
+	// longer changes and Settled is the first day it was reached.  Neither
+	// is set if the period is too short to fill keepDaily.
+	SteadyState *int       `json:"steadyState,omitempty"`
+	Settled     *time.Time `json:"settled,omitempty"`
+}
+
+// startOfDay returns midnight at the start of the time's day.
+func startOfDay(tme time.Time) time.Time {
+	return time.Date(
+		tme.Year(), tme.Month(), tme.Day(), 0, 0, 0, 0, tme.Location(),
+	)
+}
+
+// keepOnly returns the times not marked for removal.
+func keepOnly(tms []time.Time, remove []bool) []time.Time {
+	kept := tms[:0]
+
+	for i, tme := range tms {
+		if !remove[i] {
+			kept = append(kept, tme)
+		}
+	}
+
+	return kept
+}
+
+// Simulate takes a synthetic snapshot every interval for the period from the
+// start trimming them at the end of each day with the retention policy.  No
+// snapshots are read or removed.
+func Simulate(
+	start time.Time,
+	period, interval, keepHourly, keepDaily time.Duration,
+) Simulation {
+	var (
+		sim     Simulation
+		end     = start.Add(period)
+		next    = start
+		dayEnd  time.Time
+		tms     []time.Time
+		reasons []string
+		recent  int
+	)
+
+	for day := start; day.Before(end); day = dayEnd {
+		dayEnd = startOfDay(day).AddDate(0, 0, 1)
+		if dayEnd.After(end) {
+			dayEnd = end
+		}
+
+		for ; next.Before(dayEnd); next = next.Add(interval) {
+			tms = append(tms, next)
+			sim.Taken++
+		}
+
+		tms = keepOnly(tms, identifyRemovals(
+			tms, dayEnd.Add(-keepHourly), dayEnd.Add(-keepDaily),
+		))
+
+		recent = 0
+
+		for _, tme := range tms {
+			if tme.After(dayEnd.Add(-keepDaily)) {
+				recent++
+			}
+		}
+
+		sim.Days = append(sim.Days, SimulatedDay{
+			Date:      startOfDay(day),
+			Snapshots: len(tms),
+			Recent:    recent,
+		})
+	}
+
+	_, reasons = identifyReasons(
+		tms, end.Add(-keepHourly), end.Add(-keepDaily),
+	)
+
+	sim.Survivors = make([]Survivor, len(tms))
+	for i, tme := range tms {
+		sim.Survivors[i] = Survivor{Time: tme, Reason: reasons[i]}
+	}
+
+	sim.steadyState(start, end, keepDaily)
+
+	return sim
+}
+
+// steadyState records when the number of snapshots within keepDaily stopped
+// changing if the simulation ran long enough after filling keepDaily.
+func (s *Simulation) steadyState(
+	start, end time.Time, keepDaily time.Duration,
+) {
+	const day = time.Hour * 24
+
+	if len(s.Days) == 0 || end.Sub(start) < keepDaily+day {
+		return
+	}
+
+	last := len(s.Days) - 1
+	settled := last
+
+	for settled > 0 && s.Days[settled-1].Recent == s.Days[last].Recent {
+		settled--
+	}
+
+	s.SteadyState = &s.Days[last].Recent
+	s.Settled = &s.Days[settled].Date
+}
@@ -0,0 +1,78 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package trim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dancsecs/sztestlog"
+)
+
+const simDay = time.Hour * 24
+
+func TestInternalTrim_Simulate_FourWeeks(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local) // Monday.
+
+	sim := Simulate(start, simDay*28, time.Hour, simDay, simDay*7)
+
+	chk.Int(sim.Taken, 28*24)
+	chk.Int(len(sim.Days), 28)
+
+	// Hourly snapshots fill keepHourly then one per day fills keepDaily.
+	chk.Int(sim.Days[0].Snapshots, 23)
+	chk.Int(sim.Days[6].Snapshots, 29)
+	chk.Int(sim.Days[6].Recent, 29)
+
+	// Then one more is kept each ISO week.
+	chk.Int(sim.Days[13].Snapshots, 30)
+	chk.Int(sim.Days[13].Recent, 29)
+	chk.Int(sim.Days[27].Snapshots, 32)
+	chk.Int(sim.Days[27].Recent, 29)
+
+	chk.NotNil(sim.SteadyState)
+	chk.Int(*sim.SteadyState, 29)
+	chk.NotNil(sim.Settled)
+	chk.True(sim.Settled.Equal(start.AddDate(0, 0, 6)))
+
+	chk.Int(len(sim.Survivors), 32)
+	chk.True(sim.Survivors[0].Time.Equal(start.Add(simDay*7 - time.Hour)))
+	chk.Str(sim.Survivors[0].Reason, ReasonWeekly)
+	chk.Str(sim.Survivors[3].Reason, ReasonDaily)
+	chk.Str(sim.Survivors[9].Reason, ReasonHourly)
+	chk.Str(sim.Survivors[31].Reason, ReasonLatest)
+}
+
+func TestInternalTrim_Simulate_TooShort(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local)
+
+	sim := Simulate(start, simDay*3, time.Hour, simDay, simDay*7)
+
+	chk.Int(sim.Taken, 3*24)
+	chk.Int(len(sim.Days), 3)
+	chk.Int(sim.Days[2].Snapshots, 25)
+	chk.Nil(sim.SteadyState)
+	chk.Nil(sim.Settled)
+}
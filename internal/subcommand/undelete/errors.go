@@ -0,0 +1,31 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package undelete
+
+import "errors"
+
+// Undelete errors.
+var (
+	ErrUndeleteError = errors.New("undelete error")
+	ErrSearch        = errors.New("search failed")
+	ErrNoneDeleted   = errors.New("no deleted files")
+	ErrNotDeleted    = errors.New("not a deleted path")
+	ErrRestoreUsage  = errors.New("--all specified with -r")
+	ErrRestore       = errors.New("restore failed")
+)
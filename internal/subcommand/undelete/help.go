@@ -0,0 +1,63 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package undelete
+
+// HelpText describes the overall operation of the utility.
+const HelpText = `{u | undelete} ` +
+	"[--latest] " +
+	"[--all | -r path]... " +
+	"[--dry-run] " +
+	"[-t target] " +
+	"config.szb [subpath]" + `
+
+Lists the files and directories missing from the source that are held by an
+older snapshot along with the last snapshot holding them.  Only the top
+most missing directory is listed.  Specifying --all or -r restores the
+items from the last snapshot holding them into their original locations.
+Nothing else in the source is changed (files are never deleted) and each
+restore is recorded in the target's szbck.journal file.
+
+   [--latest]
+      Lists the items missing from the latest snapshot instead of the
+      source.
+
+   [--all]
+      Restores every item listed.
+
+   [-r path]
+      Restores the listed item (relative to the source IE: docs/old.txt).
+      It may be repeated.  An argument error occurs if specified with --all
+      or if the path is not listed.
+
+   [--dry-run]
+      Identifies all of the actions the utility would take without making
+      any changes to the source.
+
+   [-t target]
+      Specifies the backup set to search.  It is optional if the backup
+      config file specifies a target and mandatory if not specified in the
+      backup config file.
+
+   config.sbc
+      The backup configuration file defining the backup.
+
+   [subpath]
+      Limits the search to the path (relative to the source or an absolute
+      path within it) and everything below it.
+`
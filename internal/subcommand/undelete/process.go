@@ -0,0 +1,462 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package undelete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/journal"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/rsync"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/restore"
+	"github.com/dancsecs/szbck/internal/wait"
+)
+
+const (
+	rowFormat = "%-24s  %13s  %s"
+	noSize    = "-"
+)
+
+// options holds the command line options controlling an undelete.
+type options struct {
+	subPath string
+	restore []string
+	latest  bool
+	all     bool
+	dryRun  bool
+}
+
+// Deleted is a file or directory missing from the source held by an older
+// snapshot.
+type Deleted struct {
+	// Path relative to the source.  Directories end with a separator.
+	Path string `json:"path"`
+	// Snapshot is the last snapshot holding the path.
+	Snapshot string `json:"snapshot"`
+	// Size of the file.
+	Size int64 `json:"size"`
+}
+
+// Result is the structured outcome of an undelete.
+type Result struct {
+	DryRun   bool      `json:"dryRun"`
+	Deleted  []Deleted `json:"deleted"`
+	Restored []Deleted `json:"restored"`
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{"snapshot", "size", "path", "restored"}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Deleted))
+
+	for _, item := range r.Deleted {
+		size := ""
+		if !item.IsDir() {
+			size = strconv.FormatInt(item.Size, 10)
+		}
+
+		rows = append(rows, []string{
+			item.Snapshot,
+			size,
+			item.Path,
+			strconv.FormatBool(slices.Contains(r.Restored, item)),
+		})
+	}
+
+	return rows
+}
+
+// IsDir returns true if the deleted path is a directory.
+func (d Deleted) IsDir() bool {
+	return strings.HasSuffix(d.Path, directory.PathSeparator)
+}
+
+func parseArgs(args *szargs.Args) (*settings.Config, options, error) {
+	var (
+		opts  options
+		cfg   *settings.Config
+		extra []string
+		err   error
+	)
+
+	opts.latest = args.Is("--latest", "")
+	opts.all = args.Is("--all", "")
+	opts.dryRun = args.Is("--dry-run", "")
+	opts.restore = args.ValuesString("-r", "")
+
+	if !args.HasErr() && opts.all && len(opts.restore) > 0 {
+		args.PushErr(ErrRestoreUsage)
+	}
+
+	err = args.Err()
+
+	if err == nil {
+		cfg, extra, err = settings.LoadFromArgsWith(args, 1)
+	}
+
+	if err == nil && len(extra) > 0 {
+		opts.subPath, err = sourceRel(cfg, extra[0])
+	}
+
+	for i := 0; i < len(opts.restore) && err == nil; i++ {
+		opts.restore[i], err = sourceRel(cfg, opts.restore[i])
+	}
+
+	return cfg, opts, err //nolint:wrapcheck // Ok.
+}
+
+// sourceRel returns the path relative to the source.
+func sourceRel(cfg *settings.Config, path string) (string, error) {
+	rel, err := cfg.SnapshotPath(path)
+
+	if err == nil {
+		rel, err = filepath.Rel(filepath.Base(cfg.Source), rel)
+	}
+
+	return rel, err //nolint:wrapcheck // Ok.
+}
+
+// search returns the paths missing from the current tree (the source or the
+// latest snapshot) held by an older snapshot along with the last snapshot
+// holding them.  Only the top most missing directory is reported.
+//
+//nolint:cyclop,funlen // Ok.
+func search(cfg *settings.Config, opts options) ([]Deleted, error) {
+	var (
+		snapshots []string
+		latest    string
+		current   = cfg.Source
+		srcBase   = filepath.Base(cfg.Source)
+		found     = make(map[string]Deleted)
+		exists    = make(map[string]bool)
+		deleted   []Deleted
+		err       error
+	)
+
+	snapshots, err = cfg.Target.Snapshots()
+
+	if err == nil && opts.latest {
+		latest, err = cfg.Target.LatestSnapshot()
+	}
+
+	if err == nil && latest != "" {
+		current = filepath.Join(cfg.Target.GetPath(), latest, srcBase)
+		snapshots = slices.DeleteFunc(snapshots, func(name string) bool {
+			return name == latest
+		})
+	}
+
+	isCurrent := func(rel string) bool {
+		present, ok := exists[rel]
+		if !ok {
+			_, statErr := os.Lstat(filepath.Join(current, rel))
+			present = !errors.Is(statErr, fs.ErrNotExist)
+			exists[rel] = present
+		}
+
+		return present
+	}
+
+	for i := 0; i < len(snapshots) && err == nil; i++ {
+		snapshot := snapshots[i]
+		root := filepath.Join(cfg.Target.GetPath(), snapshot, srcBase)
+
+		err = filepath.WalkDir(
+			filepath.Join(root, opts.subPath),
+			func(path string, entry fs.DirEntry, err error) error {
+				var (
+					rel  string
+					info fs.FileInfo
+				)
+
+				if err == nil {
+					rel, err = filepath.Rel(root, path)
+				}
+
+				if err != nil || rel == "." || isCurrent(rel) {
+					return err
+				}
+
+				info, err = entry.Info()
+				if err == nil && entry.IsDir() {
+					found[rel] = Deleted{
+						Path:     rel + directory.PathSeparator,
+						Snapshot: snapshot,
+					}
+
+					return filepath.SkipDir
+				}
+
+				if err == nil {
+					found[rel] = Deleted{
+						Path:     rel,
+						Snapshot: snapshot,
+						Size:     info.Size(),
+					}
+				}
+
+				return err
+			},
+		)
+
+		// The path may not exist in a snapshot.
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	}
+
+	if err == nil {
+		for _, item := range found {
+			deleted = append(deleted, item)
+		}
+
+		slices.SortFunc(deleted, func(a, b Deleted) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+
+		return deleted, nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrSearch, err)
+}
+
+// chosen returns the deleted items to restore.
+func chosen(deleted []Deleted, opts options) ([]Deleted, error) {
+	if opts.all {
+		return deleted, nil
+	}
+
+	selected := make([]Deleted, 0, len(opts.restore))
+
+	for _, path := range opts.restore {
+		idx := slices.IndexFunc(deleted, func(item Deleted) bool {
+			return strings.TrimSuffix(item.Path, directory.PathSeparator) ==
+				path
+		})
+
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: '%s'", ErrNotDeleted, path)
+		}
+
+		selected = append(selected, deleted[idx])
+	}
+
+	return selected, nil
+}
+
+// restoreItem copies the deleted item from the last snapshot holding it back
+// into its original location.  Nothing else is changed.
+func restoreItem(
+	ctx context.Context, cfg *settings.Config, item Deleted, dryRun bool,
+) error {
+	var (
+		restoreFrom string
+		restoreTo   string
+		rsyncArgs   []string
+		stdout      io.Writer
+		progress    *rsync.ProgressScanner
+		runCtx      context.Context //nolint:containedctx // Ok.
+		cancel      context.CancelFunc
+		start       = time.Now()
+		err         error
+	)
+
+	restoreFrom, restoreTo, err = restore.MakeDirs(
+		filepath.Join(
+			cfg.Target.GetPath(),
+			item.Snapshot,
+			filepath.Base(cfg.Source),
+			item.Path,
+		),
+		cfg.Source,
+	)
+
+	if err == nil {
+		stdout = out.TextWriter()
+		fmt.Fprintf(
+			stdout, "Restoring: %s from %s\n", item.Path, item.Snapshot,
+		)
+
+		rsyncArgs = rsync.WithBandwidthLimit(
+			rsync.BuildArgs(
+				false, // Never delete from the source.
+				dryRun,
+				"", // no linkDest for restore operations.
+				cfg.Options,
+				cfg.RestoreOptions,
+				restoreFrom,
+				restoreTo,
+			),
+			wait.BandwidthLimit(cfg.Bandwidth, start),
+		)
+
+		// Progress updates are requested when detecting stalls as rsync is
+		// otherwise silent while transferring.
+		if cfg.StallTimeout > 0 {
+			progress = rsync.NewProgressScanner(stdout, nil)
+			rsyncArgs = rsync.WithProgress(rsyncArgs)
+			stdout = progress
+		}
+
+		runCtx, cancel = cfg.RunContext(ctx)
+		err = rsync.Run(
+			runCtx,
+			rsyncArgs,
+			stdout,
+			os.Stderr,
+			rsync.WithStallTimeout(nil, cfg.StallTimeout),
+			&cfg.Priority,
+		)
+
+		cancel()
+
+		if progress != nil {
+			progress.Flush()
+		}
+
+		if !dryRun {
+			err = logRestore(cfg, item.Snapshot, start, err)
+		}
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: '%s': %w", ErrRestore, item.Path, err)
+}
+
+// logRestore appends a record of the restore to the target's journal
+// returning the restore's error (if any) along with any error writing to
+// the journal.
+func logRestore(
+	cfg *settings.Config, snapshot string, start time.Time, runErr error,
+) error {
+	record := journal.Record{
+		Operation: journal.OperationRestore,
+		Snapshot:  snapshot,
+		Start:     start,
+		End:       time.Now(),
+		Status:    journal.StatusSuccess,
+		ExitCode:  rsync.ExitCode(runErr),
+	}
+
+	if runErr != nil {
+		record.Status = journal.StatusFailed
+		record.Error = runErr.Error()
+	}
+
+	return errors.Join(runErr, journal.Append(cfg.Target.Journal(), record))
+}
+
+func buildReport(deleted []Deleted) string {
+	var report strings.Builder
+
+	report.WriteString(
+		fmt.Sprintf(rowFormat, "Last Snapshot", "Size", "Path") + "\n",
+	)
+
+	for _, item := range deleted {
+		size := noSize
+		if !item.IsDir() {
+			size = out.Int(item.Size)
+		}
+
+		report.WriteString(
+			fmt.Sprintf(rowFormat, item.Snapshot, size, item.Path) + "\n",
+		)
+	}
+
+	return report.String()
+}
+
+// Process parses the remaining arguments listing the paths deleted from the
+// source and restoring those chosen.
+func Process(ctx context.Context, args *szargs.Args) (string, error) {
+	var (
+		cfg      *settings.Config
+		opts     options
+		deleted  []Deleted
+		restored []Deleted
+		outText  string
+		err      error
+	)
+
+	cfg, opts, err = parseArgs(args)
+
+	if err == nil {
+		deleted, err = search(cfg, opts)
+	}
+
+	if err == nil && len(deleted) == 0 {
+		err = ErrNoneDeleted
+	}
+
+	if err == nil {
+		restored, err = chosen(deleted, opts)
+	}
+
+	for i := 0; i < len(restored) && err == nil; i++ {
+		err = restoreItem(ctx, cfg, restored[i], opts.dryRun)
+	}
+
+	if err == nil && out.Structured() {
+		if restored == nil {
+			restored = []Deleted{}
+		}
+
+		outText, err = out.Result(Result{
+			DryRun:   opts.dryRun,
+			Deleted:  deleted,
+			Restored: restored,
+		})
+		if err == nil {
+			return outText, nil
+		}
+	}
+
+	if err == nil && len(restored) > 0 {
+		return fmt.Sprintf(
+			"undelete successful\n\nRestored: %d\n", len(restored),
+		), nil
+	}
+
+	if err == nil {
+		return "undelete successful\n\n" + buildReport(deleted), nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrUndeleteError, err)
+}
@@ -0,0 +1,249 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package undelete_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
+	"github.com/dancsecs/szbck/internal/settings"
+	"github.com/dancsecs/szbck/internal/subcommand/undelete"
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+// setup creates two snapshots returning the config file and the names of
+// the snapshots oldest first.
+func setup(chk *sztest.Chk) (string, []string) {
+	chk.T().Helper()
+
+	source := chk.CreateTmpSubDir("source")
+	trgDir := chk.CreateTmpSubDir("target")
+
+	cfgData, err := settings.Create(source, trgDir)
+	chk.NoErr(err)
+
+	cfgFile := chk.CreateTmpFileAs("", "backup.sbc", []byte(cfgData))
+
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	taken := time.Now().Add(-time.Hour * 2)
+	names := make([]string, 0, 2)
+
+	for i := range 2 {
+		dir := trg.SnapshotDir(taken.Add(time.Hour * time.Duration(i)))
+		names = append(names, filepath.Base(dir))
+		chk.NoErr(
+			os.MkdirAll(filepath.Join(dir, "source", "docs", "old"), 0o0700),
+		)
+	}
+
+	write := func(root, path, data string) {
+		chk.NoErr(
+			os.WriteFile(filepath.Join(root, path), []byte(data), 0o0600),
+		)
+	}
+
+	snap := func(name string) string {
+		return filepath.Join(trgDir, name, "source")
+	}
+
+	chk.NoErr(os.MkdirAll(filepath.Join(source, "docs"), 0o0700))
+	write(source, "docs/keep.txt", "keep")
+
+	write(snap(names[0]), "docs/keep.txt", "keep")
+	write(snap(names[0]), "docs/gone.txt", "gone")
+	write(snap(names[0]), "docs/old/a.txt", "a")
+	write(snap(names[0]), "top.txt", "top!!")
+
+	write(snap(names[1]), "docs/keep.txt", "keep")
+	write(snap(names[1]), "docs/gone.txt", "gone, later")
+	write(snap(names[1]), "docs/old/b.txt", "b")
+
+	chk.NoErr(trg.SetLatest(filepath.Join(trgDir, names[1])))
+
+	return cfgFile, names
+}
+
+func row(snapshot, size, path string) string {
+	return snapshot + "  " +
+		strings.Repeat(" ", 13-len(size)) + size + "  " + path
+}
+
+const header = "" +
+	"Last Snapshot                      Size  Path"
+
+func TestUndeleteProcess_List(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{"prg", cfgFile})
+	outText, err := undelete.Process(context.Background(), args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"undelete successful",
+			"",
+			header,
+			row(names[1], "11", "docs/gone.txt"),
+			row(names[1], "-", "docs/old/"),
+			row(names[0], "5", "top.txt"),
+			"",
+		},
+	)
+}
+
+func TestUndeleteProcess_ListLatestSubPath(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	args := szargs.New("", []string{"prg", "--latest", cfgFile, "docs"})
+	outText, err := undelete.Process(context.Background(), args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"undelete successful",
+			"",
+			header,
+			row(names[0], "1", "docs/old/a.txt"),
+			"",
+		},
+	)
+}
+
+func TestUndeleteProcess_ListLatestUnfinished(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, names := setup(chk)
+
+	// A newer snapshot still being written holding only part of the source.
+	trgDir := filepath.Join(filepath.Dir(cfgFile), "target")
+	trg, err := target.New(trgDir)
+	chk.NoErr(err)
+
+	partial := filepath.Join(trg.SnapshotDir(time.Now()), "source", "docs")
+	chk.NoErr(os.MkdirAll(partial, 0o0700))
+
+	args := szargs.New("", []string{"prg", "--latest", cfgFile, "docs"})
+	outText, err := undelete.Process(context.Background(), args)
+	chk.NoErr(err)
+
+	chk.StrSlice(
+		strings.Split(outText, "\n"),
+		[]string{
+			"undelete successful",
+			"",
+			header,
+			row(names[0], "1", "docs/old/a.txt"),
+			"",
+		},
+	)
+}
+
+func TestUndeleteProcess_Structured(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	cfgFile, names := setup(chk)
+
+	chk.NoErr(out.SetFormat(out.FormatCSV))
+
+	args := szargs.New("", []string{"prg", cfgFile})
+	outText, err := undelete.Process(context.Background(), args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"snapshot,size,path,restored\n"+
+			names[1]+",11,docs/gone.txt,false\n"+
+			names[1]+",,docs/old/,false\n"+
+			names[0]+",5,top.txt,false\n",
+	)
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	args = szargs.New("", []string{"prg", "--latest", cfgFile, "docs"})
+	outText, err = undelete.Process(context.Background(), args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"{\n"+
+			"  \"dryRun\": false,\n"+
+			"  \"deleted\": [\n"+
+			"    {\n"+
+			"      \"path\": \"docs/old/a.txt\",\n"+
+			"      \"snapshot\": \""+names[0]+"\",\n"+
+			"      \"size\": 1\n"+
+			"    }\n"+
+			"  ],\n"+
+			"  \"restored\": []\n"+
+			"}\n",
+	)
+}
+
+func TestUndeleteProcess_InvalidArgs(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	cfgFile, _ := setup(chk)
+
+	args := szargs.New("", []string{"prg", "--all", "-r", "a", cfgFile})
+	_, err := undelete.Process(context.Background(), args)
+	chk.Err(
+		err,
+		undelete.ErrUndeleteError.Error()+": "+
+			undelete.ErrRestoreUsage.Error(),
+	)
+
+	args = szargs.New("", []string{"prg", "-r", "docs/keep.txt", cfgFile})
+	_, err = undelete.Process(context.Background(), args)
+	chk.Err(
+		err,
+		undelete.ErrUndeleteError.Error()+": "+
+			undelete.ErrNotDeleted.Error()+": 'docs/keep.txt'",
+	)
+
+	args = szargs.New("", []string{"prg", cfgFile, "docs/keep.txt"})
+	_, err = undelete.Process(context.Background(), args)
+	chk.Err(
+		err,
+		undelete.ErrUndeleteError.Error()+": "+
+			undelete.ErrNoneDeleted.Error(),
+	)
+}
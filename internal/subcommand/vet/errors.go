@@ -23,4 +23,5 @@ import "errors"
 // Vet errors.
 var (
 	ErrVetError = errors.New("vet error")
+	ErrProblems = errors.New("problems found")
 )
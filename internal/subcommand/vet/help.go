@@ -22,7 +22,9 @@ package vet
 const HelpText = `{v | vet} ` +
 	`config.szb
 
-Loads and parses the named configuration files reporting any issues.
+Loads and parses the named configuration files reporting any issues.  With
+structured output (--output json or csv) every problem is reported with its
+line number instead of stopping at the first one.
 
    config.sbc
       the backup configuration file defining the backup.
@@ -20,12 +20,40 @@ package vet
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/dancsecs/szargs"
+	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/settings"
 )
 
-func parseArguments(args *szargs.Args) error {
+// Result is the structured outcome of vetting a configuration file.
+type Result struct {
+	File     string             `json:"file"`
+	Valid    bool               `json:"valid"`
+	Problems []settings.Problem `json:"problems"`
+}
+
+// Header implements out.Tabular.
+func (r Result) Header() []string {
+	return []string{"line", "error", "text"}
+}
+
+// Rows implements out.Tabular.
+func (r Result) Rows() [][]string {
+	rows := make([][]string, 0, len(r.Problems))
+
+	for _, problem := range r.Problems {
+		rows = append(rows, []string{
+			strconv.Itoa(problem.Line), problem.Error, problem.Text,
+		})
+	}
+
+	return rows
+}
+
+func parseArguments(args *szargs.Args) (string, error) {
 	var (
 		configFileName string
 		err            error
@@ -35,16 +63,61 @@ func parseArguments(args *szargs.Args) error {
 	args.Done()
 	err = args.Err()
 
+	return configFileName, err //nolint:wrapcheck // Ok.
+}
+
+// vetAll reports every problem in the configuration file.
+func vetAll(configFileName string) (string, error) {
+	var (
+		fileData []byte
+		result   = Result{File: configFileName}
+		report   string
+		err      error
+	)
+
+	//nolint:gosec // Ok.
+	fileData, err = os.ReadFile(configFileName)
+
 	if err == nil {
-		_, err = settings.Load(configFileName)
+		result.Problems = settings.Vet(string(fileData))
+		result.Valid = len(result.Problems) == 0
+
+		if result.Problems == nil {
+			result.Problems = []settings.Problem{}
+		}
+
+		report, err = out.Result(result)
+	}
+
+	if err == nil && !result.Valid {
+		err = fmt.Errorf("%w: %d", ErrProblems, len(result.Problems))
 	}
 
-	return err //nolint:wrapcheck // Ok.
+	return report, err
 }
 
 // Process parses the remaining arguments deleting previous backups.
 func Process(args *szargs.Args) (string, error) {
-	err := parseArguments(args)
+	var (
+		configFileName string
+		report         string
+		err            error
+	)
+
+	configFileName, err = parseArguments(args)
+
+	if err == nil && out.Structured() {
+		report, err = vetAll(configFileName)
+		if err == nil {
+			return report, nil
+		}
+
+		return report, fmt.Errorf("%w: %w", ErrVetError, err)
+	}
+
+	if err == nil {
+		_, err = settings.Load(configFileName)
+	}
 
 	if err == nil {
 		return "vet successful (no problems found)\n", nil
@@ -21,11 +21,13 @@ package vet_test
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/dancsecs/szargs"
 	"github.com/dancsecs/szbck/internal/directory"
+	"github.com/dancsecs/szbck/internal/out"
 	"github.com/dancsecs/szbck/internal/settings"
 	"github.com/dancsecs/szbck/internal/subcommand/vet"
 	"github.com/dancsecs/sztest"
@@ -132,3 +134,110 @@ func TestVet_Process_Valid(t *testing.T) {
 	chk.NoErr(err)
 	chk.Str(outText, "vet successful (no problems found)\n")
 }
+
+// sourceLine returns the line number of the source in the config file.
+func sourceLine(chk *sztest.Chk, cfgFile string) int {
+	chk.T().Helper()
+
+	data, err := os.ReadFile(cfgFile)
+	chk.NoErr(err)
+
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "source:") {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+func TestVet_Process_JSON(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	chk.NoErr(out.SetFormat(out.FormatJSON))
+
+	cfgFile := setupBackupConfig(chk, false)
+
+	args := szargs.New("", []string{"prg", cfgFile})
+	outText, err := vet.Process(args)
+	chk.NoErr(err)
+	chk.Str(
+		outText,
+		""+
+			"{\n"+
+			"  \"file\": \""+cfgFile+"\",\n"+
+			"  \"valid\": true,\n"+
+			"  \"problems\": []\n"+
+			"}\n",
+	)
+
+	cfgFile = setupBackupConfig(chk, true)
+
+	args = szargs.New("", []string{"prg", cfgFile})
+	outText, err = vet.Process(args)
+	chk.Err(
+		err,
+		""+
+			vet.ErrVetError.Error()+
+			": "+
+			vet.ErrProblems.Error()+
+			": 2",
+	)
+	chk.Str(
+		outText,
+		""+
+			"{\n"+
+			"  \"file\": \""+cfgFile+"\",\n"+
+			"  \"valid\": false,\n"+
+			"  \"problems\": [\n"+
+			"    {\n"+
+			"      \"line\": "+strconv.Itoa(sourceLine(chk, cfgFile))+",\n"+
+			"      \"text\": \"source: /home/DOES_NOT_EXIST\",\n"+
+			"      \"error\": \""+settings.ErrSource.Error()+": "+
+			directory.ErrInvalid.Error()+": '/home/DOES_NOT_EXIST'\"\n"+
+			"    },\n"+
+			"    {\n"+
+			"      \"line\": 0,\n"+
+			"      \"error\": \""+settings.ErrUndefined.Error()+": "+
+			settings.ErrSourceMissing.Error()+"\"\n"+
+			"    }\n"+
+			"  ]\n"+
+			"}\n",
+	)
+}
+
+func TestVet_Process_CSV(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	defer func() { chk.NoErr(out.SetFormat(out.FormatText)) }()
+
+	chk.NoErr(out.SetFormat(out.FormatCSV))
+
+	cfgFile := setupBackupConfig(chk, true)
+
+	args := szargs.New("", []string{"prg", cfgFile})
+	outText, err := vet.Process(args)
+	chk.Err(
+		err,
+		""+
+			vet.ErrVetError.Error()+
+			": "+
+			vet.ErrProblems.Error()+
+			": 2",
+	)
+	chk.Str(
+		outText,
+		""+
+			"line,error,text\n"+
+			strconv.Itoa(sourceLine(chk, cfgFile))+","+
+			settings.ErrSource.Error()+": "+
+			directory.ErrInvalid.Error()+": '/home/DOES_NOT_EXIST',"+
+			"source: /home/DOES_NOT_EXIST\n"+
+			"0,"+settings.ErrUndefined.Error()+": "+
+			settings.ErrSourceMissing.Error()+",\n",
+	)
+}
@@ -32,4 +32,16 @@ var (
 	ErrHasLatest           = errors.New("has latest failed")
 	ErrSplitNotFound       = errors.New("split not found")
 	ErrInvalidSplit        = errors.New("invalid directory split")
+	ErrMarkFailed          = errors.New("could not mark snapshot failed")
+	ErrInvalidTag          = errors.New("invalid tag symlink")
+	ErrTags                = errors.New("could not list tags")
+	ErrSnapshots           = errors.New("could not list snapshots")
+	ErrSnapshotName        = errors.New("invalid snapshot name")
+	ErrSelect              = errors.New("could not select snapshot")
+	ErrInvalidSelector     = errors.New("invalid snapshot selector")
+	ErrInvalidDuration     = errors.New("invalid duration")
+	ErrNoSnapshot          = errors.New("no snapshot")
+	ErrVersions            = errors.New("could not list versions")
+	ErrNoVersions          = errors.New("no versions")
+	ErrInvalidVersion      = errors.New("invalid version")
 )
@@ -0,0 +1,312 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package target
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/directory"
+)
+
+// Selector formats accepted for absolute times.  A date without a time
+// selects the end of that day.
+const (
+	selectorDateTime        = "2006-01-02 15:04"
+	selectorDateTimeSeconds = "2006-01-02 15:04:05"
+	selectorDate            = "2006-01-02"
+)
+
+// Selector keywords.
+const (
+	SelectorNow       = "now"
+	SelectorYesterday = "yesterday"
+)
+
+// Relative selector constants.
+const (
+	hoursPerDay = 24
+	daysPerWeek = 7
+	minDuration = 2 // Amount and unit.
+)
+
+// Snapshots returns the names of the target's snapshot directories ordered
+// oldest first.  Failed snapshots are not included.
+func (target Path) Snapshots() ([]string, error) {
+	matches, err := filepath.Glob(
+		filepath.Join(target.path, "*"+BackupDirectoryExtension),
+	)
+
+	if err == nil {
+		names := make([]string, 0, len(matches))
+		for _, match := range matches {
+			names = append(names, filepath.Base(match))
+		}
+
+		slices.Sort(names)
+
+		return names, nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrSnapshots, err)
+}
+
+// SnapshotTime returns the time a snapshot was taken as encoded in its
+// directory name.
+func SnapshotTime(name string) (time.Time, error) {
+	stamp, found := strings.CutSuffix(
+		filepath.Base(name), BackupDirectoryExtension,
+	)
+
+	if found {
+		tme, err := time.ParseInLocation(
+			BackupDirectoryFormat, stamp, time.Local,
+		)
+		if err == nil {
+			return tme, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: '%s'", ErrSnapshotName, name)
+}
+
+// ParseDuration parses a duration such as 30m, 3h, 2d or 1w.
+func ParseDuration(spec string) (time.Duration, error) {
+	var (
+		amount int
+		unit   time.Duration
+		err    error
+	)
+
+	if len(spec) < minDuration {
+		err = ErrInvalidDuration
+	}
+
+	if err == nil {
+		switch spec[len(spec)-1] {
+		case 'm':
+			unit = time.Minute
+		case 'h':
+			unit = time.Hour
+		case 'd':
+			unit = time.Hour * hoursPerDay
+		case 'w':
+			unit = time.Hour * hoursPerDay * daysPerWeek
+		default:
+			err = ErrInvalidDuration
+		}
+	}
+
+	if err == nil {
+		// The amount must be unsigned.
+		amount, err = strconv.Atoi(spec[:len(spec)-1])
+		if err != nil || spec[0] < '0' || spec[0] > '9' {
+			err = ErrInvalidDuration
+		}
+	}
+
+	if err == nil {
+		return time.Duration(amount) * unit, nil
+	}
+
+	return 0, fmt.Errorf("%w: '%s'", err, spec)
+}
+
+// ParseMoment returns the moment identified by a time specification.  It
+// may be 'now', 'yesterday' (24 hours before now), a duration before now
+// (-30m, -3h, -2d, -1w) or an absolute local time formatted as
+// 'YYYY-MM-DD HH:MM[:SS]' or 'YYYY-MM-DD' (the end of that day).
+func ParseMoment(spec string, now time.Time) (time.Time, error) {
+	var (
+		moment time.Time
+		offset time.Duration
+		err    error
+	)
+
+	spec = strings.TrimSpace(spec)
+
+	switch {
+	case spec == SelectorNow:
+		moment = now
+	case spec == SelectorYesterday:
+		moment = now.Add(-time.Hour * hoursPerDay)
+	case strings.HasPrefix(spec, "-"):
+		offset, err = ParseDuration(spec[1:])
+		moment = now.Add(-offset)
+	default:
+		moment, err = time.ParseInLocation(
+			selectorDateTimeSeconds, strings.Replace(spec, "T", " ", 1),
+			time.Local,
+		)
+		if err != nil {
+			moment, err = time.ParseInLocation(
+				selectorDateTime, strings.Replace(spec, "T", " ", 1),
+				time.Local,
+			)
+		}
+
+		if err != nil {
+			moment, err = time.ParseInLocation(selectorDate, spec, time.Local)
+			moment = moment.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+
+	if err == nil {
+		return moment, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: '%s'", ErrInvalidSelector, spec)
+}
+
+// selectLatest resolves 'latest' (the snapshot the latest symbolic link
+// points at) or 'latest~N' (N snapshots before it) returning false if the
+// specification is not of that form.  Newer snapshot directories (IE: one
+// still being written) are never selected.
+func (target Path) selectLatest(
+	spec string, snapshots []string,
+) (string, bool, error) {
+	var (
+		backStr string
+		back    int
+		found   bool
+		latest  string
+		index   int
+		err     error
+	)
+
+	if spec != LatestDirectoryLink {
+		backStr, found = strings.CutPrefix(spec, LatestDirectoryLink+"~")
+		if !found {
+			return "", false, nil
+		}
+
+		back, err = strconv.Atoi(backStr)
+		if err != nil || back < 0 {
+			return "", true, fmt.Errorf("%w: '%s'", ErrInvalidSelector, spec)
+		}
+	}
+
+	latest, err = target.LatestSnapshot()
+	if err != nil {
+		return "", true, err
+	}
+
+	index = slices.Index(snapshots, latest)
+	if index < 0 {
+		return "", true, fmt.Errorf(
+			"%w: '%s': no latest snapshot", ErrNoSnapshot, spec,
+		)
+	}
+
+	if back > index {
+		return "", true, fmt.Errorf(
+			"%w: '%s': only %d snapshots",
+			ErrNoSnapshot, spec, index+1,
+		)
+	}
+
+	return snapshots[index-back], true, nil
+}
+
+// Select returns the name of the snapshot identified by the specification.
+// It may be an exact snapshot directory name, 'latest', 'latest~N' (N
+// snapshots before the latest) or any time specification accepted by
+// ParseMoment in which case the newest snapshot taken at or before that
+// moment is selected.
+func (target Path) Select(spec string, now time.Time) (string, error) {
+	var (
+		snapshots []string
+		selected  string
+		found     bool
+		moment    time.Time
+		taken     time.Time
+		err       error
+	)
+
+	snapshots, err = target.Snapshots()
+
+	if err == nil && slices.Contains(snapshots, spec) {
+		return spec, nil
+	}
+
+	if err == nil {
+		selected, found, err = target.selectLatest(spec, snapshots)
+	}
+
+	if err == nil && !found {
+		moment, err = ParseMoment(spec, now)
+
+		for i := len(snapshots) - 1; i >= 0 && err == nil && !found; i-- {
+			taken, err = SnapshotTime(snapshots[i])
+			if err == nil && !taken.After(moment) {
+				selected, found = snapshots[i], true
+			}
+		}
+
+		if err == nil && !found {
+			err = fmt.Errorf(
+				"%w: at or before %s",
+				ErrNoSnapshot, moment.Format(selectorDateTimeSeconds),
+			)
+		}
+	}
+
+	if err == nil {
+		return selected, nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrSelect, err)
+}
+
+// SelectPath resolves the snapshot selector leading a path within the target
+// (IE: yesterday/home/user) returning the path with the selector replaced by
+// the name of the snapshot it identifies.  Paths whose leading component
+// already names an entry in the target (a snapshot directory or latest) are
+// returned unchanged as are empty, relative ('.' or '..') and absolute paths.
+func (target Path) SelectPath(path string, now time.Time) (string, error) {
+	var (
+		spec     string
+		rest     string
+		selected string
+		err      error
+	)
+
+	spec, rest, _ = strings.Cut(path, directory.PathSeparator)
+
+	if spec == "" || spec == "." || spec == ".." {
+		return path, nil
+	}
+
+	_, err = os.Lstat(filepath.Join(target.path, spec))
+	if err == nil {
+		return path, nil
+	}
+
+	selected, err = target.Select(spec, now)
+	if err == nil {
+		return filepath.Join(selected, rest), nil
+	}
+
+	return "", err
+}
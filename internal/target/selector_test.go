@@ -0,0 +1,316 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package target_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztest"
+	"github.com/dancsecs/sztestlog"
+)
+
+func selectorTarget(chk *sztest.Chk, stamps ...time.Time) *target.Path {
+	dir := chk.CreateTmpDir()
+
+	trg, err := target.New(dir)
+	chk.NoErr(err)
+
+	for _, stamp := range stamps {
+		chk.NoErr(os.Mkdir(trg.SnapshotDir(stamp), 0o0700))
+	}
+
+	// Failed snapshots are never selected.
+	chk.NoErr(
+		os.Mkdir(
+			trg.SnapshotDir(time.Now())+target.FailedDirectoryExtension,
+			0o0700,
+		),
+	)
+
+	return trg
+}
+
+func TestTarget_SnapshotTime(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	tme, err := target.SnapshotTime("/a/b/20260101_093015.1234.szb")
+	chk.NoErr(err)
+	chk.True(
+		tme.Equal(
+			time.Date(2026, time.January, 1, 9, 30, 15, 123400000, time.Local),
+		),
+	)
+
+	_, err = target.SnapshotTime("20260101_093015.1234")
+	chk.Err(
+		err,
+		target.ErrSnapshotName.Error()+": '20260101_093015.1234'",
+	)
+
+	_, err = target.SnapshotTime("2026010_093015.1234.szb")
+	chk.Err(
+		err,
+		target.ErrSnapshotName.Error()+": '2026010_093015.1234.szb'",
+	)
+}
+
+func TestTarget_ParseMoment(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.Local)
+
+	tst := func(spec string, want time.Time) {
+		t.Helper()
+
+		moment, err := target.ParseMoment(spec, now)
+		chk.NoErr(err)
+		chk.True(moment.Equal(want), spec+": "+moment.String())
+	}
+
+	tst("now", now)
+	tst("yesterday", now.Add(-time.Hour*24))
+	tst("-30m", now.Add(-time.Minute*30))
+	tst("-3h", now.Add(-time.Hour*3))
+	tst("-2d", now.Add(-time.Hour*48))
+	tst("-1w", now.Add(-time.Hour*24*7))
+	tst("2026-10-01 14:00",
+		time.Date(2026, time.October, 1, 14, 0, 0, 0, time.Local),
+	)
+	tst("2026-10-01T14:00:30",
+		time.Date(2026, time.October, 1, 14, 0, 30, 0, time.Local),
+	)
+	tst("2026-10-01",
+		time.Date(2026, time.October, 2, 0, 0, 0, 0, time.Local).
+			Add(-time.Nanosecond),
+	)
+
+	for _, spec := range []string{"", "-3", "-h", "-3y", "--3h", "tomorrow"} {
+		_, err := target.ParseMoment(spec, now)
+		chk.Err(err, target.ErrInvalidSelector.Error()+": '"+spec+"'")
+	}
+}
+
+func TestTarget_ParseDuration(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	tst := func(spec string, want time.Duration) {
+		t.Helper()
+
+		duration, err := target.ParseDuration(spec)
+		chk.NoErr(err)
+		chk.Int64(int64(duration), int64(want), spec)
+	}
+
+	tst("0m", 0)
+	tst("30m", time.Minute*30)
+	tst("25h", time.Hour*25)
+	tst("2d", time.Hour*48)
+	tst("1w", time.Hour*24*7)
+
+	for _, spec := range []string{"", "3", "h", "3y", "-3h", "+3h", "3s"} {
+		_, err := target.ParseDuration(spec)
+		chk.Err(err, target.ErrInvalidDuration.Error()+": '"+spec+"'")
+	}
+}
+
+func TestTarget_Select(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.Local)
+	stamps := []time.Time{
+		now.Add(-time.Hour * 50),
+		now.Add(-time.Hour * 26),
+		now.Add(-time.Hour * 3),
+		now.Add(-time.Hour),
+	}
+	trg := selectorTarget(chk, stamps...)
+	chk.NoErr(trg.SetLatest(trg.SnapshotDir(stamps[3])))
+
+	name := func(i int) string {
+		return filepath.Base(trg.SnapshotDir(stamps[i]))
+	}
+
+	snapshots, err := trg.Snapshots()
+	chk.NoErr(err)
+	chk.StrSlice(snapshots, []string{name(0), name(1), name(2), name(3)})
+
+	tst := func(spec string, want int) {
+		t.Helper()
+
+		selected, err := trg.Select(spec, now)
+		chk.NoErr(err)
+		chk.Str(selected, name(want), spec)
+	}
+
+	tst(name(1), 1)
+	tst("latest", 3)
+	tst("latest~0", 3)
+	tst("latest~3", 0)
+	tst("now", 3)
+	tst("-1h", 3)
+	tst("-90m", 2)
+	tst("-3h", 2)
+	tst("yesterday", 1)
+	tst("-2d", 0)
+	tst(stamps[1].Format("2006-01-02 15:04:05"), 1)
+
+	_, err = trg.Select("latest~4", now)
+	chk.Err(
+		err,
+		""+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrNoSnapshot.Error()+
+			": 'latest~4': only 4 snapshots",
+	)
+
+	_, err = trg.Select("latest~x", now)
+	chk.Err(
+		err,
+		""+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrInvalidSelector.Error()+
+			": 'latest~x'",
+	)
+
+	_, err = trg.Select("-1w", now)
+	chk.Err(
+		err,
+		""+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrNoSnapshot.Error()+
+			": at or before "+
+			now.Add(-time.Hour*24*7).Format("2006-01-02 15:04:05"),
+	)
+
+	_, err = trg.Select("whenever", now)
+	chk.Err(
+		err,
+		""+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrInvalidSelector.Error()+
+			": 'whenever'",
+	)
+}
+
+func TestTarget_Select_Unfinished(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.Local)
+	stamps := []time.Time{
+		now.Add(-time.Hour * 2),
+		now.Add(-time.Hour),
+		now, // Still being written.
+	}
+	trg := selectorTarget(chk, stamps...)
+
+	name := func(i int) string {
+		return filepath.Base(trg.SnapshotDir(stamps[i]))
+	}
+
+	_, err := trg.Select("latest", now)
+	chk.Err(
+		err,
+		""+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrNoSnapshot.Error()+
+			": 'latest': no latest snapshot",
+	)
+
+	chk.NoErr(trg.SetLatest(trg.SnapshotDir(stamps[1])))
+
+	selected, err := trg.Select("latest", now)
+	chk.NoErr(err)
+	chk.Str(selected, name(1))
+
+	selected, err = trg.Select("latest~1", now)
+	chk.NoErr(err)
+	chk.Str(selected, name(0))
+
+	_, err = trg.Select("latest~2", now)
+	chk.Err(
+		err,
+		""+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrNoSnapshot.Error()+
+			": 'latest~2': only 2 snapshots",
+	)
+
+	// The unfinished snapshot may still be selected by name.
+	selected, err = trg.Select(name(2), now)
+	chk.NoErr(err)
+	chk.Str(selected, name(2))
+}
+
+func TestTarget_SelectPath(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.Local)
+	stamps := []time.Time{
+		now.Add(-time.Hour * 26),
+		now.Add(-time.Hour),
+	}
+	trg := selectorTarget(chk, stamps...)
+	chk.NoErr(trg.SetLatest(trg.SnapshotDir(stamps[1])))
+
+	older := filepath.Base(trg.SnapshotDir(stamps[0]))
+	newer := filepath.Base(trg.SnapshotDir(stamps[1]))
+
+	tst := func(path, want string) {
+		t.Helper()
+
+		selected, err := trg.SelectPath(path, now)
+		chk.NoErr(err)
+		chk.Str(selected, want, path)
+	}
+
+	tst("", "")
+	tst(".", ".")
+	tst("/abs/path", "/abs/path")
+	tst("latest/source/dir", "latest/source/dir")
+	tst(older+"/source", older+"/source")
+	tst("latest~1/source/dir", filepath.Join(older, "source", "dir"))
+	tst("yesterday/source", filepath.Join(older, "source"))
+	tst("-30m", newer)
+
+	_, err := trg.SelectPath("-3y/source", now)
+	chk.Err(
+		err,
+		""+
+			target.ErrSelect.Error()+
+			": "+
+			target.ErrInvalidSelector.Error()+
+			": '-3y'",
+	)
+}
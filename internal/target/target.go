@@ -38,6 +38,14 @@ const (
 	// BackupDirectoryExtension identifies a directory as a Szerszam backup
 	// snapshot.
 	BackupDirectoryExtension = ".szb"
+	// FailedDirectoryExtension is appended to a snapshot directory that did
+	// not complete successfully.
+	FailedDirectoryExtension = ".failed"
+	// JournalFile names the file recording the outcome of each snapshot.
+	JournalFile = "szbck.journal"
+	// PreRestoreTag names the link pointing to the safety snapshot taken
+	// before the source was last overwritten by a restore.
+	PreRestoreTag = "pre-restore"
 )
 
 // Path represent the directory containing the szerszam backup.
@@ -88,8 +96,36 @@ func (target Path) HasLatest() (bool, error) {
 	return false, fmt.Errorf("%w: %w", ErrHasLatest, err)
 }
 
+// LatestSnapshot returns the name of the snapshot the latest symbolic link
+// points at or an empty string if there is none.
+func (target Path) LatestSnapshot() (string, error) {
+	var (
+		hasLatest bool
+		latestDir string
+		err       error
+	)
+
+	hasLatest, err = target.HasLatest()
+
+	if err == nil && hasLatest {
+		latestDir, err = filepath.EvalSymlinks(target.Latest())
+	}
+
+	if err == nil && hasLatest {
+		return filepath.Base(latestDir), nil
+	}
+
+	return "", err //nolint:wrapcheck // Ok.
+}
+
+// Journal returns the path to the target's snapshot journal.
+func (target Path) Journal() string {
+	return filepath.Join(target.path, JournalFile)
+}
+
 // Validate insures that the target is a directory that either has a 'latest'
-// symlink pointing to a backup set or the directory is empty.
+// symlink pointing to a backup set or the directory is empty (disregarding
+// any failed snapshots and the journal).
 func (target Path) Validate() error {
 	var (
 		hasLatest bool
@@ -103,7 +139,9 @@ func (target Path) Validate() error {
 	}
 
 	if err == nil && !hasLatest {
-		err = directory.IsEmpty(target.path)
+		err = directory.IsEmpty(
+			target.path, FailedDirectoryExtension, JournalFile,
+		)
 	}
 
 	if err == nil {
@@ -113,6 +151,15 @@ func (target Path) Validate() error {
 	return fmt.Errorf("%w: %w", ErrInvalid, err)
 }
 
+// SnapshotDir returns the path of the snapshot directory for the provided
+// date/time without creating it.
+func (target Path) SnapshotDir(tme time.Time) string {
+	return filepath.Join(
+		target.path,
+		tme.Format(BackupDirectoryFormat)+BackupDirectoryExtension,
+	)
+}
+
 // Create a new target directory based on the provided date/time.
 func (target Path) Create(tme time.Time, perm os.FileMode) (string, error) {
 	var (
@@ -123,10 +170,7 @@ func (target Path) Create(tme time.Time, perm os.FileMode) (string, error) {
 	err = target.Validate()
 
 	if err == nil {
-		newDir = filepath.Join(
-			target.path,
-			tme.Format(BackupDirectoryFormat)+BackupDirectoryExtension,
-		)
+		newDir = target.SnapshotDir(tme)
 
 		_, err = os.Stat(newDir)
 		if err == nil {
@@ -147,6 +191,93 @@ func (target Path) Create(tme time.Time, perm os.FileMode) (string, error) {
 	return "", fmt.Errorf("%w: %w", ErrCreateTargetFailed, err)
 }
 
+// MarkFailed renames the snapshot directory identifying it as failed so that
+// it is no longer treated as a snapshot.  The new name is returned.
+func (target Path) MarkFailed(dir string) (string, error) {
+	failedDir := dir + FailedDirectoryExtension
+
+	err := os.Rename(dir, failedDir)
+	if err == nil {
+		return failedDir, nil
+	}
+
+	return "", fmt.Errorf("%w: %w", ErrMarkFailed, err)
+}
+
+// Tag returns the path to the link named by the tag.
+func (target Path) Tag(tag string) string {
+	return filepath.Join(target.path, tag)
+}
+
+// HasTag returns true if the link named by the tag points at an existing
+// snapshot.
+func (target Path) HasTag(tag string) bool {
+	info, err := os.Stat(target.Tag(tag))
+
+	return err == nil && info.IsDir()
+}
+
+// SetTag creates a symbolic link named by the tag pointing to the supplied
+// backup directory.
+func (target Path) SetTag(tag, path string) error {
+	err := directory.LinkRelative(path, target.Tag(tag))
+
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: '%s': %w", ErrInvalidTag, tag, err)
+}
+
+// Pins returns the tags pinning each snapshot keyed by the snapshot's
+// directory name.  Every symbolic link in the target other than latest and
+// the automatic pre-restore tag is a tag created by the user.  Tags that do
+// not point at a snapshot (IE: its snapshot was removed) pin nothing.
+func (target Path) Pins() (map[string][]string, error) {
+	var (
+		entries  []os.DirEntry
+		trgPath  string
+		resolved string
+		pins     = make(map[string][]string)
+		err      error
+	)
+
+	trgPath, err = filepath.EvalSymlinks(target.path)
+
+	if err == nil {
+		entries, err = os.ReadDir(trgPath)
+	}
+
+	for i := 0; i < len(entries) && err == nil; i++ {
+		if entries[i].Type()&os.ModeSymlink == 0 ||
+			entries[i].Name() == LatestDirectoryLink ||
+			entries[i].Name() == PreRestoreTag {
+			continue
+		}
+
+		resolved, err = filepath.EvalSymlinks(target.Tag(entries[i].Name()))
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+
+			continue
+		}
+
+		if err == nil &&
+			filepath.Dir(resolved) == trgPath &&
+			strings.HasSuffix(resolved, BackupDirectoryExtension) {
+			pins[filepath.Base(resolved)] = append(
+				pins[filepath.Base(resolved)], entries[i].Name(),
+			)
+		}
+	}
+
+	if err == nil {
+		return pins, nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrTags, err)
+}
+
 // SetLatest create a symbolic link to the supplied backup directory.
 func (target Path) SetLatest(path string) error {
 	err := directory.LinkRelative(path, target.Latest())
@@ -19,6 +19,7 @@
 package target_test
 
 import (
+	"os"
 	"path/filepath"
 	"regexp"
 	"testing"
@@ -279,3 +280,154 @@ func TestDirectory_Split(t *testing.T) {
 	chk.Str(pre, filepath.Join(dir, "abc"))
 	chk.Str(post, "def/ghi")
 }
+
+func TestConfigBackup_SnapshotDir(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+	trg, err := target.New(dir)
+	chk.NoErr(err)
+
+	tme := time.Date(2025, time.May, 2, 3, 4, 5, 333999000, time.Local)
+
+	chk.Str(
+		trg.SnapshotDir(tme),
+		filepath.Join(dir, "20250502_030405.3339.szb"),
+	)
+
+	// Not created.
+	chk.Err(
+		directory.Is(trg.SnapshotDir(tme)),
+		""+
+			directory.ErrInvalid.Error()+
+			": '"+filepath.Join(dir, "20250502_030405.3339.szb")+"'"+
+			"",
+	)
+}
+
+func TestConfigBackup_MarkFailed(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+	trg, err := target.New(dir)
+	chk.NoErr(err)
+
+	tme := time.Date(2025, time.May, 2, 3, 4, 5, 333999000, time.Local)
+
+	path, err := trg.Create(tme, 0o0700)
+	chk.NoErr(err)
+
+	failedPath, err := trg.MarkFailed(path)
+	chk.NoErr(err)
+	chk.Str(failedPath, path+target.FailedDirectoryExtension)
+
+	// Failed snapshots do not count when validating a new target.
+	chk.NoErr(trg.Validate())
+
+	failedPath, err = trg.MarkFailed(path)
+	chk.Err(
+		err,
+		""+
+			target.ErrMarkFailed.Error()+
+			": rename "+path+" "+path+target.FailedDirectoryExtension+
+			": no such file or directory"+
+			"",
+	)
+	chk.Str(failedPath, "")
+}
+
+func TestConfigBackup_Journal(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+	trg, err := target.New(dir)
+	chk.NoErr(err)
+
+	chk.Str(trg.Journal(), filepath.Join(dir, target.JournalFile))
+
+	// The journal does not count when validating a new target.
+	chk.NoErr(os.WriteFile(trg.Journal(), []byte("{}\n"), 0o0600))
+	chk.NoErr(trg.Validate())
+}
+
+func TestConfigBackup_SetTag(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+	trg, err := target.New(dir)
+	chk.NoErr(err)
+
+	chk.Str(
+		trg.Tag(target.PreRestoreTag),
+		filepath.Join(dir, target.PreRestoreTag),
+	)
+	chk.False(trg.HasTag(target.PreRestoreTag))
+
+	backupDir := trg.SnapshotDir(time.Now())
+
+	chk.Err(
+		trg.SetTag(target.PreRestoreTag, backupDir),
+		""+
+			target.ErrInvalidTag.Error()+
+			": '"+target.PreRestoreTag+"': "+
+			directory.ErrCreateLink.Error()+
+			": (from: '"+backupDir+
+			"' to: '"+
+			trg.Tag(target.PreRestoreTag)+"'): "+
+			directory.ErrInvalid.Error()+
+			": '"+backupDir+
+			"'"+
+			"",
+	)
+
+	chk.NoErr(os.Mkdir(backupDir, 0o0700))
+	chk.NoErr(trg.SetTag(target.PreRestoreTag, backupDir))
+	chk.True(trg.HasTag(target.PreRestoreTag))
+
+	// A tag pointing at a removed snapshot is not usable.
+	chk.NoErr(os.Remove(backupDir))
+	chk.False(trg.HasTag(target.PreRestoreTag))
+}
+
+func TestConfigBackup_Pins(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	dir := chk.CreateTmpDir()
+	trg, err := target.New(dir)
+	chk.NoErr(err)
+
+	pins, err := trg.Pins()
+	chk.NoErr(err)
+	chk.Int(len(pins), 0)
+
+	tme := time.Now()
+	pinnedDir := trg.SnapshotDir(tme)
+	removedDir := trg.SnapshotDir(tme.Add(time.Second))
+	latestDir := trg.SnapshotDir(tme.Add(time.Second * 2))
+
+	for _, snapshotDir := range []string{pinnedDir, removedDir, latestDir} {
+		chk.NoErr(os.Mkdir(snapshotDir, 0o0700))
+	}
+
+	chk.NoErr(trg.SetTag(target.PreRestoreTag, pinnedDir))
+	chk.NoErr(trg.SetTag("release", pinnedDir))
+	chk.NoErr(trg.SetTag("removed", removedDir))
+	chk.NoErr(trg.SetLatest(latestDir))
+	chk.NoErr(os.Remove(removedDir))
+
+	// Links outside of the snapshots are not tags.
+	chk.NoErr(os.Symlink(chk.CreateTmpDir(), filepath.Join(dir, "other")))
+
+	pins, err = trg.Pins()
+	chk.NoErr(err)
+	chk.Int(len(pins), 1)
+	chk.StrSlice(
+		pins[filepath.Base(pinnedDir)],
+		[]string{"release"},
+	)
+}
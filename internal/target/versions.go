@@ -0,0 +1,132 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package target
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// Version is a copy of an item held unchanged by one or more consecutive
+// snapshots.  Unchanged files are hard linked between snapshots so each
+// version is a distinct inode.
+type Version struct {
+	// Number of the version counting from 1 for the oldest.
+	Number int
+	// First snapshot holding the version.
+	First string
+	// Last snapshot holding the version.
+	Last string
+	// Size of the version.
+	Size int64
+	// ModTime is the version's modification time.
+	ModTime time.Time
+	// Mode is the version's type and permissions.
+	Mode fs.FileMode
+}
+
+// inode returns the device and inode of the item.
+func inode(info fs.FileInfo) (uint64, uint64) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Dev, stat.Ino
+	}
+
+	return 0, 0
+}
+
+// Versions returns the versions of the item (a path relative to the
+// snapshot directories) oldest first.  Consecutive snapshots holding the
+// same inode are grouped into a single version.  A snapshot missing the
+// item ends the version.
+func (target Path) Versions(path string) ([]Version, error) {
+	var (
+		snapshots []string
+		versions  []Version
+		info      fs.FileInfo
+		lastDev   uint64
+		lastIno   uint64
+		extends   bool
+		err       error
+	)
+
+	snapshots, err = target.Snapshots()
+
+	for i := 0; i < len(snapshots) && err == nil; i++ {
+		info, err = os.Lstat(filepath.Join(target.path, snapshots[i], path))
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+			extends = false
+
+			continue
+		}
+
+		if err == nil {
+			dev, ino := inode(info)
+
+			if extends && dev == lastDev && ino == lastIno && ino != 0 {
+				versions[len(versions)-1].Last = snapshots[i]
+
+				continue
+			}
+
+			versions = append(versions, Version{
+				Number:  len(versions) + 1,
+				First:   snapshots[i],
+				Last:    snapshots[i],
+				Size:    info.Size(),
+				ModTime: info.ModTime(),
+				Mode:    info.Mode(),
+			})
+			lastDev, lastIno, extends = dev, ino, true
+		}
+	}
+
+	if err == nil && len(versions) == 0 {
+		err = fmt.Errorf("%w: '%s'", ErrNoVersions, path)
+	}
+
+	if err == nil {
+		return versions, nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrVersions, err)
+}
+
+// Version returns the numbered version of the item (see Versions).
+func (target Path) Version(path string, number int) (Version, error) {
+	versions, err := target.Versions(path)
+
+	if err == nil && (number < 1 || number > len(versions)) {
+		err = fmt.Errorf(
+			"%w: '%d': %d versions of '%s'",
+			ErrInvalidVersion, number, len(versions), path,
+		)
+	}
+
+	if err == nil {
+		return versions[number-1], nil
+	}
+
+	return Version{}, err
+}
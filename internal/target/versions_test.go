@@ -0,0 +1,94 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package target_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/target"
+	"github.com/dancsecs/sztestlog"
+)
+
+func TestTarget_Versions(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	trg, err := target.New(chk.CreateTmpDir())
+	chk.NoErr(err)
+
+	taken := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.Local)
+	names := make([]string, 0, 5)
+
+	for i := range 5 {
+		dir := trg.SnapshotDir(taken.Add(time.Hour * time.Duration(i)))
+		names = append(names, filepath.Base(dir))
+		chk.NoErr(os.MkdirAll(filepath.Join(dir, "source"), 0o0700))
+	}
+
+	file := func(name string) string {
+		return filepath.Join(trg.GetPath(), name, "source", "file.txt")
+	}
+
+	// Version 1 held by the first two snapshots, version 2 by the third,
+	// missing from the fourth and version 3 (unchanged content) by the last.
+	chk.NoErr(os.WriteFile(file(names[0]), []byte("one"), 0o0600))
+	chk.NoErr(os.Link(file(names[0]), file(names[1])))
+	chk.NoErr(os.WriteFile(file(names[2]), []byte("two!"), 0o0640))
+	chk.NoErr(os.Link(file(names[2]), file(names[4])))
+
+	versions, err := trg.Versions("source/file.txt")
+	chk.NoErr(err)
+	chk.Int(len(versions), 3)
+
+	chk.Int(versions[0].Number, 1)
+	chk.Str(versions[0].First, names[0])
+	chk.Str(versions[0].Last, names[1])
+	chk.Int(int(versions[0].Size), 3)
+	chk.Str(versions[0].Mode.String(), "-rw-------")
+
+	chk.Str(versions[1].First, names[2])
+	chk.Str(versions[1].Last, names[2])
+	chk.Int(int(versions[1].Size), 4)
+	chk.Str(versions[1].Mode.String(), "-rw-r-----")
+
+	chk.Int(versions[2].Number, 3)
+	chk.Str(versions[2].First, names[4])
+	chk.Str(versions[2].Last, names[4])
+
+	version, err := trg.Version("source/file.txt", 2)
+	chk.NoErr(err)
+	chk.Str(version.First, names[2])
+
+	_, err = trg.Version("source/file.txt", 4)
+	chk.Err(
+		err,
+		target.ErrInvalidVersion.Error()+
+			": '4': 3 versions of 'source/file.txt'",
+	)
+
+	_, err = trg.Versions("source/missing.txt")
+	chk.Err(
+		err,
+		target.ErrVersions.Error()+": "+
+			target.ErrNoVersions.Error()+": 'source/missing.txt'",
+	)
+}
@@ -0,0 +1,152 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package wait
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const hoursPerDay = 24
+
+// Window defines a daily time span during which an rsync bandwidth limit
+// applies.  A window whose end is before its start wraps past midnight.
+type Window struct {
+	// Start and End are offsets from midnight.  Start is inclusive and End
+	// exclusive.
+	Start time.Duration
+	End   time.Duration
+	// Limit is the rsync --bwlimit value (IE: 5M).
+	Limit string
+}
+
+// parseClock parses a time of day formatted as HH:MM (00:00-24:00) returning
+// the offset from midnight.
+func parseClock(clock string) (time.Duration, error) {
+	const maxMinute = 59
+
+	var (
+		hourStr   string
+		minuteStr string
+		hour      int
+		minute    int
+		found     bool
+		err       error
+	)
+
+	hourStr, minuteStr, found = strings.Cut(clock, ":")
+	if !found || len(hourStr) != 2 || len(minuteStr) != 2 {
+		err = ErrInvalidClock
+	}
+
+	if err == nil {
+		hour, err = strconv.Atoi(hourStr)
+	}
+
+	if err == nil {
+		minute, err = strconv.Atoi(minuteStr)
+	}
+
+	if err == nil &&
+		(hour < 0 || hour > hoursPerDay || minute < 0 || minute > maxMinute ||
+			(hour == hoursPerDay && minute != 0)) {
+		err = ErrInvalidClock
+	}
+
+	if err == nil {
+		return time.Duration(hour)*time.Hour +
+			time.Duration(minute)*time.Minute, nil
+	}
+
+	return 0, fmt.Errorf("%w: '%s'", ErrInvalidClock, clock)
+}
+
+// NewWindow returns a window applying the limit over the span formatted as
+// HH:MM-HH:MM (IE: 09:00-18:00).
+func NewWindow(span, limit string) (Window, error) {
+	var (
+		window   Window
+		startStr string
+		endStr   string
+		found    bool
+		err      error
+	)
+
+	startStr, endStr, found = strings.Cut(span, "-")
+	if !found {
+		err = fmt.Errorf("%w: '%s'", ErrInvalidSpan, span)
+	}
+
+	if err == nil {
+		window.Start, err = parseClock(startStr)
+	}
+
+	if err == nil {
+		window.End, err = parseClock(endStr)
+	}
+
+	if err == nil && window.Start == window.End {
+		err = fmt.Errorf("%w: '%s'", ErrInvalidSpan, span)
+	}
+
+	if err == nil {
+		window.Limit = limit
+
+		return window, nil
+	}
+
+	return Window{}, err
+}
+
+// Contains returns true if the time of day falls within the window.
+func (w Window) Contains(at time.Time) bool {
+	offset := time.Duration(at.Hour())*time.Hour +
+		time.Duration(at.Minute())*time.Minute +
+		time.Duration(at.Second())*time.Second
+
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+
+	return offset >= w.Start || offset < w.End
+}
+
+// String returns the window formatted as it appears in a configuration file.
+func (w Window) String() string {
+	return fmt.Sprintf(
+		"%02d:%02d-%02d:%02d %s",
+		int(w.Start/time.Hour), int(w.Start%time.Hour/time.Minute),
+		int(w.End/time.Hour), int(w.End%time.Hour/time.Minute),
+		w.Limit,
+	)
+}
+
+// BandwidthLimit returns the limit of the first window containing the time
+// of day or an empty string if the bandwidth is unlimited.
+func BandwidthLimit(schedule []Window, at time.Time) string {
+	for _, window := range schedule {
+		if window.Contains(at) {
+			return window.Limit
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,104 @@
+/*
+   Golang rsync backup utility wrapper: szbck.
+   Copyright (C) 2026 Leslie Dancsecs
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dancsecs/szbck/internal/wait"
+	"github.com/dancsecs/sztestlog"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, time.May, 15, hour, minute, 0, 0, time.Local)
+}
+
+func TestWaitBandwidth_NewWindowErrors(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	_, err := wait.NewWindow("09:00", "5M")
+	chk.Err(err, wait.ErrInvalidSpan.Error()+": '09:00'")
+
+	_, err = wait.NewWindow("9:00-18:00", "5M")
+	chk.Err(err, wait.ErrInvalidClock.Error()+": '9:00'")
+
+	_, err = wait.NewWindow("09:00-18:60", "5M")
+	chk.Err(err, wait.ErrInvalidClock.Error()+": '18:60'")
+
+	_, err = wait.NewWindow("24:01-18:00", "5M")
+	chk.Err(err, wait.ErrInvalidClock.Error()+": '24:01'")
+
+	_, err = wait.NewWindow("0a:00-18:00", "5M")
+	chk.Err(err, wait.ErrInvalidClock.Error()+": '0a:00'")
+
+	_, err = wait.NewWindow("09:00-09:00", "5M")
+	chk.Err(err, wait.ErrInvalidSpan.Error()+": '09:00-09:00'")
+}
+
+func TestWaitBandwidth_Window(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	window, err := wait.NewWindow("09:00-18:00", "5M")
+	chk.NoErr(err)
+	chk.Str(window.String(), "09:00-18:00 5M")
+
+	chk.False(window.Contains(at(8, 59)))
+	chk.True(window.Contains(at(9, 0)))
+	chk.True(window.Contains(at(17, 59)))
+	chk.False(window.Contains(at(18, 0)))
+
+	window, err = wait.NewWindow("22:30-06:00", "1M")
+	chk.NoErr(err)
+	chk.Str(window.String(), "22:30-06:00 1M")
+
+	chk.False(window.Contains(at(22, 29)))
+	chk.True(window.Contains(at(22, 30)))
+	chk.True(window.Contains(at(0, 0)))
+	chk.True(window.Contains(at(5, 59)))
+	chk.False(window.Contains(at(6, 0)))
+
+	window, err = wait.NewWindow("00:00-24:00", "100K")
+	chk.NoErr(err)
+	chk.True(window.Contains(at(0, 0)))
+	chk.True(window.Contains(at(23, 59)))
+}
+
+func TestWaitBandwidth_Limit(t *testing.T) {
+	chk := sztestlog.CaptureNothing(t)
+	defer chk.Release()
+
+	chk.Str(wait.BandwidthLimit(nil, at(12, 0)), "")
+
+	workDay, err := wait.NewWindow("09:00-18:00", "5M")
+	chk.NoErr(err)
+
+	lunch, err := wait.NewWindow("12:00-13:00", "1M")
+	chk.NoErr(err)
+
+	schedule := []wait.Window{lunch, workDay}
+
+	chk.Str(wait.BandwidthLimit(schedule, at(8, 0)), "")
+	chk.Str(wait.BandwidthLimit(schedule, at(9, 0)), "5M")
+	chk.Str(wait.BandwidthLimit(schedule, at(12, 30)), "1M")
+	chk.Str(wait.BandwidthLimit(schedule, at(13, 0)), "5M")
+	chk.Str(wait.BandwidthLimit(schedule, at(18, 0)), "")
+}
@@ -18,5 +18,10 @@
 
 package wait
 
+import "errors"
+
 // waiting errors.
-var ()
+var (
+	ErrInvalidSpan  = errors.New("invalid time span")
+	ErrInvalidClock = errors.New("invalid time of day")
+)
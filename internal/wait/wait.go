@@ -19,6 +19,7 @@
 package wait
 
 import (
+	"context"
 	"time"
 
 	"github.com/dancsecs/szlog"
@@ -85,15 +86,23 @@ func chkIn(from time.Time, maxDur time.Duration) time.Duration {
 }
 
 // Until waits (sleeps) until the specified time displaying an updated
-// countdown if monitor is true.
-func Until(title string, monitor bool, targetTime time.Time) {
+// countdown if monitor is true.  The context's error is returned if it is
+// done before the time is reached.
+//
+//nolint:funlen // Ok.
+func Until(
+	ctx context.Context,
+	title string,
+	monitor bool,
+	targetTime time.Time,
+) error {
 	targetTimeStr := targetTime.Format("2006-01-02 15:04:05.999")
 
 	now := time.Now()
 	maxSleep := targetTime.Sub(now)
 
 	if maxSleep <= 0 {
-		return
+		return nil
 	}
 
 	szlog.Say0f(
@@ -115,7 +124,16 @@ func Until(title string, monitor bool, targetTime time.Time) {
 			)
 		}
 
-		time.Sleep(chkIn(now, maxSleep))
+		select {
+		case <-ctx.Done():
+			if monitor {
+				szlog.Say0f("\n")
+			}
+
+			return ctx.Err() //nolint:wrapcheck // Ok.
+		case <-time.After(chkIn(now, maxSleep)):
+		}
+
 		now = time.Now()
 		maxSleep = targetTime.Sub(now)
 	}
@@ -138,4 +156,6 @@ func Until(title string, monitor bool, targetTime time.Time) {
 			clearLine,
 		)
 	}
+
+	return nil
 }
@@ -19,6 +19,7 @@
 package wait_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -66,7 +67,9 @@ func TestSnapshotProcess_WaitTillNow(t *testing.T) {
 	chk := sztestlog.CaptureStdout(t)
 	defer chk.Release()
 
-	wait.Until("Timer Title Now", false, time.Now())
+	chk.NoErr(
+		wait.Until(context.Background(), "Timer Title Now", false, time.Now()),
+	)
 
 	chk.Stdout()
 }
@@ -75,10 +78,13 @@ func TestSnapshotProcess_WaitTill(t *testing.T) {
 	chk := sztestlog.CaptureStdout(t)
 	defer chk.Release()
 
-	wait.Until(
-		"Timer Title (500ms)",
-		false,
-		time.Now().Add(time.Millisecond*500),
+	chk.NoErr(
+		wait.Until(
+			context.Background(),
+			"Timer Title (500ms)",
+			false,
+			time.Now().Add(time.Millisecond*500),
+		),
 	)
 
 	chk.AddSub(`\-?\d[\d\,\.]*(?:s|ms|µs|ns)?`, "#")
@@ -95,10 +101,14 @@ func TestSnapshotProcess_WaitTillMonitor(t *testing.T) {
 	defer chk.Release()
 
 	startTime := time.Now()
-	wait.Until(
-		"Timer Title",
-		true,
-		time.Now().Add(time.Second+time.Millisecond*50))
+	chk.NoErr(
+		wait.Until(
+			context.Background(),
+			"Timer Title",
+			true,
+			time.Now().Add(time.Second+time.Millisecond*50),
+		),
+	)
 
 	numberOfMessages := 3
 
@@ -117,3 +127,29 @@ func TestSnapshotProcess_WaitTillMonitor(t *testing.T) {
 			clearLine,
 	)
 }
+
+func TestSnapshotProcess_WaitTillCanceled(t *testing.T) {
+	chk := sztestlog.CaptureStdout(t)
+	defer chk.Release()
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), time.Millisecond*50,
+	)
+	defer cancel()
+
+	startTime := time.Now()
+
+	chk.Err(
+		wait.Until(ctx, "Timer Title", true, time.Now().Add(time.Hour)),
+		context.DeadlineExceeded.Error(),
+	)
+
+	chk.True(time.Since(startTime) < time.Second*5)
+
+	chk.AddSub(`\-?\d[\d\,\.]*(?:s|ms|µs|ns)?`, "#")
+	chk.Stdout(
+		"" +
+			"Starting 'Timer Title' at ### #:#:# in: #m#" + clearLine + "\r" +
+			"Starting 'Timer Title' at ### #:#:# in: #m#" + clearLine + "\r",
+	)
+}